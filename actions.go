@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package mirbft
 
 import (
+	"fmt"
+
 	pb "github.com/IBM/mirbft/mirbftpb"
 )
 
@@ -51,6 +53,21 @@ type Actions struct {
 	// replica in the network.  The consumer _must_ report success or failure but may
 	// continue to process other actions in the interim.
 	StateTransfer *StateTarget
+
+	// StableCheckpoint is set to the sequence number of a checkpoint once it has
+	// become stable, that is, once a quorum of the network has agreed upon its value.
+	// No correct replica will ever again require data for sequence numbers at or
+	// below a stable checkpoint, so the consumer may garbage collect such data, in
+	// particular, the requests committed at or below this sequence number.  This
+	// must only be performed after the commits of this set of actions are applied.
+	StableCheckpoint *uint64
+
+	// Misbehaviors is evidence of other nodes sending messages which no correct
+	// node would send.  The offending messages have already been discarded, so
+	// the consumer need take no action, but may wish to alert an operator, or
+	// eventually remove the offending node via reconfiguration.  A divergent
+	// checkpoint of this node is also reported here, see DivergentCheckpoint.
+	Misbehaviors []*Misbehavior
 }
 
 func (a *Actions) send(targets []uint64, msg *pb.Msg) *Actions {
@@ -75,6 +92,15 @@ func (a *Actions) forwardRequest(targets []uint64, requestAck *pb.RequestAck) *A
 	return a
 }
 
+func (a *Actions) misbehave(source nodeID, kind MisbehaviorKind, msgs ...*pb.Msg) *Actions {
+	a.Misbehaviors = append(a.Misbehaviors, &Misbehavior{
+		Node: uint64(source),
+		Kind: kind,
+		Msgs: msgs,
+	})
+	return a
+}
+
 func (a *Actions) persist(index uint64, p *pb.Persistent) *Actions {
 	a.WriteAhead = append(a.WriteAhead,
 		&Write{
@@ -95,6 +121,8 @@ func (a *Actions) clear() {
 	a.StoreRequests = nil
 	a.ForwardRequests = nil
 	a.StateTransfer = nil
+	a.StableCheckpoint = nil
+	a.Misbehaviors = nil
 }
 
 func (a *Actions) isEmpty() bool {
@@ -104,7 +132,9 @@ func (a *Actions) isEmpty() bool {
 		len(a.StoreRequests) == 0 &&
 		len(a.ForwardRequests) == 0 &&
 		len(a.Commits) == 0 &&
-		a.StateTransfer == nil
+		a.StateTransfer == nil &&
+		a.StableCheckpoint == nil &&
+		len(a.Misbehaviors) == 0
 }
 
 // concat takes a set of actions and for each field, appends it to
@@ -116,12 +146,18 @@ func (a *Actions) concat(o *Actions) *Actions {
 	a.WriteAhead = append(a.WriteAhead, o.WriteAhead...)
 	a.StoreRequests = append(a.StoreRequests, o.StoreRequests...)
 	a.ForwardRequests = append(a.ForwardRequests, o.ForwardRequests...)
+	a.Misbehaviors = append(a.Misbehaviors, o.Misbehaviors...)
 	if o.StateTransfer != nil {
 		if a.StateTransfer != nil {
 			panic("attempted to concatenate two concurrent state transfer requests")
 		}
 		a.StateTransfer = o.StateTransfer
 	}
+	if o.StableCheckpoint != nil {
+		if a.StableCheckpoint == nil || *a.StableCheckpoint < *o.StableCheckpoint {
+			a.StableCheckpoint = o.StableCheckpoint
+		}
+	}
 	return a
 }
 
@@ -149,6 +185,61 @@ type Forward struct {
 	RequestAck *pb.RequestAck
 }
 
+// MisbehaviorKind categorizes the evidence in a Misbehavior.
+type MisbehaviorKind int
+
+const (
+	// ConflictingMsgs indicates that the node sent two messages which
+	// contradict one another, for instance, prepares for two different
+	// digests at the same sequence number.
+	ConflictingMsgs MisbehaviorKind = iota
+
+	// MalformedMsg indicates that the node sent a message which is not
+	// well formed, for instance, an epoch change with duplicated entries,
+	// a new epoch whose config does not follow from its epoch changes, or
+	// a forwarded batch or request which does not match its digest.
+	MalformedMsg
+
+	// DivergentCheckpoint indicates that this node computed a checkpoint value
+	// which disagrees with the value a quorum of the network attested to.  This
+	// generally indicates non-determinism in the application.  It is only
+	// reported when TransferOnCheckpointDivergence is set, in which case the
+	// node state transfers to the network's checkpoint.
+	DivergentCheckpoint
+)
+
+func (mk MisbehaviorKind) String() string {
+	switch mk {
+	case ConflictingMsgs:
+		return "ConflictingMsgs"
+	case MalformedMsg:
+		return "MalformedMsg"
+	case DivergentCheckpoint:
+		return "DivergentCheckpoint"
+	default:
+		return fmt.Sprintf("MisbehaviorKind(%d)", int(mk))
+	}
+}
+
+// Misbehavior is evidence that a node has sent messages which no correct
+// node would send.
+type Misbehavior struct {
+	// Node is the node which sent the offending messages.
+	Node uint64
+
+	// Kind categorizes the misbehavior.
+	Kind MisbehaviorKind
+
+	// Msgs are the offending messages.  For ConflictingMsgs, the first message
+	// is the one which was previously accepted, and the second the one which
+	// conflicts with it.  Conflicting epoch change acks are reported against
+	// the acking node, though should we not have received both epoch changes
+	// from the originator, it may be the originator which misbehaved.  For MalformedMsg, it is the malformed message.  For
+	// DivergentCheckpoint, Node is this node, the first message is its own
+	// checkpoint, and the second is the checkpoint the network attested to.
+	Msgs []*pb.Msg
+}
+
 // HashRequest is a request from the state machine to the consumer to hash some data.
 // The Data field is generally the only field the consumer should read.  One of the other fields
 // e.g. Batch or Request, will be populated, while the remainder will be nil.  The consumer
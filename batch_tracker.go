@@ -167,17 +167,30 @@ func (bt *batchTracker) applyForwardBatchMsg(source nodeID, seqNo uint64, digest
 	}
 }
 
-func (bt *batchTracker) applyVerifyBatchHashResult(digest []byte, verifyBatch *pb.HashResult_VerifyBatch) {
+func (bt *batchTracker) applyVerifyBatchHashResult(digest []byte, verifyBatch *pb.HashResult_VerifyBatch) *Actions {
 	if !bytes.Equal(verifyBatch.ExpectedDigest, digest) {
-		panic("byzantine")
-		// XXX this should be a log only, but panic-ing to make dev easier for now
+		// The forwarded batch does not match the digest we fetched, so we
+		// discard it, and await a response from one of the other sources.
+		return (&Actions{}).misbehave(
+			nodeID(verifyBatch.Source),
+			MalformedMsg,
+			&pb.Msg{
+				Type: &pb.Msg_ForwardBatch{
+					ForwardBatch: &pb.ForwardBatch{
+						SeqNo:       verifyBatch.SeqNo,
+						RequestAcks: verifyBatch.RequestAcks,
+						Digest:      verifyBatch.ExpectedDigest,
+					},
+				},
+			},
+		)
 	}
 
 	inFlight, ok := bt.fetchInFlight[string(digest)]
 	if !ok {
 		// We must have gotten multiple responses, and already
 		// committed one, which is fine.
-		return
+		return &Actions{}
 	}
 
 	b, ok := bt.batchesByDigest[string(digest)]
@@ -194,6 +207,8 @@ func (bt *batchTracker) applyVerifyBatchHashResult(digest []byte, verifyBatch *p
 	}
 
 	delete(bt.fetchInFlight, string(digest))
+
+	return &Actions{}
 }
 
 func (bt *batchTracker) hasFetchInFlight() bool {
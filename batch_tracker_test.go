@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("batchTracker", func() {
+	It("reports a forwarded batch which does not match its digest", func() {
+		bt := newBatchTracker(nil)
+		bt.fetchInFlight["digest"] = []uint64{5}
+
+		requestAcks := []*pb.RequestAck{{ClientId: 1, ReqNo: 2, Digest: []byte("request")}}
+		actions := bt.applyVerifyBatchHashResult([]byte("other-digest"), &pb.HashResult_VerifyBatch{
+			Source:         2,
+			SeqNo:          5,
+			RequestAcks:    requestAcks,
+			ExpectedDigest: []byte("digest"),
+		})
+
+		Expect(actions.Misbehaviors).To(Equal([]*Misbehavior{
+			{
+				Node: 2,
+				Kind: MalformedMsg,
+				Msgs: []*pb.Msg{
+					{
+						Type: &pb.Msg_ForwardBatch{
+							ForwardBatch: &pb.ForwardBatch{
+								SeqNo:       5,
+								RequestAcks: requestAcks,
+								Digest:      []byte("digest"),
+							},
+						},
+					},
+				},
+			},
+		}))
+		Expect(bt.hasFetchInFlight()).To(BeTrue())
+		Expect(bt.batchesByDigest).To(BeEmpty())
+	})
+})
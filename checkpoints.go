@@ -69,6 +69,20 @@ func (ct *checkpointTracker) reinitialize() {
 				// time we reinitialize.
 				ct.networkConfig = cEntry.NetworkState.Config
 			}
+
+			for el := ct.activeCheckpoints.Back(); el != nil; el = ct.activeCheckpoints.Back() {
+				cp := el.Value.(*checkpoint)
+				if cp.seqNo < cEntry.SeqNo {
+					break
+				}
+
+				// We transferred away from a checkpoint which diverged from
+				// the network, the transferred checkpoint supersedes any we
+				// computed at or above its sequence number.
+				delete(ct.checkpointMap, cp.seqNo)
+				ct.activeCheckpoints.Remove(el)
+			}
+
 			cp := ct.checkpoint(cEntry.SeqNo)
 			cp.applyCheckpointMsg(nodeID(ct.myConfig.Id), cEntry.CheckpointValue)
 			ct.activeCheckpoints.PushBack(cp)
@@ -87,6 +101,12 @@ func (ct *checkpointTracker) reinitialize() {
 		validNodes[nodeID(id)] = struct{}{}
 	}
 
+	for id, buffer := range oldMsgBuffers {
+		if _, ok := validNodes[id]; !ok {
+			buffer.clear()
+		}
+	}
+
 	// Lots of non-determinism in this iteration... but it should
 	// all be commutative.
 	for seqNo, cp := range oldCheckpointMap {
@@ -100,6 +120,11 @@ func (ct *checkpointTracker) reinitialize() {
 					continue
 				}
 
+				if node == nodeID(ct.myConfig.Id) {
+					// Our own checkpoints were recovered from the log
+					continue
+				}
+
 				ct.applyCheckpointMsg(node, seqNo, []byte(value))
 			}
 		}
@@ -239,6 +264,52 @@ func (ct *checkpointTracker) applyCheckpointMsg(source nodeID, seqNo uint64, val
 	}
 }
 
+// highestCorrectCheckpoint returns the highest checkpoint whose value
+// some correct node has attested to, if any.
+func (ct *checkpointTracker) highestCorrectCheckpoint() (uint64, []byte, bool) {
+	var highest *checkpoint
+	for _, cp := range ct.checkpointMap {
+		if cp.committedValue == nil {
+			continue
+		}
+
+		if highest == nil || cp.seqNo > highest.seqNo {
+			highest = cp
+		}
+	}
+
+	if highest == nil {
+		return 0, nil, false
+	}
+
+	return highest.seqNo, highest.committedValue, true
+}
+
+// divergentCheckpoint returns the lowest checkpoint for which the value
+// this node computed disagrees with the value some correct node has attested
+// to, along with both values, if any.
+func (ct *checkpointTracker) divergentCheckpoint() (uint64, []byte, []byte, bool) {
+	for el := ct.activeCheckpoints.Front(); el != nil; el = el.Next() {
+		cp := el.Value.(*checkpoint)
+		if cp.divergent {
+			return cp.seqNo, cp.myValue, cp.committedValue, true
+		}
+	}
+
+	return 0, nil, nil, false
+}
+
+func checkpointMsg(seqNo uint64, value []byte) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_Checkpoint{
+			Checkpoint: &pb.Checkpoint{
+				SeqNo: seqNo,
+				Value: value,
+			},
+		},
+	}
+}
+
 func (ct *checkpointTracker) status() []*status.Checkpoint {
 	result := make([]*status.Checkpoint, len(ct.checkpointMap))
 	i := 0
@@ -264,6 +335,7 @@ type checkpoint struct {
 	committedValue []byte
 	myValue        []byte
 	stable         bool
+	divergent      bool
 }
 
 func (cw *checkpoint) applyCheckpointMsg(source nodeID, value []byte) {
@@ -274,9 +346,7 @@ func (cw *checkpoint) applyCheckpointMsg(source nodeID, value []byte) {
 	checkpointValueNodes := append(cw.values[string(value)], source)
 	cw.values[string(value)] = checkpointValueNodes
 
-	agreements := len(checkpointValueNodes)
-
-	if agreements == someCorrectQuorum(cw.networkConfig) {
+	if len(checkpointValueNodes) == someCorrectQuorum(cw.networkConfig) {
 		cw.committedValue = value
 	}
 
@@ -286,15 +356,23 @@ func (cw *checkpoint) applyCheckpointMsg(source nodeID, value []byte) {
 
 	// If I have completed this checkpoint, along with a quorum of the network, and I've not already run this path
 	if cw.myValue != nil && cw.committedValue != nil && !cw.stable {
-		if !bytes.Equal(value, cw.committedValue) {
-			// TODO optionally handle this more gracefully, with state transfer (though this
-			// indicates a violation of the byzantine assumptions)
-			panic("my checkpoint disagrees with the committed network view of this checkpoint")
+		if !bytes.Equal(cw.myValue, cw.committedValue) {
+			// This indicates non-determinism in the application, or a
+			// violation of the byzantine assumptions.
+			if !cw.myConfig.TransferOnCheckpointDivergence {
+				panic(fmt.Sprintf("my checkpoint disagrees with the committed network view of this checkpoint, seq_no=%d local_value=%x network_value=%x", cw.seqNo, cw.myValue, cw.committedValue))
+			}
+
+			if !cw.divergent {
+				cw.logger.Log(LevelWarn, "my checkpoint disagrees with the committed network view of this checkpoint", "seq_no", cw.seqNo, "local_value", cw.myValue, "network_value", cw.committedValue)
+			}
+			cw.divergent = true
+			return
 		}
 
 		// This checkpoint has enough agreements, including my own, it may now be garbage collectable
 		// Note, this must be >= (not ==) because my agreement could come after 2f+1 from the network.
-		if agreements >= intersectionQuorum(cw.networkConfig) {
+		if len(cw.values[string(cw.committedValue)]) >= intersectionQuorum(cw.networkConfig) {
 			if !cw.stable {
 				cw.logger.Log(LevelDebug, "checkpoint is now stable", "seq_no", cw.seqNo)
 			}
@@ -315,5 +393,6 @@ func (cw *checkpoint) status() *status.Checkpoint {
 		MaxAgreements: maxAgreements,
 		NetQuorum:     cw.committedValue != nil,
 		LocalDecision: cw.myValue != nil,
+		Stable:        cw.stable,
 	}
 }
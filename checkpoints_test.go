@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("checkpoint", func() {
+	var cp *checkpoint
+
+	BeforeEach(func() {
+		cp = &checkpoint{
+			seqNo: 20,
+			myConfig: &pb.StateEvent_InitialParameters{
+				Id: 1,
+			},
+			networkConfig: &pb.NetworkState_Config{
+				Nodes: []uint64{0, 1, 2, 3},
+				F:     1,
+			},
+			logger: ConsoleInfoLogger,
+		}
+	})
+
+	It("becomes stable once a quorum including this node agrees", func() {
+		cp.applyCheckpointMsg(0, []byte("value"))
+		cp.applyCheckpointMsg(1, []byte("value"))
+		cp.applyCheckpointMsg(2, []byte("other-value"))
+		Expect(cp.stable).To(BeFalse())
+		cp.applyCheckpointMsg(3, []byte("value"))
+		Expect(cp.stable).To(BeTrue())
+		Expect(cp.divergent).To(BeFalse())
+	})
+
+	It("panics when this node's value diverges from the network", func() {
+		cp.applyCheckpointMsg(1, []byte("my-value"))
+		cp.applyCheckpointMsg(0, []byte("value"))
+		Expect(func() {
+			cp.applyCheckpointMsg(2, []byte("value"))
+		}).To(Panic())
+	})
+
+	When("configured to transfer on divergence", func() {
+		BeforeEach(func() {
+			cp.myConfig.TransferOnCheckpointDivergence = true
+		})
+
+		It("marks the checkpoint divergent rather than stable", func() {
+			cp.applyCheckpointMsg(0, []byte("value"))
+			cp.applyCheckpointMsg(2, []byte("value"))
+			cp.applyCheckpointMsg(3, []byte("value"))
+			cp.applyCheckpointMsg(1, []byte("my-value"))
+			Expect(cp.divergent).To(BeTrue())
+			Expect(cp.stable).To(BeFalse())
+			Expect(cp.myValue).To(Equal([]byte("my-value")))
+			Expect(cp.committedValue).To(Equal([]byte("value")))
+		})
+	})
+})
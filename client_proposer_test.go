@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("ClientProposer", func() {
+	var (
+		cp      *ClientProposer
+		waiter  *clientWaiter
+		clientC chan *clientReq
+		errC    chan error
+	)
+
+	BeforeEach(func() {
+		waiter = &clientWaiter{
+			lowWatermark:  0,
+			highWatermark: 5,
+			expired:       make(chan struct{}),
+		}
+		clientC = make(chan *clientReq)
+		cp = &ClientProposer{
+			blocking:     true,
+			clientID:     1,
+			clientWaiter: waiter,
+			accepted: map[uint64]struct{}{
+				3: {},
+				4: {},
+				5: {},
+			},
+			s: &serializer{
+				clientC: clientC,
+				errC:    make(chan struct{}),
+			},
+		}
+
+		errC = make(chan error, 1)
+		go func() {
+			errC <- cp.Propose(context.Background(), &pb.Request{
+				ClientId: 1,
+				ReqNo:    10,
+			})
+		}()
+	})
+
+	It("fails in-flight proposals once the client is removed", func() {
+		Consistently(errC).ShouldNot(Receive())
+		waiter.removed = true
+		close(waiter.expired)
+		Eventually(errC).Should(Receive(Equal(ErrClientRemoved)))
+	})
+
+	It("fails in-flight proposals if the client is no longer known", func() {
+		close(waiter.expired)
+		var req *clientReq
+		Eventually(clientC).Should(Receive(&req))
+		Expect(req.clientID).To(Equal(uint64(1)))
+		req.replyC <- nil
+		Eventually(errC).Should(Receive(Equal(ErrClientRemoved)))
+	})
+
+	It("reports accepted requests which were dropped as the client window shrank", func() {
+		close(waiter.expired)
+		var req *clientReq
+		Eventually(clientC).Should(Receive(&req))
+		req.replyC <- &clientWaiter{
+			lowWatermark:  4,
+			highWatermark: 4,
+			expired:       make(chan struct{}),
+		}
+		Eventually(errC).Should(Receive(Equal(&RequestsDroppedError{
+			ClientID: 1,
+			ReqNos:   []uint64{5},
+		})))
+		Expect(cp.accepted).To(Equal(map[uint64]struct{}{4: {}}))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"container/list"
 	"fmt"
+	"math"
 	"sort"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
@@ -292,14 +293,28 @@ type clientTracker struct {
 	readyList     *readyList
 	availableList *availableList // A list of requests which have f+1 ACKs and the requestData
 	myConfig      *pb.StateEvent_InitialParameters
+	timeouts      *requestTimeouts
 	persisted     *persisted
 	nodeBuffers   *nodeBuffers
+
+	// removedClients maps the clients removed from the network state to
+	// the sequence number of the checkpoint which removed them.  Messages
+	// for them are discarded rather than buffered.  These tombstones are
+	// retained across reinitialization, until a reconfiguration adds the
+	// client back, as late acks may arrive at any point after the removal.
+	removedClients map[uint64]uint64
+
+	// pendingClients are the clients which a pending reconfiguration adds
+	// to the network state, their messages are buffered even if the
+	// client was previously removed.
+	pendingClients map[uint64]struct{}
 }
 
 func newClientWindows(persisted *persisted, nodeBuffers *nodeBuffers, myConfig *pb.StateEvent_InitialParameters, logger Logger) *clientTracker {
 	ct := &clientTracker{
 		logger:      logger,
 		myConfig:    myConfig,
+		timeouts:    newRequestTimeouts(myConfig),
 		persisted:   persisted,
 		nodeBuffers: nodeBuffers,
 	}
@@ -309,12 +324,25 @@ func newClientWindows(persisted *persisted, nodeBuffers *nodeBuffers, myConfig *
 
 func (ct *clientTracker) reinitialize() {
 	var lowCEntry, highCEntry *pb.CEntry
+	removedClients := map[uint64]uint64{}
+	for clientID, seqNo := range ct.removedClients {
+		removedClients[clientID] = seqNo
+	}
 
 	ct.persisted.iterate(logIterator{
 		onCEntry: func(cEntry *pb.CEntry) {
 			if lowCEntry == nil {
 				lowCEntry = cEntry
 			}
+			if highCEntry != nil {
+				// Any client removals pending in the previous checkpoint
+				// have been applied by this one.
+				for _, reconfig := range highCEntry.NetworkState.PendingReconfigurations {
+					if rc, ok := reconfig.Type.(*pb.Reconfiguration_RemoveClient); ok {
+						removedClients[rc.RemoveClient] = cEntry.SeqNo
+					}
+				}
+			}
 			highCEntry = cEntry
 		},
 	})
@@ -332,15 +360,18 @@ func (ct *clientTracker) reinitialize() {
 
 	oldClients := ct.clients
 	ct.clients = map[uint64]*client{}
+	oldClientStates := ct.clientStates
 	ct.clientStates = highCEntry.NetworkState.Clients
 	for _, clientState := range ct.clientStates {
 		client, ok := oldClients[clientState.Id]
-		if !ok {
-			client = newClient(ct.logger)
+		if _, removed := removedClients[clientState.Id]; !ok || removed {
+			// A client ID which was removed and then added back is a new
+			// client, and must not inherit the old client's requests.
+			client = newClient(ct.timeouts, ct.logger)
 		}
 
 		ct.clients[clientState.Id] = client
-		// TODO, this assumes no client deletion, need to handle that
+		delete(removedClients, clientState.Id)
 		client.reinitialize(
 			lowCEntry.NetworkState.Config,
 			lowCEntry.SeqNo,
@@ -349,9 +380,46 @@ func (ct *clientTracker) reinitialize() {
 			latestClientStates[clientState.Id],
 		)
 
+		// Requests which were already known correct before reinitializing
+		// (for instance across a reconfiguration) will not be acked again,
+		// so make them available to the new epoch now.
+		for el := client.reqNoList.Front(); el != nil; el = el.Next() {
+			crn := el.Value.(*clientReqNo)
+			if crn.committed != nil {
+				continue
+			}
+
+			digests := make([]string, 0, len(crn.weakRequests))
+			for digest := range crn.weakRequests {
+				digests = append(digests, digest)
+			}
+			sort.Strings(digests)
+
+			for _, digest := range digests {
+				ct.availableList.pushBack(crn.weakRequests[digest])
+			}
+		}
+
 		ct.advanceReady(client)
 	}
 
+	for _, oldClientState := range oldClientStates {
+		oldClient := oldClients[oldClientState.Id]
+		if ct.clients[oldClientState.Id] == oldClient {
+			continue
+		}
+
+		ct.logger.Log(LevelDebug, "removing client", "client_id", oldClientState.Id)
+		oldClient.remove()
+		if _, ok := ct.clients[oldClientState.Id]; !ok {
+			removedClients[oldClientState.Id] = highCEntry.SeqNo
+		}
+	}
+	ct.removedClients = removedClients
+
+	ct.pendingClients = map[uint64]struct{}{}
+	ct.addPendingClients(highCEntry.NetworkState.PendingReconfigurations)
+
 	oldMsgBuffers := ct.msgBuffers
 	ct.msgBuffers = map[nodeID]*msgBuffer{}
 	for _, id := range lowCEntry.NetworkState.Config.Nodes {
@@ -362,6 +430,12 @@ func (ct *clientTracker) reinitialize() {
 		}
 	}
 
+	for id, oldBuffer := range oldMsgBuffers {
+		if _, ok := ct.msgBuffers[id]; !ok {
+			oldBuffer.clear()
+		}
+	}
+
 }
 
 func (ct *clientTracker) tick() *Actions {
@@ -376,11 +450,12 @@ func (ct *clientTracker) tick() *Actions {
 func (ct *clientTracker) filter(_ nodeID, msg *pb.Msg) applyable {
 	switch innerMsg := msg.Type.(type) {
 	case *pb.Msg_RequestAck:
-		// TODO, prevent ack spam of multiple msg digests from the same node
+		// Ack spam of multiple digests from the same node is
+		// rejected, and reported, as the ack is applied.
 		ack := innerMsg.RequestAck
 		client, ok := ct.client(ack.ClientId)
 		if !ok {
-			return future
+			return ct.unknownClient(ack.ClientId)
 		}
 		switch {
 		case client.lowWatermark > ack.ReqNo:
@@ -396,7 +471,7 @@ func (ct *clientTracker) filter(_ nodeID, msg *pb.Msg) applyable {
 		requestAck := innerMsg.ForwardRequest.RequestAck
 		client, ok := ct.client(requestAck.ClientId)
 		if !ok {
-			return future
+			return ct.unknownClient(requestAck.ClientId)
 		}
 		// TODO, we need to validate that the request is correct before further processing
 		// probably by having a separate forward request queue to iterate through, maybe
@@ -414,6 +489,29 @@ func (ct *clientTracker) filter(_ nodeID, msg *pb.Msg) applyable {
 	}
 }
 
+// addPendingClients records the clients which the given pending
+// reconfigurations add to the network state.
+func (ct *clientTracker) addPendingClients(reconfigurations []*pb.Reconfiguration) {
+	for _, reconfig := range reconfigurations {
+		if rc, ok := reconfig.Type.(*pb.Reconfiguration_NewClient_); ok {
+			ct.pendingClients[rc.NewClient.Id] = struct{}{}
+		}
+	}
+}
+
+// unknownClient classifies messages for clients we are not tracking.  Clients
+// which were removed are discarded, unless a pending reconfiguration
+// adds them back, any other client may yet be added by a future checkpoint.
+func (ct *clientTracker) unknownClient(clientID uint64) applyable {
+	if _, ok := ct.pendingClients[clientID]; ok {
+		return future
+	}
+	if _, ok := ct.removedClients[clientID]; ok {
+		return past
+	}
+	return future
+}
+
 func (ct *clientTracker) step(source nodeID, msg *pb.Msg) *Actions {
 	switch ct.filter(source, msg) {
 	case past:
@@ -432,6 +530,19 @@ func (ct *clientTracker) applyMsg(source nodeID, msg *pb.Msg) *Actions {
 	switch innerMsg := msg.Type.(type) {
 	case *pb.Msg_RequestAck:
 		// TODO, make sure nodeMsgs ignores this if client is not defined
+		crn := ct.clients[innerMsg.RequestAck.ClientId].reqNo(innerMsg.RequestAck.ReqNo)
+		if conflictingAck := crn.checkNonNullAck(source, innerMsg.RequestAck); conflictingAck != nil {
+			return (&Actions{}).misbehave(
+				source,
+				ConflictingMsgs,
+				&pb.Msg{
+					Type: &pb.Msg_RequestAck{
+						RequestAck: conflictingAck,
+					},
+				},
+				msg,
+			)
+		}
 		ct.ack(source, innerMsg.RequestAck)
 		return &Actions{}
 	case *pb.Msg_FetchRequest:
@@ -465,6 +576,23 @@ func (ct *clientTracker) applyRequestDigest(ack *pb.RequestAck, data []byte) *Ac
 	return client.reqNo(ack.ReqNo).applyRequestDigest(ack, data)
 }
 
+// loadRequest marks a request which was persisted before a restart as stored.
+// Unlike applyRequestDigest, the request data is already in the request store,
+// so only its size is supplied.
+func (ct *clientTracker) loadRequest(ack *pb.RequestAck, size int) {
+	client, ok := ct.clients[ack.ClientId]
+	if !ok || !client.inWatermarks(ack.ReqNo) {
+		// The client was removed, or the request committed
+		return
+	}
+
+	crn := client.reqNo(ack.ReqNo)
+	crn.applyRequestDigest(ack, nil) // XXX silly, but necessary for the moment
+	if clientReq, ok := crn.myRequests[string(ack.Digest)]; ok {
+		clientReq.size = size
+	}
+}
+
 // commitsCompletedForCheckpointWindow indicates to the client tracker that no client request
 // will be marked committed for any sequence number in the current checkpoint window.  It triggers
 // allocation for additional client requests, and marks these new requests as depending on the
@@ -730,6 +858,7 @@ type clientReqNo struct {
 	reqNo           uint64
 	validAfterSeqNo uint64
 	nonNullVoters   map[nodeID]struct{}
+	nonNullAcks     map[nodeID]*pb.RequestAck // the first non-null ack received from each node
 	requests        map[string]*clientRequest // all requests, correct or not we've observed
 	weakRequests    map[string]*clientRequest // all correct requests we have observed
 	strongRequests  map[string]*clientRequest // strongly correct requests (at most 1 null, 1 non-null)
@@ -737,6 +866,56 @@ type clientReqNo struct {
 	committed       *uint64
 	acksSent        uint
 	ticksSinceAck   uint
+	timeouts        *requestTimeouts
+}
+
+// requestTimeouts are the intervals, in ticks, after which requests are
+// fetched, fetches are retried, and acks are re-sent.  Unset intervals
+// take their defaults, so that event logs recorded before they were
+// configurable replay identically.
+type requestTimeouts struct {
+	correctFetchTicks uint
+	fetchTimeoutTicks uint
+	ackResendTicks    uint
+	ackResendMaxTicks uint
+	ackResendBackoff  pb.StateEvent_InitialParameters_Backoff
+}
+
+func newRequestTimeouts(myConfig *pb.StateEvent_InitialParameters) *requestTimeouts {
+	orDefault := func(ticks uint32, defaultTicks uint) uint {
+		if ticks == 0 {
+			return defaultTicks
+		}
+		return uint(ticks)
+	}
+
+	return &requestTimeouts{
+		correctFetchTicks: orDefault(myConfig.CorrectFetchTicks, 4),
+		fetchTimeoutTicks: orDefault(myConfig.FetchTimeoutTicks, 4),
+		ackResendTicks:    orDefault(myConfig.AckResendTicks, 20),
+		ackResendMaxTicks: uint(myConfig.AckResendMaxTicks),
+		ackResendBackoff:  myConfig.AckResendBackoff,
+	}
+}
+
+// ackResendInterval is the number of ticks to wait before re-sending an
+// ack, given the number of times it has been sent so far.
+func (rt *requestTimeouts) ackResendInterval(acksSent uint) uint {
+	interval := rt.ackResendTicks
+	switch rt.ackResendBackoff {
+	case pb.StateEvent_InitialParameters_EXPONENTIAL:
+		for i := uint(1); i < acksSent && interval < math.MaxUint32; i++ {
+			interval *= 2
+		}
+	default:
+		interval *= acksSent
+	}
+
+	if rt.ackResendMaxTicks != 0 && interval > rt.ackResendMaxTicks {
+		return rt.ackResendMaxTicks
+	}
+
+	return interval
 }
 
 func (crn *clientReqNo) reinitialize(networkConfig *pb.NetworkState_Config) {
@@ -745,6 +924,7 @@ func (crn *clientReqNo) reinitialize(networkConfig *pb.NetworkState_Config) {
 	oldRequests := crn.requests
 
 	crn.nonNullVoters = map[nodeID]struct{}{}
+	crn.nonNullAcks = map[nodeID]*pb.RequestAck{}
 	crn.requests = map[string]*clientRequest{}
 	crn.weakRequests = map[string]*clientRequest{}
 	crn.strongRequests = map[string]*clientRequest{}
@@ -773,6 +953,7 @@ func (crn *clientReqNo) reinitialize(networkConfig *pb.NetworkState_Config) {
 		if oldClientReq.stored {
 			newClientReq := crn.clientReq(oldClientReq.ack)
 			newClientReq.stored = true
+			newClientReq.size = oldClientReq.size
 			crn.myRequests[digest] = newClientReq
 		}
 	}
@@ -808,6 +989,7 @@ func (crn *clientReqNo) applyRequestDigest(ack *pb.RequestAck, data []byte) *Act
 
 	clientReq := crn.clientReq(ack)
 	clientReq.stored = true
+	clientReq.size = len(data)
 
 	crn.myRequests[string(ack.Digest)] = clientReq
 
@@ -863,6 +1045,28 @@ func (crn *clientReqNo) applyRequestDigest(ack *pb.RequestAck, data []byte) *Act
 	)
 }
 
+// checkNonNullAck records the first non-null ack received from each node, and
+// returns that first ack if the given ack conflicts with it.  A correct node
+// acks at most one non-null request per request number, so any further
+// non-null digests from the same node must not be counted.
+func (crn *clientReqNo) checkNonNullAck(source nodeID, ack *pb.RequestAck) *pb.RequestAck {
+	if len(ack.Digest) == 0 {
+		return nil
+	}
+
+	firstAck, ok := crn.nonNullAcks[source]
+	if !ok {
+		crn.nonNullAcks[source] = ack
+		return nil
+	}
+
+	if bytes.Equal(firstAck.Digest, ack.Digest) {
+		return nil
+	}
+
+	return firstAck
+}
+
 func (crn *clientReqNo) applyRequestAck(source nodeID, ack *pb.RequestAck, force bool) {
 	if len(ack.Digest) != 0 {
 		_, ok := crn.nonNullVoters[source]
@@ -928,13 +1132,12 @@ func (crn *clientReqNo) tick() *Actions {
 	// Second, if there is only one correct request, and we don't have it,
 	// and it's been around long enough, let's go proactively fetch it.
 	if len(crn.weakRequests) == 1 {
-		correctFetchTicks := uint(4)
 		for _, cr := range crn.weakRequests {
 			if cr.stored || cr.fetching {
 				break
 			}
 
-			if cr.ticksCorrect <= correctFetchTicks {
+			if cr.ticksCorrect <= crn.timeouts.correctFetchTicks {
 				cr.ticksCorrect++
 				break
 			}
@@ -953,9 +1156,7 @@ func (crn *clientReqNo) tick() *Actions {
 			continue
 		}
 
-		fetchTimeoutTicks := uint(4) // TODO make configurable
-
-		if cr.ticksFetching <= fetchTimeoutTicks {
+		if cr.ticksFetching <= crn.timeouts.fetchTimeoutTicks {
 			cr.ticksFetching++
 			continue
 		}
@@ -975,14 +1176,12 @@ func (crn *clientReqNo) tick() *Actions {
 
 	// Finally, if we have sent any acks, and it has been long enough, we re-send.
 	// Since it's possible the client did not send the request to enough parties,
-	// we perform a linear backoff, waiting an additional interval longer after each re-ack
-	ackResendTicks := uint(20) // TODO make configurable
-
+	// we back off, by default linearly, waiting an additional interval longer after each re-ack
 	if crn.acksSent == 0 {
 		return actions
 	}
 
-	if crn.ticksSinceAck != crn.acksSent*ackResendTicks {
+	if crn.ticksSinceAck < crn.timeouts.ackResendInterval(crn.acksSent) {
 		crn.ticksSinceAck++
 		return actions
 	}
@@ -1023,6 +1222,7 @@ type clientRequest struct {
 	fetching      bool // set when we have sent a request for this request
 	ticksFetching uint // incremented by one each tick while fetching is true
 	ticksCorrect  uint // incremented by one each tick while not stored
+	size          int  // the length of the request data, or zero if not yet known
 }
 
 func (cr *clientRequest) fetch() *Actions {
@@ -1062,6 +1262,7 @@ type client struct {
 	reqNoList     *list.List
 	reqNoMap      map[uint64]*list.Element
 	clientWaiter  *clientWaiter // Used to throttle clients
+	timeouts      *requestTimeouts
 	logger        Logger
 	networkConfig *pb.NetworkState_Config
 }
@@ -1069,12 +1270,14 @@ type client struct {
 type clientWaiter struct {
 	lowWatermark  uint64
 	highWatermark uint64
+	removed       bool // set before expired is closed if the client was removed
 	expired       chan struct{}
 }
 
-func newClient(logger Logger) *client {
+func newClient(timeouts *requestTimeouts, logger Logger) *client {
 	return &client{
-		logger: logger,
+		timeouts: timeouts,
+		logger:   logger,
 	}
 }
 
@@ -1131,6 +1334,7 @@ func (c *client) reinitialize(networkConfig *pb.NetworkState_Config, lowSeqNo, h
 				validAfterSeqNo: validAfterSeqNo,
 				reqNo:           reqNo,
 				committed:       committed,
+				timeouts:        c.timeouts,
 			}
 		}
 
@@ -1140,6 +1344,22 @@ func (c *client) reinitialize(networkConfig *pb.NetworkState_Config, lowSeqNo, h
 		c.reqNoMap[reqNo] = el
 	}
 
+	// A ClientWidth reconfiguration may shrink the window below requests
+	// which were already acked, these are discarded and must be proposed again.
+	var discarded []uint64
+	for reqNo, el := range oldReqNoMap {
+		crn := el.Value.(*clientReqNo)
+		if reqNo > highWatermark && crn.committed == nil && len(crn.requests) > 0 {
+			discarded = append(discarded, reqNo)
+		}
+	}
+	if len(discarded) > 0 {
+		sort.Slice(discarded, func(i, j int) bool {
+			return discarded[i] < discarded[j]
+		})
+		c.logger.Log(LevelWarn, "client window shrank, discarding uncommitted requests above it", "client_id", highClientState.Id, "high_watermark", highWatermark, "req_nos", discarded)
+	}
+
 	c.logger.Log(LevelDebug, "reinitialized client", "client_id", c.clientState.Id, "low_watermark", c.lowWatermark, "high_watermark", c.highWatermark, "next_ready_mark", c.nextReadyMark, "committed_beyond_low_watermark", totalCommitted)
 }
 
@@ -1154,12 +1374,14 @@ func (cw *client) allocate(startingAtSeqNo uint64, state *pb.NetworkState_Client
 			validAfterSeqNo: startingAtSeqNo + uint64(cw.networkConfig.CheckpointInterval),
 			clientID:        state.Id,
 			networkConfig:   cw.networkConfig,
+			timeouts:        cw.timeouts,
 			reqNo:           reqNo,
 			requests:        map[string]*clientRequest{},
 			weakRequests:    map[string]*clientRequest{},
 			strongRequests:  map[string]*clientRequest{},
 			myRequests:      map[string]*clientRequest{},
 			nonNullVoters:   map[nodeID]struct{}{},
+			nonNullAcks:     map[nodeID]*pb.RequestAck{},
 		})
 		cw.reqNoMap[reqNo] = el
 	}
@@ -1174,6 +1396,13 @@ func (cw *client) allocate(startingAtSeqNo uint64, state *pb.NetworkState_Client
 	}
 }
 
+// remove is invoked once the client is no longer in the network state,
+// it wakes any proposers waiting on the client so that they may fail.
+func (cw *client) remove() {
+	cw.clientWaiter.removed = true
+	close(cw.clientWaiter.expired)
+}
+
 func (cw *client) moveLowWatermark(maxSeqNo uint64) {
 	for el := cw.reqNoList.Front(); el != nil; {
 		crn := el.Value.(*clientReqNo)
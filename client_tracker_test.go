@@ -0,0 +1,322 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("clientReqNo", func() {
+	var crn *clientReqNo
+
+	BeforeEach(func() {
+		crn = &clientReqNo{
+			clientID:    1,
+			reqNo:       7,
+			nonNullAcks: map[nodeID]*pb.RequestAck{},
+		}
+	})
+
+	It("detects a node acking two different non-null requests", func() {
+		first := &pb.RequestAck{ClientId: 1, ReqNo: 7, Digest: []byte("digest")}
+		Expect(crn.checkNonNullAck(2, first)).To(BeNil())
+		Expect(crn.checkNonNullAck(2, first)).To(BeNil())
+		Expect(crn.checkNonNullAck(2, &pb.RequestAck{ClientId: 1, ReqNo: 7})).To(BeNil())
+		Expect(crn.checkNonNullAck(3, &pb.RequestAck{ClientId: 1, ReqNo: 7, Digest: []byte("other-digest")})).To(BeNil())
+
+		Expect(crn.checkNonNullAck(2, &pb.RequestAck{ClientId: 1, ReqNo: 7, Digest: []byte("other-digest")})).To(Equal(first))
+	})
+})
+
+var _ = Describe("clientTracker", func() {
+	It("retains the size of requests loaded from the request store after a restart", func() {
+		networkState := StandardInitialNetworkState(1, 0)
+		ack := &pb.RequestAck{ClientId: 0, ReqNo: 0, Digest: []byte("digest")}
+
+		sm := &StateMachine{Logger: ConsoleWarnLogger}
+		events := []*pb.StateEvent{
+			{
+				Type: &pb.StateEvent_Initialize{
+					Initialize: &pb.StateEvent_InitialParameters{
+						Id:         0,
+						BatchSize:  1,
+						BufferSize: 1024,
+					},
+				},
+			},
+			{
+				Type: &pb.StateEvent_LoadEntry{
+					LoadEntry: &pb.StateEvent_PersistedEntry{
+						Index: 1,
+						Data: &pb.Persistent{
+							Type: &pb.Persistent_CEntry{
+								CEntry: &pb.CEntry{
+									SeqNo:           0,
+									CheckpointValue: []byte("fake-initial-value"),
+									NetworkState:    networkState,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Type: &pb.StateEvent_LoadEntry{
+					LoadEntry: &pb.StateEvent_PersistedEntry{
+						Index: 2,
+						Data: &pb.Persistent{
+							Type: &pb.Persistent_FEntry{
+								FEntry: &pb.FEntry{
+									EndsEpochConfig: &pb.EpochConfig{
+										Number:  0,
+										Leaders: networkState.Config.Nodes,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Type: &pb.StateEvent_LoadRequest{
+					LoadRequest: &pb.StateEvent_OutstandingRequest{
+						RequestAck: ack,
+						Size:       42,
+					},
+				},
+			},
+			{
+				Type: &pb.StateEvent_CompleteInitialization{
+					CompleteInitialization: &pb.StateEvent_LoadCompleted{},
+				},
+			},
+		}
+
+		for _, event := range events {
+			sm.ApplyEvent(event)
+		}
+
+		crn := sm.clientTracker.clients[0].reqNo(0)
+		Expect(crn.myRequests).To(HaveKey("digest"))
+		Expect(crn.myRequests["digest"].size).To(Equal(42))
+	})
+
+	It("reports a forwarded request which does not match its digest", func() {
+		requestAck := &pb.RequestAck{ClientId: 1, ReqNo: 2, Digest: []byte("digest")}
+		sm := &StateMachine{Logger: ConsoleWarnLogger}
+		actions := sm.processResults(&pb.StateEvent_ActionResults{
+			Digests: []*pb.HashResult{
+				{
+					Digest: []byte("other-digest"),
+					Type: &pb.HashResult_VerifyRequest_{
+						VerifyRequest: &pb.HashResult_VerifyRequest{
+							Source:      2,
+							RequestAck:  requestAck,
+							RequestData: []byte("data"),
+						},
+					},
+				},
+			},
+		})
+
+		Expect(actions.Misbehaviors).To(Equal([]*Misbehavior{
+			{
+				Node: 2,
+				Kind: MalformedMsg,
+				Msgs: []*pb.Msg{
+					{
+						Type: &pb.Msg_ForwardRequest{
+							ForwardRequest: &pb.ForwardRequest{
+								RequestAck:  requestAck,
+								RequestData: []byte("data"),
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	When("a client has been removed", func() {
+		var (
+			ct          *clientTracker
+			p           *persisted
+			ci          uint64
+			removedMsg  *pb.Msg
+			addedClient []*pb.Reconfiguration
+		)
+
+		BeforeEach(func() {
+			initialState := StandardInitialNetworkState(4, 0, 1, 2, 3)
+			initialState.PendingReconfigurations = []*pb.Reconfiguration{
+				{
+					Type: &pb.Reconfiguration_RemoveClient{
+						RemoveClient: 3,
+					},
+				},
+			}
+			ci = uint64(initialState.Config.CheckpointInterval)
+
+			removedState := StandardInitialNetworkState(4, 0, 1, 2)
+
+			p = newPersisted(ConsoleWarnLogger)
+			for i, cEntry := range []*pb.CEntry{
+				{SeqNo: 0, CheckpointValue: []byte("value-0"), NetworkState: initialState},
+				{SeqNo: ci, CheckpointValue: []byte("value-1"), NetworkState: removedState},
+			} {
+				p.appendInitialLoad(&WALEntry{
+					Index: uint64(i + 1),
+					Data: &pb.Persistent{
+						Type: &pb.Persistent_CEntry{
+							CEntry: cEntry,
+						},
+					},
+				})
+			}
+
+			myConfig := &pb.StateEvent_InitialParameters{Id: 0, BufferSize: 1024}
+			ct = newClientWindows(p, newNodeBuffers(myConfig, ConsoleWarnLogger), myConfig, ConsoleWarnLogger)
+			ct.reinitialize()
+
+			removedMsg = &pb.Msg{
+				Type: &pb.Msg_RequestAck{
+					RequestAck: &pb.RequestAck{ClientId: 3, ReqNo: 1, Digest: []byte("digest")},
+				},
+			}
+
+			addedClient = []*pb.Reconfiguration{
+				{
+					Type: &pb.Reconfiguration_NewClient_{
+						NewClient: &pb.Reconfiguration_NewClient{Id: 3, Width: 100},
+					},
+				},
+			}
+		})
+
+		It("discards its messages, even once the removal is truncated from the log", func() {
+			Expect(ct.filter(1, removedMsg)).To(Equal(past))
+
+			p.truncate(ci)
+			ct.reinitialize()
+			Expect(ct.removedClients).To(Equal(map[uint64]uint64{3: ci}))
+			Expect(ct.filter(1, removedMsg)).To(Equal(past))
+		})
+
+		It("buffers its messages once a pending reconfiguration adds it back", func() {
+			ct.addPendingClients(addedClient)
+			Expect(ct.filter(1, removedMsg)).To(Equal(future))
+		})
+
+		It("discards its messages long after the watermarks have moved beyond it", func() {
+			for seqNo := 2 * ci; seqNo <= 10*ci; seqNo += ci {
+				ct.garbageCollect(seqNo)
+			}
+			Expect(ct.filter(1, removedMsg)).To(Equal(past))
+		})
+
+		It("forgets the removal once a checkpoint adds it back", func() {
+			p.addCEntry(&pb.CEntry{
+				SeqNo:           2 * ci,
+				CheckpointValue: []byte("value-2"),
+				NetworkState:    StandardInitialNetworkState(4, 0, 1, 2, 3),
+			})
+			ct.reinitialize()
+			Expect(ct.removedClients).To(BeEmpty())
+			Expect(ct.filter(1, removedMsg)).NotTo(Equal(past))
+		})
+	})
+})
+
+var _ = Describe("requestTimeouts", func() {
+	var myConfig *pb.StateEvent_InitialParameters
+
+	BeforeEach(func() {
+		myConfig = &pb.StateEvent_InitialParameters{}
+	})
+
+	It("defaults unset intervals", func() {
+		rt := newRequestTimeouts(myConfig)
+		Expect(rt.correctFetchTicks).To(Equal(uint(4)))
+		Expect(rt.fetchTimeoutTicks).To(Equal(uint(4)))
+		Expect(rt.ackResendTicks).To(Equal(uint(20)))
+		Expect(rt.ackResendInterval(1)).To(Equal(uint(20)))
+		Expect(rt.ackResendInterval(3)).To(Equal(uint(60)))
+	})
+
+	When("the backoff is exponential", func() {
+		BeforeEach(func() {
+			myConfig.AckResendTicks = 3
+			myConfig.AckResendBackoff = pb.StateEvent_InitialParameters_EXPONENTIAL
+		})
+
+		It("doubles the interval after each resend", func() {
+			rt := newRequestTimeouts(myConfig)
+			Expect(rt.ackResendInterval(1)).To(Equal(uint(3)))
+			Expect(rt.ackResendInterval(2)).To(Equal(uint(6)))
+			Expect(rt.ackResendInterval(4)).To(Equal(uint(24)))
+			Expect(rt.ackResendInterval(1000)).To(BeNumerically(">=", uint(24)))
+		})
+
+		It("caps the interval at the maximum", func() {
+			myConfig.AckResendMaxTicks = 10
+			rt := newRequestTimeouts(myConfig)
+			Expect(rt.ackResendInterval(3)).To(Equal(uint(10)))
+			Expect(rt.ackResendInterval(1000)).To(Equal(uint(10)))
+		})
+	})
+})
+
+type loggedLine struct {
+	level LogLevel
+	text  string
+	args  []interface{}
+}
+
+type recordingLogger struct {
+	entries []loggedLine
+}
+
+func (rl *recordingLogger) Log(level LogLevel, text string, args ...interface{}) {
+	rl.entries = append(rl.entries, loggedLine{level: level, text: text, args: args})
+}
+
+var _ = Describe("client", func() {
+	It("warns of the acked requests discarded as its window shrinks", func() {
+		logger := &recordingLogger{}
+		networkConfig := StandardInitialNetworkState(4, 0).Config
+		c := newClient(&requestTimeouts{}, logger)
+
+		wide := &pb.NetworkState_Client{Id: 0, Width: 10, LowWatermark: 5}
+		c.reinitialize(networkConfig, 0, 0, wide, wide)
+		for _, reqNo := range []uint64{8, 12, 14} {
+			c.reqNoMap[reqNo].Value.(*clientReqNo).requests["digest"] = &clientRequest{
+				ack:        &pb.RequestAck{ClientId: 0, ReqNo: reqNo, Digest: []byte("digest")},
+				agreements: map[nodeID]struct{}{1: {}},
+			}
+		}
+
+		narrow := &pb.NetworkState_Client{Id: 0, Width: 5, LowWatermark: 5}
+		c.reinitialize(networkConfig, 0, 0, narrow, narrow)
+		Expect(c.highWatermark).To(Equal(uint64(10)))
+
+		var warnings []loggedLine
+		for _, entry := range logger.entries {
+			if entry.level == LevelWarn {
+				warnings = append(warnings, entry)
+			}
+		}
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].args).To(Equal([]interface{}{
+			"client_id", uint64(0),
+			"high_watermark", uint64(10),
+			"req_nos", []uint64{12, 14},
+		}))
+	})
+})
@@ -21,10 +21,13 @@ import (
 // commits, we wait for a strong checkpoint, then perform an epoch change
 // and restart the state machine with the new network state as starting state.
 // When a checkpoint result returns, it becomes the new activeState, the upperHalf
-// of the committed sequences becomes the lowerHalf.
+// of the committed sequences becomes the lowerHalf.  The exception is the checkpoint
+// which applies pending reconfigurations, the activeState remains the old network
+// state until the checkpoint is stable and the state machine reinitializes.
 type commitState struct {
 	persisted     *persisted
 	clientTracker *clientTracker
+	myConfig      *pb.StateEvent_InitialParameters
 	logger        Logger
 
 	lowWatermark      uint64
@@ -36,12 +39,26 @@ type commitState struct {
 	upperHalfCommits  []*pb.QEntry
 	checkpointPending bool
 	transferring      bool
+
+	// failedTransfer is the target of a state transfer which the consumer
+	// failed to obtain, and which is retried once transferRetryTicks reaches
+	// the configured retry interval.
+	failedTransfer     *pb.TEntry
+	transferRetryTicks uint32
+
+	// reconfigured is set once the checkpoint at stopAtSeqNo, which applies
+	// the pending reconfigurations, has been computed.  reconfigurationStable
+	// is set once that checkpoint is known to be stable, at which point the
+	// state machine must reinitialize under the new network state.
+	reconfigured          bool
+	reconfigurationStable bool
 }
 
-func newCommitState(persisted *persisted, clientTracker *clientTracker, logger Logger) *commitState {
+func newCommitState(persisted *persisted, clientTracker *clientTracker, myConfig *pb.StateEvent_InitialParameters, logger Logger) *commitState {
 	cs := &commitState{
 		clientTracker: clientTracker,
 		persisted:     persisted,
+		myConfig:      myConfig,
 		logger:        logger,
 	}
 
@@ -54,35 +71,55 @@ func (cs *commitState) reinitialize() *Actions {
 
 	cs.persisted.iterate(logIterator{
 		onCEntry: func(cEntry *pb.CEntry) {
+			if lastCEntry != nil && cEntry.SeqNo <= lastCEntry.SeqNo {
+				// We transferred away from a checkpoint which diverged from
+				// the network, the transferred checkpoint supersedes ours.
+				lastCEntry = nil
+			}
 			lastCEntry, secondToLastCEntry = cEntry, lastCEntry
+			if lastTEntry != nil && cEntry.SeqNo >= lastTEntry.SeqNo {
+				// The state transfer completed
+				lastTEntry = nil
+			}
 		},
 		onTEntry: func(tEntry *pb.TEntry) {
 			lastTEntry = tEntry
 		},
 	})
 
-	if secondToLastCEntry == nil || len(secondToLastCEntry.NetworkState.PendingReconfigurations) == 0 {
-		cs.activeState = lastCEntry.NetworkState
-		cs.lowWatermark = lastCEntry.SeqNo
-	} else {
+	cs.reconfigured = secondToLastCEntry != nil &&
+		len(secondToLastCEntry.NetworkState.PendingReconfigurations) != 0 &&
+		lastCEntry.SeqNo == secondToLastCEntry.SeqNo+uint64(secondToLastCEntry.NetworkState.Config.CheckpointInterval)
+	cs.reconfigurationStable = false
+
+	if cs.reconfigured {
+		// We computed the checkpoint which applies the pending reconfigurations,
+		// but it did not become stable before we reinitialized, so we continue
+		// under the old network state until it does.
 		cs.activeState = secondToLastCEntry.NetworkState
-		cs.lowWatermark = secondToLastCEntry.SeqNo
+	} else {
+		cs.activeState = lastCEntry.NetworkState
 	}
+	cs.lowWatermark = lastCEntry.SeqNo
 
 	ci := uint64(cs.activeState.Config.CheckpointInterval)
-	if len(cs.activeState.PendingReconfigurations) == 0 {
+	switch {
+	case cs.reconfigured:
+		cs.stopAtSeqNo = lastCEntry.SeqNo
+	case len(cs.activeState.PendingReconfigurations) == 0:
 		cs.stopAtSeqNo = lastCEntry.SeqNo + 2*ci
-	} else {
+	default:
 		cs.stopAtSeqNo = lastCEntry.SeqNo + ci
 	}
 
 	cs.lastAppliedCommit = lastCEntry.SeqNo
 	cs.highestCommit = lastCEntry.SeqNo
+	cs.failedTransfer = nil
 
 	cs.lowerHalfCommits = make([]*pb.QEntry, ci)
 	cs.upperHalfCommits = make([]*pb.QEntry, ci)
 
-	if lastTEntry == nil || lastCEntry.SeqNo >= lastTEntry.SeqNo {
+	if lastTEntry == nil {
 		cs.logger.Log(LevelDebug, "reinitialized commit-state", "low_watermark", cs.lowWatermark, "stop_at_seq_no", cs.stopAtSeqNo, "len(pending_reconfigurations)", len(cs.activeState.PendingReconfigurations), "last_checkpoint_seq_no", lastCEntry.SeqNo)
 		cs.transferring = false
 		return &Actions{}
@@ -90,7 +127,8 @@ func (cs *commitState) reinitialize() *Actions {
 
 	cs.logger.Log(LevelInfo, "reinitialized commit-state detected crash during state transfer", "target_seq_no", lastTEntry.SeqNo, "target_value", lastTEntry.Value)
 
-	// We crashed during a state transfer
+	// We crashed during a state transfer, or are joining the network
+	// and have not yet transferred to our bootstrap checkpoint
 	cs.transferring = true
 	return &Actions{
 		StateTransfer: &StateTarget{
@@ -115,6 +153,49 @@ func (cs *commitState) transferTo(seqNo uint64, value []byte) *Actions {
 	})
 }
 
+// transferFailed records that the consumer failed to obtain the target of
+// the state transfer.  Rather than retry immediately, which would spin should
+// the target be unreachable, the retry waits for ticks, see transferRetryDue.
+func (cs *commitState) transferFailed(seqNo uint64, value []byte) {
+	assertEqual(cs.transferring, true, "state transfer failed, but no state transfer was in progress")
+	cs.failedTransfer = &pb.TEntry{
+		SeqNo: seqNo,
+		Value: value,
+	}
+	cs.transferRetryTicks = 0
+}
+
+// transferRetryDue is invoked on each tick, and returns the target of the
+// failed state transfer once enough ticks have elapsed that it should be
+// retried.
+func (cs *commitState) transferRetryDue() (*pb.TEntry, bool) {
+	if cs.failedTransfer == nil {
+		return nil, false
+	}
+
+	retryTicks := cs.myConfig.TransferRetryTicks
+	if retryTicks == 0 {
+		retryTicks = 4
+	}
+
+	cs.transferRetryTicks++
+	if cs.transferRetryTicks < retryTicks {
+		return nil, false
+	}
+
+	failed := cs.failedTransfer
+	cs.failedTransfer = nil
+	return failed, true
+}
+
+// retryTransfer requests another state transfer after the consumer failed
+// to obtain the previous target.
+func (cs *commitState) retryTransfer(seqNo uint64, value []byte) *Actions {
+	assertEqual(cs.transferring, true, "state transfer retried, but no state transfer was in progress")
+	cs.transferring = false
+	return cs.transferTo(seqNo, value)
+}
+
 func (cs *commitState) applyCheckpointResult(epochConfig *pb.EpochConfig, result *pb.CheckpointResult) *Actions {
 	cs.logger.Log(LevelDebug, "applying checkpoint result", "seq_no", result.SeqNo, "value", result.Value)
 	ci := uint64(cs.activeState.Config.CheckpointInterval)
@@ -127,24 +208,40 @@ func (cs *commitState) applyCheckpointResult(epochConfig *pb.EpochConfig, result
 		panic("dev sanity test -- this panic is helpful for dev, but needs to be removed as we could get stale checkpoint results")
 	}
 
-	if len(result.NetworkState.PendingReconfigurations) == 0 {
+	switch {
+	case len(cs.activeState.PendingReconfigurations) != 0:
+		// This checkpoint applies the pending reconfigurations, nothing further
+		// may commit until it is stable and we reinitialize under the new state.
+		cs.logger.Log(LevelDebug, "checkpoint result applies pending reconfigurations, stopping", "stop_at_seq_no", cs.stopAtSeqNo)
+		cs.reconfigured = true
+	case len(result.NetworkState.PendingReconfigurations) == 0:
 		cs.stopAtSeqNo = result.SeqNo + 2*ci
-	} else {
+	default:
 		cs.logger.Log(LevelDebug, "checkpoint result has pending reconfigurations, not extending stop", "stop_at_seq_no", cs.stopAtSeqNo)
 	}
 
-	cs.activeState = result.NetworkState
+	if !cs.reconfigured {
+		cs.activeState = result.NetworkState
+	}
+	cs.clientTracker.addPendingClients(result.NetworkState.PendingReconfigurations)
 	cs.lowerHalfCommits = cs.upperHalfCommits
 	cs.upperHalfCommits = make([]*pb.QEntry, ci)
 	cs.lowWatermark = result.SeqNo
 	cs.checkpointPending = false
 
+	checkpointTargets := cs.activeState.Config.Nodes
+	if cs.reconfigured {
+		// Nodes joining the network learn of the checkpoint which adds
+		// them, so that they may transfer to it and begin participating.
+		checkpointTargets = unionOfNodes(checkpointTargets, result.NetworkState.Config.Nodes)
+	}
+
 	return cs.persisted.addCEntry(&pb.CEntry{
 		SeqNo:           result.SeqNo,
 		CheckpointValue: result.Value,
 		NetworkState:    result.NetworkState,
 	}).send(
-		cs.activeState.Config.Nodes,
+		checkpointTargets,
 		&pb.Msg{
 			Type: &pb.Msg_Checkpoint{
 				Checkpoint: &pb.Checkpoint{
@@ -199,7 +296,7 @@ func nextNetworkConfig(startingState *pb.NetworkState, clientConfigs []*pb.Netwo
 	for _, reconfig := range startingState.PendingReconfigurations {
 		switch rc := reconfig.Type.(type) {
 		case *pb.Reconfiguration_NewClient_:
-			clientConfigs = append(clientConfigs, &pb.NetworkState_Client{
+			nextClients = append(nextClients, &pb.NetworkState_Client{
 				Id:    rc.NewClient.Id,
 				Width: rc.NewClient.Width,
 			})
@@ -219,10 +316,55 @@ func nextNetworkConfig(startingState *pb.NetworkState, clientConfigs []*pb.Netwo
 			assertTruef(found, "asked to remove client %d which doesn't exist", rc.RemoveClient)
 		case *pb.Reconfiguration_NewConfig:
 			nextConfig = rc.NewConfig
+		case *pb.Reconfiguration_ClientWidth_:
+			found := false
+			for i, clientConfig := range nextClients {
+				if clientConfig.Id != rc.ClientWidth.Id {
+					continue
+				}
+
+				found = true
+				nextClients[i] = resizeClient(clientConfig, rc.ClientWidth.Width)
+				break
+			}
+
+			// TODO, heavy handed, back off to a warning
+			assertTruef(found, "asked to resize client %d which doesn't exist", rc.ClientWidth.Id)
 		}
 	}
 
-	return nextConfig, clientConfigs
+	return nextConfig, nextClients
+}
+
+// resizeClient returns a copy of the client state with a new window width.
+// The window may not shrink to exclude requests which have already committed
+// above the low watermark, and the width consumed in the last checkpoint
+// interval is capped at the new width.  Because the state machine reinitializes
+// under the new client state once the reconfiguration is stable, the new
+// watermarks apply to every node from the same sequence.  Uncommitted requests
+// above the shrunken window are discarded as the state machine reinitializes,
+// and their proposers learn of this through a RequestsDroppedError.
+func resizeClient(clientState *pb.NetworkState_Client, width uint32) *pb.NetworkState_Client {
+	mask := bitmask(clientState.CommittedMask)
+	for i := 8*len(mask) - 1; i > int(width); i-- {
+		if mask.isBitSet(i) {
+			width = uint32(i)
+			break
+		}
+	}
+
+	widthConsumed := clientState.WidthConsumedLastCheckpoint
+	if widthConsumed > width {
+		widthConsumed = width
+	}
+
+	return &pb.NetworkState_Client{
+		Id:                          clientState.Id,
+		Width:                       width,
+		WidthConsumedLastCheckpoint: widthConsumed,
+		LowWatermark:                clientState.LowWatermark,
+		CommittedMask:               clientState.CommittedMask,
+	}
 }
 
 // drain returns all available Commits (including checkpoint requests)
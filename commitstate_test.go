@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("resizeClient", func() {
+	var clientState *pb.NetworkState_Client
+
+	BeforeEach(func() {
+		mask := bitmask(make([]byte, 2))
+		mask.setBit(12)
+
+		clientState = &pb.NetworkState_Client{
+			Id:                          3,
+			Width:                       20,
+			WidthConsumedLastCheckpoint: 8,
+			LowWatermark:                40,
+			CommittedMask:               mask,
+		}
+	})
+
+	It("grows the window, retaining the consumed width", func() {
+		resized := resizeClient(clientState, 50)
+		Expect(resized.Width).To(Equal(uint32(50)))
+		Expect(resized.WidthConsumedLastCheckpoint).To(Equal(uint32(8)))
+		Expect(resized.LowWatermark).To(Equal(uint64(40)))
+		Expect(resized.CommittedMask).To(Equal(clientState.CommittedMask))
+		Expect(clientState.Width).To(Equal(uint32(20)))
+	})
+
+	It("does not shrink the window below committed requests", func() {
+		resized := resizeClient(clientState, 5)
+		Expect(resized.Width).To(Equal(uint32(12)))
+		Expect(resized.WidthConsumedLastCheckpoint).To(Equal(uint32(8)))
+	})
+
+	It("caps the consumed width at the new width", func() {
+		clientState.CommittedMask = nil
+		resized := resizeClient(clientState, 5)
+		Expect(resized.Width).To(Equal(uint32(5)))
+		Expect(resized.WidthConsumedLastCheckpoint).To(Equal(uint32(5)))
+	})
+})
+
+var _ = Describe("commitState state transfer retries", func() {
+	var cs *commitState
+
+	BeforeEach(func() {
+		cs = &commitState{
+			myConfig: &pb.StateEvent_InitialParameters{
+				TransferRetryTicks: 3,
+			},
+			transferring: true,
+		}
+	})
+
+	It("waits the configured ticks before retrying a failed transfer", func() {
+		_, ok := cs.transferRetryDue()
+		Expect(ok).To(BeFalse())
+
+		cs.transferFailed(20, []byte("value"))
+		for i := 0; i < 2; i++ {
+			_, ok := cs.transferRetryDue()
+			Expect(ok).To(BeFalse())
+		}
+
+		failed, ok := cs.transferRetryDue()
+		Expect(ok).To(BeTrue())
+		Expect(failed.SeqNo).To(Equal(uint64(20)))
+		Expect(failed.Value).To(Equal([]byte("value")))
+
+		_, ok = cs.transferRetryDue()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("defaults to four ticks", func() {
+		cs.myConfig.TransferRetryTicks = 0
+		cs.transferFailed(20, []byte("value"))
+		for i := 0; i < 3; i++ {
+			_, ok := cs.transferRetryDue()
+			Expect(ok).To(BeFalse())
+		}
+
+		_, ok := cs.transferRetryDue()
+		Expect(ok).To(BeTrue())
+	})
+})
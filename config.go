@@ -21,6 +21,36 @@ type Config struct {
 	// before it is cut. (Note, batches may be cut earlier, so this is a max size).
 	BatchSize uint32
 
+	// MaxBatchBytes, if non-zero, determines how large a batch may grow (in total
+	// bytes of request data) before it is cut.  A batch always contains at least
+	// one request, regardless of its size.  Note, the size of a request is only
+	// known once this node has stored it, requests not yet stored are counted as
+	// empty.
+	MaxBatchBytes uint32
+
+	// MinBatchSize, if non-zero, is the number of requests which, once pending,
+	// cause a batch to be cut before it reaches BatchSize.  Otherwise, a batch
+	// is cut only once it is full, or when a heartbeat or the batch timeout fires.
+	MinBatchSize uint32
+
+	// BatchTimeoutTicks, if non-zero, is the number of ticks a request may be
+	// pending before the batch containing it is cut, regardless of its size.
+	BatchTimeoutTicks uint32
+
+	// AdaptiveBatchSize, if set, varies the number of requests at which a batch
+	// is cut, between MinBatchSize and BatchSize, according to the number of
+	// requests which were queued when recent batches were cut.  So, under load,
+	// the leader waits to cut larger batches, and when idle cuts batches as soon
+	// as MinBatchSize is reached.  This should generally be combined with
+	// BatchTimeoutTicks so that the batch size may decrease as load decreases.
+	AdaptiveBatchSize bool
+
+	// LeaderPolicy, if set, selects the leaders of each new epoch for which this
+	// node is the primary.  If not set, the SuspectLeaderPolicy is used.  Because
+	// the policy is not recorded in the event log, replaying an event log of a
+	// node with a custom policy requires supplying the same policy.
+	LeaderPolicy LeaderPolicy
+
 	// HeartbeatTicks is the number of ticks before a heartbeat is emitted
 	// by a leader.
 	HeartbeatTicks uint32
@@ -41,6 +71,39 @@ type Config struct {
 	// to a minimum of a few MB.
 	BufferSize uint32
 
+	// CorrectFetchTicks is the number of ticks a request may be known to be
+	// correct, but not stored, before this node fetches it from the nodes
+	// which acked it.  If zero, 4 ticks are used.
+	CorrectFetchTicks uint32
+
+	// FetchTimeoutTicks is the number of ticks a fetch for a request may go
+	// unanswered before it is retried.  If zero, 4 ticks are used.
+	FetchTimeoutTicks uint32
+
+	// AckResendTicks is the number of ticks after which an ack for a request
+	// is re-sent if the request has not yet committed.  If zero, 20 ticks are used.
+	// Lower values speed recovery from lost acks at the cost of more traffic.
+	AckResendTicks uint32
+
+	// AckResendBackoff determines how the interval between ack re-sends grows
+	// with each re-send.  By default, the backoff is linear.
+	AckResendBackoff BackoffStrategy
+
+	// AckResendMaxTicks, if non-zero, is the maximum number of ticks between
+	// ack re-sends, regardless of backoff.
+	AckResendMaxTicks uint32
+
+	// TransferOnCheckpointDivergence, if set, causes this node to report and then
+	// state transfer to the network's checkpoint when the checkpoint value it
+	// computed disagrees with the value attested to by a quorum of the network.
+	// This indicates a non-deterministic application (or a violation of the
+	// byzantine assumptions).  If not set, such a divergence causes a panic.
+	TransferOnCheckpointDivergence bool
+
+	// TransferRetryTicks is the number of ticks after a state transfer fails
+	// before another is requested.  If zero, 4 ticks are used.
+	TransferRetryTicks uint32
+
 	// EventInterceptor, if set, has its Intercept method invoked each time the
 	// state machine undergoes some mutation.  This allows for additional
 	// external insight into the state machine, but comes at a performance cost
@@ -48,14 +111,40 @@ type Config struct {
 	EventInterceptor EventInterceptor
 }
 
+// BackoffStrategy determines how a retry interval grows with each retry.
+type BackoffStrategy int32
+
+const (
+	// LinearBackoff waits one additional interval longer after each retry.
+	LinearBackoff = BackoffStrategy(pb.StateEvent_InitialParameters_LINEAR)
+
+	// ExponentialBackoff doubles the interval after each retry.  It should
+	// generally be combined with a maximum interval.
+	ExponentialBackoff = BackoffStrategy(pb.StateEvent_InitialParameters_EXPONENTIAL)
+)
+
 // EventInterceptor provides a way for a consumer to gain insight into
 // the internal operation of the state machine.  And is usually not
 // interesting outside of debugging or testing scenarios.  Note, this
 // is applied inside the serializer, so any blocking will prevent the
-// event from arriving at the state machine until it returns.
+// event from arriving at the state machine until it returns.  The
+// eventlog.Recorder may instead be configured to drop events when it
+// cannot keep up, see eventlog.DropOnOverflowOpt.
 type EventInterceptor interface {
 	// Intercept is invoked prior to passing each state event to
 	// the state machine.  If Intercept returns an error, the
 	// state machine halts.
 	Intercept(s *pb.StateEvent) error
 }
+
+// ActionsInterceptor may optionally be implemented by an EventInterceptor
+// to additionally gain insight into the actions the state machine emits.
+// Like Intercept, it is applied inside the serializer, so any blocking
+// will prevent further events from arriving at the state machine.
+type ActionsInterceptor interface {
+	// InterceptActions is invoked with each set of actions handed to the
+	// consumer via Ready(), prior to the ActionsReceived event which
+	// acknowledges them.  If InterceptActions returns an error, the state
+	// machine halts.
+	InterceptActions(a *Actions) error
+}
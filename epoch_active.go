@@ -39,6 +39,7 @@ type activeEpoch struct {
 
 	lastCommittedAtTick uint64
 	ticksSinceProgress  uint32
+	suspected           bool // set once our suspicion of this epoch is persisted
 }
 
 func newActiveEpoch(epochConfig *pb.EpochConfig, persisted *persisted, nodeBuffers *nodeBuffers, commitState *commitState, clientTracker *clientTracker, myConfig *pb.StateEvent_InitialParameters, logger Logger) *activeEpoch {
@@ -138,6 +139,17 @@ func (e *activeEpoch) sequence(seqNo uint64) *sequence {
 	return sequence
 }
 
+// releaseBuffers discards any messages buffered for this epoch.
+func (ae *activeEpoch) releaseBuffers() {
+	for _, ppb := range ae.preprepareBuffers {
+		ppb.buffer.clear()
+	}
+
+	for _, buffer := range ae.otherBuffers {
+		buffer.clear()
+	}
+}
+
 func (ae *activeEpoch) filter(source nodeID, msg *pb.Msg) applyable {
 	switch innerMsg := msg.Type.(type) {
 	case *pb.Msg_Preprepare:
@@ -295,13 +307,11 @@ func (e *activeEpoch) applyPrepareMsg(source nodeID, seqNo uint64, digest []byte
 func (e *activeEpoch) applyCommitMsg(source nodeID, seqNo uint64, digest []byte) *Actions {
 	seq := e.sequence(seqNo)
 
-	seq.applyCommitMsg(source, digest)
+	actions := seq.applyCommitMsg(source, digest)
 	if seq.state != sequenceCommitted || seqNo != e.lowestUncommitted {
-		return &Actions{}
+		return actions
 	}
 
-	actions := &Actions{}
-
 	for e.lowestUncommitted <= e.highWatermark() {
 		seq := e.sequence(e.lowestUncommitted)
 		if seq.state != sequenceCommitted {
@@ -390,6 +400,14 @@ func (e *activeEpoch) advance() *Actions {
 
 	actions.concat(e.drainBuffers())
 
+	return actions.concat(e.allocateBatches())
+}
+
+// allocateBatches allocates a sequence for each batch which the proposer
+// is ready to cut, for the buckets this node leads.
+func (e *activeEpoch) allocateBatches() *Actions {
+	actions := &Actions{}
+
 	e.proposer.advance(e.lowestUncommitted)
 
 	for bucketID, ownerID := range e.buckets {
@@ -434,14 +452,16 @@ func (e *activeEpoch) applyBatchHashResult(seqNo uint64, digest []byte) *Actions
 }
 
 func (e *activeEpoch) tick() *Actions {
+	e.proposer.tick()
+	actions := e.allocateBatches()
+
 	if e.lastCommittedAtTick < e.commitState.highestCommit {
 		e.lastCommittedAtTick = e.commitState.highestCommit
 		e.ticksSinceProgress = 0
-		return &Actions{}
+		return actions
 	}
 
 	e.ticksSinceProgress++
-	actions := &Actions{}
 
 	if e.ticksSinceProgress > e.myConfig.SuspectTicks {
 		suspect := &pb.Suspect{
@@ -452,7 +472,12 @@ func (e *activeEpoch) tick() *Actions {
 				Suspect: suspect,
 			},
 		})
-		actions.concat(e.persisted.addSuspect(suspect))
+		if !e.suspected {
+			// The suspicion need only be persisted once, it is rebroadcast
+			// each tick, and on restart.
+			actions.concat(e.persisted.addSuspect(suspect))
+			e.suspected = true
+		}
 		e.logger.Log(LevelDebug, "suspect epoch to have failed due to lack of active progress", "epoch_no", e.epochConfig.Number)
 	}
 
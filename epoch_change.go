@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package mirbft
 
 import (
+	"bytes"
 	"sort"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
@@ -24,9 +25,64 @@ type epochChange struct {
 
 	// updated via updateAcks
 	strongCert []byte
+
+	// originChanges are the distinct epoch changes received directly from
+	// the originator, in the order received, and originDigests their digests.
+	originChanges []*pb.EpochChange
+	originDigests map[string]struct{}
+
+	// sourceAcks are the epoch changes first acked by each node other
+	// than the originator.
+	sourceAcks map[nodeID]*sourceAck
+}
+
+type sourceAck struct {
+	digest []byte
+	msg    *pb.EpochChange
+}
+
+// addSourceAck records the epoch change a node other than the originator
+// acked.  A correct originator sends a single epoch change, so if the node
+// previously acked a different epoch change, that epoch change is returned
+// and the new one should be discarded.
+func (ec *epochChange) addSourceAck(source nodeID, msg *pb.EpochChange, digest []byte) *pb.EpochChange {
+	if ec.sourceAcks == nil {
+		ec.sourceAcks = map[nodeID]*sourceAck{}
+	}
+
+	previous, ok := ec.sourceAcks[source]
+	if !ok {
+		ec.sourceAcks[source] = &sourceAck{
+			digest: digest,
+			msg:    msg,
+		}
+		return nil
+	}
+
+	if bytes.Equal(previous.digest, digest) {
+		return nil
+	}
+
+	return previous.msg
+}
+
+// addOriginMsg records an epoch change received directly from its originator.
+// It returns false if the originator has already sent this epoch change.
+func (ec *epochChange) addOriginMsg(msg *pb.EpochChange, digest []byte) bool {
+	if ec.originDigests == nil {
+		ec.originDigests = map[string]struct{}{}
+	}
+
+	if _, ok := ec.originDigests[string(digest)]; ok {
+		return false
+	}
+
+	ec.originDigests[string(digest)] = struct{}{}
+	ec.originChanges = append(ec.originChanges, msg)
+	return true
 }
 
-func (ec *epochChange) addMsg(source nodeID, msg *pb.EpochChange, digest []byte) {
+func (ec *epochChange) addMsg(source nodeID, msg *pb.EpochChange, digest []byte) error {
 	if ec.parsedByDigest == nil {
 		ec.parsedByDigest = map[string]*parsedEpochChange{}
 	}
@@ -36,8 +92,7 @@ func (ec *epochChange) addMsg(source nodeID, msg *pb.EpochChange, digest []byte)
 		var err error
 		parsedChange, err = newParsedEpochChange(msg)
 		if err != nil {
-			// TODO, log
-			return
+			return err
 		}
 		ec.parsedByDigest[string(digest)] = parsedChange
 	}
@@ -45,10 +100,11 @@ func (ec *epochChange) addMsg(source nodeID, msg *pb.EpochChange, digest []byte)
 	parsedChange.acks[source] = struct{}{}
 
 	if ec.strongCert != nil || len(parsedChange.acks) < intersectionQuorum(ec.networkConfig) {
-		return
+		return nil
 	}
 
 	ec.strongCert = digest
+	return nil
 }
 
 type parsedEpochChange struct {
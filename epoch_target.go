@@ -54,6 +54,8 @@ type epochTarget struct {
 	leaderNewEpoch  *pb.NewEpoch       // The NewEpoch msg we received directly from the leader
 	networkNewEpoch *pb.NewEpochConfig // The NewEpoch msg as received via the bracha broadcast
 	isLeader        bool
+	gracefulEnd     bool // set when the active epoch reaches its planned end
+	suspected       bool // set once our suspicion of this epoch is persisted
 	prestartBuffers map[nodeID]*msgBuffer
 
 	persisted     *persisted
@@ -92,7 +94,7 @@ func newEpochTarget(
 		strongChanges:   map[nodeID]*parsedEpochChange{},
 		echos:           map[*pb.NewEpochConfig]map[nodeID]struct{}{},
 		readies:         map[*pb.NewEpochConfig]map[nodeID]struct{}{},
-		isLeader:        number%uint64(len(networkConfig.Nodes)) == myConfig.Id,
+		isLeader:        epochPrimary(networkConfig, number) == myConfig.Id,
 		prestartBuffers: prestartBuffers,
 		persisted:       persisted,
 		nodeBuffers:     nodeBuffers,
@@ -104,6 +106,18 @@ func newEpochTarget(
 	}
 }
 
+// releaseBuffers discards any messages buffered for this epoch, it is
+// invoked once the target is replaced.
+func (et *epochTarget) releaseBuffers() {
+	for _, buffer := range et.prestartBuffers {
+		buffer.clear()
+	}
+
+	if et.activeEpoch != nil {
+		et.activeEpoch.releaseBuffers()
+	}
+}
+
 func (et *epochTarget) step(source nodeID, msg *pb.Msg) *Actions {
 	if et.state < etInProgress {
 		et.prestartBuffers[source].store(msg)
@@ -159,8 +173,8 @@ func (et *epochTarget) verifyNewEpochState() *Actions {
 	epochChanges := map[nodeID]*parsedEpochChange{}
 	for _, remoteEpochChange := range et.leaderNewEpoch.EpochChanges {
 		if _, ok := epochChanges[nodeID(remoteEpochChange.NodeId)]; ok {
-			// TODO, references multiple epoch changes from the same node, malformed, log oddity
-			return &Actions{}
+			// References multiple epoch changes from the same node
+			return et.discardLeaderNewEpoch()
 		}
 
 		change, ok := et.changes[nodeID(remoteEpochChange.NodeId)]
@@ -184,8 +198,9 @@ func (et *epochTarget) verifyNewEpochState() *Actions {
 	newEpochConfig := constructNewEpochConfig(et.networkConfig, et.leaderNewEpoch.NewConfig.Config.Leaders, epochChanges)
 
 	if !proto.Equal(newEpochConfig, et.leaderNewEpoch.NewConfig) {
-		// TODO byzantine, log oddity
-		return &Actions{}
+		// The epoch changes referenced are verified, so the leader
+		// must have constructed the config incorrectly.
+		return et.discardLeaderNewEpoch()
 	}
 
 	et.logger.Log(LevelDebug, "epoch transitioning from from verifying to fetching", "epoch_no", et.number)
@@ -194,6 +209,24 @@ func (et *epochTarget) verifyNewEpochState() *Actions {
 	return et.advanceState()
 }
 
+// discardLeaderNewEpoch reports the leader's malformed NewEpoch message, and
+// returns to waiting for a NewEpoch.  Should the leader send no other, the
+// epoch change will time out, as if no NewEpoch message had been sent.
+func (et *epochTarget) discardLeaderNewEpoch() *Actions {
+	leader := nodeID(epochPrimary(et.networkConfig, et.number))
+	msg := &pb.Msg{
+		Type: &pb.Msg_NewEpoch{
+			NewEpoch: et.leaderNewEpoch,
+		},
+	}
+
+	et.logger.Log(LevelWarn, "discarding malformed new epoch message from leader", "epoch_no", et.number, "leader", leader)
+	et.leaderNewEpoch = nil
+	et.state = etPending
+
+	return (&Actions{}).misbehave(leader, MalformedMsg, msg)
+}
+
 func (et *epochTarget) fetchNewEpochState() *Actions {
 	newEpochConfig := et.leaderNewEpoch.NewConfig
 
@@ -210,6 +243,7 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 
 	actions := &Actions{}
 	fetchPending := false
+	preparedBeyondStop := false
 
 	for i, digest := range newEpochConfig.FinalPreprepares {
 		if len(digest) == 0 {
@@ -218,6 +252,13 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 
 		seqNo := uint64(i) + newEpochConfig.StartingCheckpoint.SeqNo + 1
 
+		if seqNo > et.commitState.stopAtSeqNo {
+			// This sequence was prepared under the next network configuration,
+			// we will consent on it again once we have reconfigured.
+			preparedBeyondStop = true
+			continue
+		}
+
 		if seqNo <= et.commitState.highestCommit {
 			continue
 		}
@@ -276,10 +317,7 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 		return actions
 	}
 
-	et.logger.Log(LevelDebug, "epoch transitioning from fetching to echoing", "epoch_no", et.number)
-	et.state = etEchoing
-
-	if newEpochConfig.StartingCheckpoint.SeqNo == et.commitState.stopAtSeqNo && len(newEpochConfig.FinalPreprepares) > 0 {
+	if newEpochConfig.StartingCheckpoint.SeqNo == et.commitState.stopAtSeqNo && preparedBeyondStop {
 		// We know at this point that
 		// newEpochConfig.StartingCheckpoint.SeqNo <= et.commitState.lowWatermark
 		// and always et.commitState.lowWatermark <= et.commitState.stopAtSeqNo
@@ -288,18 +326,20 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 		// will wait for a strong checkpoint quorum before preparing beyond a reconfiguration
 		// we therefore know that this checkpoint is in fact stable, and we must
 		// reinitialize under the new network configuration before processing further.
-
-		// XXX the problem becomes though, that in order to reinitialize, we need to
-		// append the FEntry, but, then we truncate the log, and if we crash, we come
-		// back up unaware of our previous epoch change message.  Fine, we know
-		// the checkpoint is strong, but, without epoch message reliable
-		// (re)broadcast, the new view timer might never start at other nodes
-		// and we could get stuck in this epoch forever.
-
-		panic("deal with this")
+		// The epoch tracker persists the end of the configuration along with our
+		// epoch change, which we rebroadcast under the new configuration, so that
+		// the epoch change proceeds even if we crash while reinitializing.
+		assertTruef(et.commitState.reconfigured, "prepared beyond the stop sequence %d, but it does not apply a reconfiguration", et.commitState.stopAtSeqNo)
+		et.commitState.reconfigurationStable = true
+		return actions
 	}
 
-	// XXX what if the final preprepares span both an old and new config?
+	et.logger.Log(LevelDebug, "epoch transitioning from fetching to echoing", "epoch_no", et.number)
+	et.state = etEchoing
+
+	// If the final preprepares span both the old and the new network configuration,
+	// we consent only on those in the old configuration, the remainder will be
+	// consented on again once we have reconfigured.
 
 	actions.concat(et.persisted.addNEntry(&pb.NEntry{
 		SeqNo:       newEpochConfig.StartingCheckpoint.SeqNo + 1,
@@ -308,6 +348,10 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 	for i, digest := range newEpochConfig.FinalPreprepares {
 		seqNo := uint64(i) + newEpochConfig.StartingCheckpoint.SeqNo + 1
 
+		if seqNo > et.commitState.stopAtSeqNo {
+			break
+		}
+
 		if len(digest) == 0 {
 			actions.concat(et.persisted.addQEntry(&pb.QEntry{
 				SeqNo: seqNo,
@@ -338,6 +382,9 @@ func (et *epochTarget) fetchNewEpochState() *Actions {
 
 	et.startingSeqNo = newEpochConfig.StartingCheckpoint.SeqNo +
 		uint64(len(newEpochConfig.FinalPreprepares)) + 1
+	if et.startingSeqNo > et.commitState.stopAtSeqNo+1 {
+		et.startingSeqNo = et.commitState.stopAtSeqNo + 1
+	}
 
 	return actions.send(
 		et.networkConfig.Nodes,
@@ -401,10 +448,22 @@ func (et *epochTarget) tickPrepending() *Actions {
 
 func (et *epochTarget) tickPending() *Actions {
 	pendingTicks := et.stateTicks % uint64(et.myConfig.NewEpochTimeoutTicks)
+	if et.myEpochChange == nil {
+		// We crashed during this epoch and suspected it when we restarted,
+		// there is no epoch change to repeat, but we continue to broadcast
+		// our suspicion until the epoch ends.
+		if pendingTicks == 0 {
+			return et.suspect()
+		}
+		return &Actions{}
+	}
+
 	if et.isLeader {
-		// resend the new-view if others perhaps missed it
+		// resend the new-view if others perhaps missed it, along with
+		// our epoch change, as a restarted node must again collect a
+		// quorum of epoch changes before it will accept the new-view.
 		if pendingTicks%2 == 0 {
-			return (&Actions{}).send(
+			return et.repeatEpochChangeBroadcast().send(
 				et.networkConfig.Nodes,
 				&pb.Msg{
 					Type: &pb.Msg_NewEpoch{
@@ -415,17 +474,7 @@ func (et *epochTarget) tickPending() *Actions {
 		}
 	} else {
 		if pendingTicks == 0 {
-			suspect := &pb.Suspect{
-				Epoch: et.myNewEpoch.NewConfig.Config.Number,
-			}
-			return (&Actions{}).send(
-				et.networkConfig.Nodes,
-				&pb.Msg{
-					Type: &pb.Msg_Suspect{
-						Suspect: suspect,
-					},
-				},
-			).concat(et.persisted.addSuspect(suspect))
+			return et.suspect()
 		}
 		if pendingTicks%2 == 0 {
 			return et.repeatEpochChangeBroadcast()
@@ -434,6 +483,30 @@ func (et *epochTarget) tickPending() *Actions {
 	return &Actions{}
 }
 
+// suspect broadcasts our suspicion of this epoch, persisting it
+// if it has not already been persisted.
+func (et *epochTarget) suspect() *Actions {
+	suspect := &pb.Suspect{
+		Epoch: et.number,
+	}
+
+	actions := (&Actions{}).send(
+		et.networkConfig.Nodes,
+		&pb.Msg{
+			Type: &pb.Msg_Suspect{
+				Suspect: suspect,
+			},
+		},
+	)
+
+	if !et.suspected {
+		actions.concat(et.persisted.addSuspect(suspect))
+		et.suspected = true
+	}
+
+	return actions
+}
+
 func (et *epochTarget) applyEpochChangeMsg(source nodeID, msg *pb.EpochChange) *Actions {
 	actions := &Actions{}
 	if source != nodeID(et.myConfig.Id) {
@@ -457,7 +530,8 @@ func (et *epochTarget) applyEpochChangeMsg(source nodeID, msg *pb.EpochChange) *
 }
 
 func (et *epochTarget) applyEpochChangeAckMsg(source nodeID, origin nodeID, msg *pb.EpochChange) *Actions {
-	// TODO, prevent multiple different acks from the same source for the same target
+	// Acks of more than one epoch change per source and originator are
+	// discarded once hashed, see applyEpochChangeDigest.
 	hashRequest := &HashRequest{
 		Data: epochChangeHashData(msg),
 		Origin: &pb.HashResult{
@@ -488,19 +562,75 @@ func (et *epochTarget) applyEpochChangeDigest(processedChange *pb.HashResult_Epo
 		et.changes[originNode] = change
 	}
 
-	change.addMsg(sourceNode, processedChange.EpochChange, digest)
+	actions := &Actions{}
+
+	// Correct nodes ack the epoch changes they receive without inspecting
+	// them, so only the originator may be held responsible for their contents.
+	isNewOriginMsg := sourceNode == originNode && change.addOriginMsg(processedChange.EpochChange, digest)
+	if isNewOriginMsg && len(change.originChanges) > 1 {
+		// The conflicting epoch change is still applied, as other
+		// nodes may have acked either of the two.
+		actions.misbehave(
+			originNode,
+			ConflictingMsgs,
+			epochChangeMsg(change.originChanges[0]),
+			epochChangeMsg(processedChange.EpochChange),
+		)
+	}
+
+	if sourceNode != originNode {
+		if previous := change.addSourceAck(sourceNode, processedChange.EpochChange, digest); previous != nil {
+			// A correct node only acks a second epoch change if the originator
+			// sent it two, in which case the originator was already reported.
+			if len(change.originChanges) < 2 {
+				actions.misbehave(
+					sourceNode,
+					ConflictingMsgs,
+					epochChangeAckMsg(originNode, previous),
+					epochChangeAckMsg(originNode, processedChange.EpochChange),
+				)
+			}
+			return actions
+		}
+	}
+
+	if err := change.addMsg(sourceNode, processedChange.EpochChange, digest); err != nil {
+		if isNewOriginMsg {
+			actions.misbehave(originNode, MalformedMsg, epochChangeMsg(processedChange.EpochChange))
+		}
+		return actions
+	}
 
 	if change.strongCert == nil {
-		return &Actions{}
+		return actions
 	}
 
 	if _, alreadyInQuorum := et.strongChanges[originNode]; alreadyInQuorum {
-		return &Actions{}
+		return actions
 	}
 
 	et.strongChanges[originNode] = change.parsedByDigest[string(change.strongCert)]
 
-	return et.advanceState()
+	return actions.concat(et.advanceState())
+}
+
+func epochChangeMsg(epochChange *pb.EpochChange) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_EpochChange{
+			EpochChange: epochChange,
+		},
+	}
+}
+
+func epochChangeAckMsg(originator nodeID, epochChange *pb.EpochChange) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_EpochChangeAck{
+			EpochChangeAck: &pb.EpochChangeAck{
+				Originator:  uint64(originator),
+				EpochChange: epochChange,
+			},
+		},
+	}
 }
 
 func (et *epochTarget) checkEpochQuorum() *Actions {
@@ -750,6 +880,7 @@ func (et *epochTarget) moveLowWatermark(seqNo uint64) *Actions {
 	actions, done := et.activeEpoch.moveLowWatermark(seqNo)
 	if done {
 		et.logger.Log(LevelDebug, "epoch gracefully transitioning from in progress to done", "epoch_no", et.number)
+		et.gracefulEnd = true
 		et.state = etDone
 	}
 
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("epochTarget", func() {
+	var (
+		et            *epochTarget
+		networkConfig *pb.NetworkState_Config
+	)
+
+	epochChange := func(value string) *pb.EpochChange {
+		return &pb.EpochChange{
+			NewEpoch: 1,
+			Checkpoints: []*pb.Checkpoint{
+				{
+					SeqNo: 0,
+					Value: []byte(value),
+				},
+			},
+		}
+	}
+
+	ack := func(source, origin nodeID, msg *pb.EpochChange, digest string) *Actions {
+		return et.applyEpochChangeDigest(&pb.HashResult_EpochChange{
+			Source:      uint64(source),
+			Origin:      uint64(origin),
+			EpochChange: msg,
+		}, []byte(digest))
+	}
+
+	BeforeEach(func() {
+		networkConfig = StandardInitialNetworkState(4, 0).Config
+		myConfig := &pb.StateEvent_InitialParameters{
+			Id:         0,
+			BufferSize: 1024,
+		}
+		et = newEpochTarget(
+			1,
+			newPersisted(ConsoleWarnLogger),
+			newNodeBuffers(myConfig, ConsoleWarnLogger),
+			nil,
+			nil,
+			nil,
+			networkConfig,
+			myConfig,
+			ConsoleWarnLogger,
+		)
+	})
+
+	It("reports a node which acks two different epoch changes from the same originator", func() {
+		first, second := epochChange("value"), epochChange("other-value")
+		Expect(ack(2, 1, first, "first").Misbehaviors).To(BeEmpty())
+		Expect(ack(2, 1, first, "first").Misbehaviors).To(BeEmpty())
+
+		actions := ack(2, 1, second, "second")
+		Expect(actions.Misbehaviors).To(Equal([]*Misbehavior{
+			{
+				Node: 2,
+				Kind: ConflictingMsgs,
+				Msgs: []*pb.Msg{
+					epochChangeAckMsg(1, first),
+					epochChangeAckMsg(1, second),
+				},
+			},
+		}))
+		Expect(et.changes[1].parsedByDigest).NotTo(HaveKey("second"))
+	})
+
+	It("reports only the originator when it sent two different epoch changes", func() {
+		first, second := epochChange("value"), epochChange("other-value")
+		Expect(ack(1, 1, first, "first").Misbehaviors).To(BeEmpty())
+		Expect(ack(1, 1, second, "second").Misbehaviors).To(HaveLen(1))
+
+		Expect(ack(2, 1, first, "first").Misbehaviors).To(BeEmpty())
+		Expect(ack(2, 1, second, "second").Misbehaviors).To(BeEmpty())
+	})
+
+	When("the leader's new epoch message is malformed", func() {
+		var newEpoch *pb.NewEpoch
+
+		BeforeEach(func() {
+			for _, origin := range []nodeID{1, 2, 3} {
+				msg := epochChange("value")
+				ack(origin, origin, msg, "digest")
+				ack((origin+1)%4, origin, msg, "digest")
+			}
+
+			newEpoch = &pb.NewEpoch{
+				NewConfig: &pb.NewEpochConfig{
+					Config: &pb.EpochConfig{
+						Number:            1,
+						Leaders:           networkConfig.Nodes,
+						PlannedExpiration: networkConfig.MaxEpochLength,
+					},
+					StartingCheckpoint: &pb.Checkpoint{
+						SeqNo: 0,
+						Value: []byte("value"),
+					},
+					FinalPreprepares: make([][]byte, 2*networkConfig.CheckpointInterval),
+				},
+				EpochChanges: []*pb.NewEpoch_RemoteEpochChange{
+					{NodeId: 1, Digest: []byte("digest")},
+					{NodeId: 2, Digest: []byte("digest")},
+					{NodeId: 3, Digest: []byte("digest")},
+				},
+			}
+			et.state = etPending
+		})
+
+		expectDiscarded := func(actions *Actions) {
+			Expect(actions.Misbehaviors).To(Equal([]*Misbehavior{
+				{
+					Node: 1,
+					Kind: MalformedMsg,
+					Msgs: []*pb.Msg{
+						{
+							Type: &pb.Msg_NewEpoch{
+								NewEpoch: newEpoch,
+							},
+						},
+					},
+				},
+			}))
+			Expect(et.leaderNewEpoch).To(BeNil())
+			Expect(et.state).To(Equal(epochTargetState(etPending)))
+		}
+
+		It("reports a message which references the same node twice", func() {
+			newEpoch.EpochChanges[2].NodeId = 2
+			expectDiscarded(et.applyNewEpochMsg(newEpoch))
+		})
+
+		It("reports a message whose config does not follow from its epoch changes", func() {
+			newEpoch.NewConfig.StartingCheckpoint.Value = []byte("other-value")
+			expectDiscarded(et.applyNewEpochMsg(newEpoch))
+		})
+	})
+})
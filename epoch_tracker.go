@@ -27,10 +27,25 @@ type epochTracker struct {
 	futureMsgs         map[nodeID]*msgBuffer
 	targets            map[uint64]*epochTarget
 	needsStateTransfer bool
+	leaderPolicy       LeaderPolicy
+
+	// reconfiguring is set once the end of the network configuration has
+	// been persisted, the state machine must then reinitialize.
+	reconfiguring bool
+
+	// lastEpochConfig is the config of the most recent epoch to become
+	// active, and lastEpochGraceful whether that epoch ended gracefully.
+	// These are the inputs to the leader policy for the next epoch.
+	lastEpochConfig   *pb.EpochConfig
+	lastEpochGraceful bool
 
 	maxEpochs              map[nodeID]uint64
 	maxCorrectEpoch        uint64
 	ticksOutOfCorrectEpoch int
+
+	// epochChangesSent counts the epoch changes this node has initiated
+	// since it started, for status reporting only.
+	epochChangesSent uint64
 }
 
 func newEpochTracker(
@@ -42,6 +57,7 @@ func newEpochTracker(
 	myConfig *pb.StateEvent_InitialParameters,
 	batchTracker *batchTracker,
 	clientTracker *clientTracker,
+	leaderPolicy LeaderPolicy,
 ) *epochTracker {
 	return &epochTracker{
 		persisted:     persisted,
@@ -51,12 +67,21 @@ func newEpochTracker(
 		logger:        logger,
 		batchTracker:  batchTracker,
 		clientTracker: clientTracker,
+		leaderPolicy:  leaderPolicy,
 		targets:       map[uint64]*epochTarget{},
 		maxEpochs:     map[nodeID]uint64{},
 	}
 }
 
 func (et *epochTracker) reinitialize() *Actions {
+	reconfigured := et.reconfiguring
+	if reconfigured {
+		// Our epoch target belongs to the previous network configuration
+		et.releaseCurrentEpoch()
+		et.currentEpoch = nil
+		et.reconfiguring = false
+	}
+
 	et.networkConfig = et.commitState.activeState.Config
 
 	newFutureMsgs := map[nodeID]*msgBuffer{}
@@ -70,20 +95,30 @@ func (et *epochTracker) reinitialize() *Actions {
 		}
 		newFutureMsgs[nodeID(id)] = futureMsgs
 	}
+	for id, futureMsgs := range et.futureMsgs {
+		if _, ok := newFutureMsgs[id]; !ok {
+			futureMsgs.clear()
+		}
+	}
 	et.futureMsgs = newFutureMsgs
 
 	actions := &Actions{}
 	var lastNEntry *pb.NEntry
 	var lastECEntry *pb.ECEntry
 	var lastFEntry *pb.FEntry
-	var highestPreprepared uint64
+	var lastSuspect *pb.Suspect
+	var highestPreprepared, highestCheckpoint uint64
 
 	et.persisted.iterate(logIterator{
 		onNEntry: func(nEntry *pb.NEntry) {
 			lastNEntry = nEntry
 		},
 		onFEntry: func(fEntry *pb.FEntry) {
+			// Entries before the FEntry belong to the previous network configuration
 			lastFEntry = fEntry
+			lastNEntry = nil
+			lastECEntry = nil
+			lastSuspect = nil
 		},
 		onECEntry: func(ecEntry *pb.ECEntry) {
 			lastECEntry = ecEntry
@@ -99,10 +134,13 @@ func (et *epochTracker) reinitialize() *Actions {
 			if cEntry.SeqNo > highestPreprepared {
 				highestPreprepared = cEntry.SeqNo
 			}
+			if cEntry.SeqNo > highestCheckpoint {
+				highestCheckpoint = cEntry.SeqNo
+			}
+		},
+		onSuspect: func(suspect *pb.Suspect) {
+			lastSuspect = suspect
 		},
-
-		// TODO, implement
-		onSuspect: func(*pb.Suspect) {},
 	})
 
 	var lastEpochConfig *pb.EpochConfig
@@ -114,7 +152,8 @@ func (et *epochTracker) reinitialize() *Actions {
 		graceful = false
 	case lastNEntry != nil:
 		lastEpochConfig = lastNEntry.EpochConfig
-		graceful = false
+		// The epoch ended gracefully if it reached its planned expiration
+		graceful = highestCheckpoint >= lastEpochConfig.PlannedExpiration
 	case lastFEntry != nil:
 		lastEpochConfig = lastFEntry.EndsEpochConfig
 		graceful = true
@@ -122,10 +161,14 @@ func (et *epochTracker) reinitialize() *Actions {
 		panic("no active epoch and no last epoch in log")
 	}
 
+	et.lastEpochConfig = lastEpochConfig
+	et.lastEpochGraceful = graceful
+
 	switch {
 	case lastNEntry != nil && (lastECEntry == nil || lastECEntry.EpochNumber <= lastNEntry.EpochConfig.Number):
 		et.logger.Log(LevelDebug, "reinitializing during a currently active epoch")
 
+		et.releaseCurrentEpoch()
 		et.currentEpoch = newEpochTarget(
 			lastNEntry.EpochConfig.Number,
 			et.persisted,
@@ -151,15 +194,18 @@ func (et *epochTracker) reinitialize() *Actions {
 		}
 		et.currentEpoch.startingSeqNo = startingSeqNo
 		et.currentEpoch.state = etResuming
-		suspect := &pb.Suspect{
-			Epoch: lastNEntry.EpochConfig.Number,
+
+		// Should the epoch resume before it fails, it resumes
+		// under the configuration we persisted when it began.
+		et.currentEpoch.networkNewEpoch = &pb.NewEpochConfig{
+			Config: lastNEntry.EpochConfig,
 		}
-		actions.concat(et.persisted.addSuspect(suspect))
-		actions.send(et.networkConfig.Nodes, &pb.Msg{
-			Type: &pb.Msg_Suspect{
-				Suspect: suspect,
-			},
-		})
+
+		// We cannot resume participating in an epoch we crashed during,
+		// so we suspect it, unless we had already suspected it, in
+		// which case the suspicion is already persisted.
+		et.currentEpoch.suspected = lastSuspect != nil && lastSuspect.Epoch == lastNEntry.EpochConfig.Number
+		actions.concat(et.currentEpoch.suspect())
 	case lastFEntry != nil && (lastECEntry == nil || lastECEntry.EpochNumber <= lastFEntry.EndsEpochConfig.Number):
 		et.logger.Log(LevelDebug, "reinitializing immediately after graceful epoch end, but before epoch change sent, creating epoch change")
 		// An epoch has just gracefully ended, and we have not yet tried to move to the next
@@ -182,6 +228,7 @@ func (et *epochTracker) reinitialize() *Actions {
 		parsedEpochChange, err := newParsedEpochChange(epochChange)
 		assertEqualf(err, nil, "could not parse epoch change we generated: %s", err)
 
+		et.releaseCurrentEpoch()
 		et.currentEpoch = newEpochTarget(
 			epochChange.NewEpoch,
 			et.persisted,
@@ -196,10 +243,20 @@ func (et *epochTracker) reinitialize() *Actions {
 
 		et.currentEpoch.myEpochChange = parsedEpochChange
 
-		// XXX this leader selection is wrong, but using while we modify the startup.
-		// instead base it on the lastEpochConfig and whether that epoch ended gracefully.
-		_, _ = lastEpochConfig, graceful
-		et.currentEpoch.myLeaderChoice = et.networkConfig.Nodes
+		if reconfigured {
+			// Our epoch change was not sent under the new network configuration,
+			// so we broadcast it immediately rather than waiting to repeat it.
+			actions.concat(et.currentEpoch.repeatEpochChangeBroadcast())
+		}
+
+		if lastSuspect != nil && lastSuspect.Epoch == epochChange.NewEpoch {
+			// We suspected this epoch change would fail before crashing,
+			// so we resume suspecting it.
+			et.currentEpoch.suspected = true
+			actions.concat(et.currentEpoch.suspect())
+		}
+
+		et.currentEpoch.myLeaderChoice = et.leaderPolicy.Leaders(et.networkConfig, lastEpochConfig, graceful, epochChange.NewEpoch)
 	default:
 		// There's no active epoch, it did not end gracefully, or ungracefully
 		panic("no recorded active epoch, ended epoch, or epoch change in log")
@@ -214,9 +271,26 @@ func (et *epochTracker) reinitialize() *Actions {
 	return actions
 }
 
+// releaseCurrentEpoch discards the messages buffered by the current
+// epoch target, if any, before it is replaced.
+func (et *epochTracker) releaseCurrentEpoch() {
+	if et.currentEpoch != nil {
+		et.currentEpoch.releaseBuffers()
+	}
+}
+
 func (et *epochTracker) advanceState() *Actions {
+	if et.commitState.reconfigurationStable {
+		return et.reconfigure(et.commitState.stopAtSeqNo)
+	}
+
 	if et.currentEpoch.state < etDone {
-		return et.currentEpoch.advanceState()
+		actions := et.currentEpoch.advanceState()
+		if et.commitState.reconfigurationStable {
+			// The new epoch has shown us the reconfiguration is stable
+			actions.concat(et.reconfigure(et.commitState.stopAtSeqNo))
+		}
+		return actions
 	}
 
 	if et.commitState.checkpointPending {
@@ -225,6 +299,11 @@ func (et *epochTracker) advanceState() *Actions {
 		return &Actions{}
 	}
 
+	if et.currentEpoch.activeEpoch != nil {
+		et.lastEpochConfig = et.currentEpoch.activeEpoch.epochConfig
+		et.lastEpochGraceful = et.currentEpoch.gracefulEnd
+	}
+
 	newEpochNumber := et.currentEpoch.number + 1
 	if et.maxCorrectEpoch > newEpochNumber {
 		newEpochNumber = et.maxCorrectEpoch
@@ -234,6 +313,7 @@ func (et *epochTracker) advanceState() *Actions {
 	myEpochChange, err := newParsedEpochChange(epochChange)
 	assertEqualf(err, nil, "could not parse epoch change we generated: %s", err)
 
+	et.releaseCurrentEpoch()
 	et.currentEpoch = newEpochTarget(
 		newEpochNumber,
 		et.persisted,
@@ -246,7 +326,8 @@ func (et *epochTracker) advanceState() *Actions {
 		et.logger,
 	)
 	et.currentEpoch.myEpochChange = myEpochChange
-	et.currentEpoch.myLeaderChoice = []uint64{et.myConfig.Id} // XXX, wrong
+	et.currentEpoch.myLeaderChoice = et.leaderPolicy.Leaders(et.networkConfig, et.lastEpochConfig, et.lastEpochGraceful, newEpochNumber)
+	et.epochChangesSent++
 
 	actions := et.persisted.addECEntry(&pb.ECEntry{
 		EpochNumber: newEpochNumber,
@@ -345,7 +426,7 @@ func (et *epochTracker) applyMsg(source nodeID, msg *pb.Msg) *Actions {
 	case *pb.Msg_EpochChangeAck:
 		return target.applyEpochChangeAckMsg(source, nodeID(innerMsg.EpochChangeAck.Originator), innerMsg.EpochChangeAck.EpochChange)
 	case *pb.Msg_NewEpoch:
-		if innerMsg.NewEpoch.NewConfig.Config.Number%uint64(len(et.networkConfig.Nodes)) != uint64(source) {
+		if epochPrimary(et.networkConfig, innerMsg.NewEpoch.NewConfig.Config.Number) != uint64(source) {
 			// TODO, log oddity
 			return &Actions{}
 		}
@@ -401,9 +482,46 @@ func (et *epochTracker) tick() *Actions {
 }
 
 func (et *epochTracker) moveLowWatermark(seqNo uint64) *Actions {
+	if seqNo == et.commitState.stopAtSeqNo && et.commitState.reconfigured {
+		et.commitState.reconfigurationStable = true
+	}
+
 	return et.currentEpoch.moveLowWatermark(seqNo)
 }
 
+// reconfigure persists the end of the current network configuration, once the
+// checkpoint which applies the pending reconfigurations is stable, or once we
+// have transferred to a checkpoint under a new configuration.  If we were
+// changing to an epoch which never became active, we persist that epoch change
+// again, so that after reinitializing (or crashing) we resume the epoch change
+// under the new network configuration.  The state machine must reinitialize
+// before applying any further events.
+func (et *epochTracker) reconfigure(seqNo uint64) *Actions {
+	if et.reconfiguring {
+		return &Actions{}
+	}
+	et.reconfiguring = true
+
+	endsEpochConfig := et.lastEpochConfig
+	if et.currentEpoch.activeEpoch != nil {
+		endsEpochConfig = et.currentEpoch.activeEpoch.epochConfig
+	}
+
+	et.logger.Log(LevelInfo, "ending network configuration", "seq_no", seqNo, "epoch_no", endsEpochConfig.Number)
+
+	actions := et.persisted.addFEntry(&pb.FEntry{
+		EndsEpochConfig: endsEpochConfig,
+	})
+
+	if et.currentEpoch.number > endsEpochConfig.Number {
+		actions.concat(et.persisted.addECEntry(&pb.ECEntry{
+			EpochNumber: et.currentEpoch.number,
+		}))
+	}
+
+	return actions
+}
+
 func (et *epochTracker) applyEpochChangeDigest(hashResult *pb.HashResult_EpochChange, digest []byte) *Actions {
 	targetNumber := hashResult.EpochChange.NewEpoch
 	switch {
@@ -428,9 +546,16 @@ func (et *epochTracker) status() *status.EpochTracker {
 		return targets[i].Number < targets[j].Number
 	})
 
+	var leaders []uint64
+	if et.currentEpoch.activeEpoch != nil {
+		leaders = et.currentEpoch.activeEpoch.epochConfig.Leaders
+	}
+
 	return &status.EpochTracker{
-		LastActiveEpoch: et.currentEpoch.number,
-		State:           status.EpochTargetState(et.currentEpoch.state),
-		EpochTargets:    targets,
+		LastActiveEpoch:  et.currentEpoch.number,
+		State:            status.EpochTargetState(et.currentEpoch.state),
+		EpochTargets:     targets,
+		EpochChangesSent: et.epochChangesSent,
+		Leaders:          leaders,
 	}
 }
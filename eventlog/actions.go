@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog
+
+import (
+	"github.com/IBM/mirbft"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+// ActionsProto converts a set of actions into their serialized form,
+// as recorded into the log.
+func ActionsProto(actions *mirbft.Actions) *rpb.Actions {
+	result := &rpb.Actions{}
+
+	for _, send := range actions.Send {
+		result.Send = append(result.Send, &rpb.Actions_Send{
+			Targets: send.Targets,
+			Msg:     send.Msg,
+		})
+	}
+
+	for _, hash := range actions.Hash {
+		result.Hash = append(result.Hash, &rpb.Actions_Hash{
+			Data:   hash.Data,
+			Origin: hash.Origin,
+		})
+	}
+
+	for _, write := range actions.WriteAhead {
+		if write.Truncate != nil {
+			result.WriteAhead = append(result.WriteAhead, &rpb.Actions_Write{
+				Type: &rpb.Actions_Write_Truncate{
+					Truncate: *write.Truncate,
+				},
+			})
+			continue
+		}
+
+		result.WriteAhead = append(result.WriteAhead, &rpb.Actions_Write{
+			Type: &rpb.Actions_Write_Append{
+				Append: &pb.StateEvent_PersistedEntry{
+					Index: write.Append.Index,
+					Data:  write.Append.Data,
+				},
+			},
+		})
+	}
+
+	for _, commit := range actions.Commits {
+		if commit.Batch != nil {
+			result.Commits = append(result.Commits, &rpb.Actions_Commit{
+				Batch: commit.Batch,
+			})
+			continue
+		}
+
+		result.Commits = append(result.Commits, &rpb.Actions_Commit{
+			Checkpoint: &rpb.Actions_Checkpoint{
+				SeqNo:         commit.Checkpoint.SeqNo,
+				NetworkConfig: commit.Checkpoint.NetworkConfig,
+				ClientsState:  commit.Checkpoint.ClientsState,
+			},
+		})
+	}
+
+	result.StoreRequests = actions.StoreRequests
+
+	for _, forward := range actions.ForwardRequests {
+		result.ForwardRequests = append(result.ForwardRequests, &rpb.Actions_Forward{
+			Targets:    forward.Targets,
+			RequestAck: forward.RequestAck,
+		})
+	}
+
+	if actions.StateTransfer != nil {
+		result.StateTransfer = &rpb.Actions_StateTarget{
+			SeqNo: actions.StateTransfer.SeqNo,
+			Value: actions.StateTransfer.Value,
+		}
+	}
+
+	if actions.StableCheckpoint != nil {
+		result.StableCheckpoint = &rpb.Actions_StableCheckpoint{
+			SeqNo: *actions.StableCheckpoint,
+		}
+	}
+
+	for _, misbehavior := range actions.Misbehaviors {
+		result.Misbehaviors = append(result.Misbehaviors, &rpb.Actions_Misbehavior{
+			Node: misbehavior.Node,
+			Kind: int32(misbehavior.Kind),
+			Msgs: misbehavior.Msgs,
+		})
+	}
+
+	return result
+}
+
+// RedactActions returns a copy of the serialized actions without any
+// request data, as they are recorded unless RetainRequestDataOpt is set.
+// Because the request data is also omitted from the recorded state events,
+// actions regenerated by replaying a log should be redacted before they are
+// compared with the recorded ones.
+func RedactActions(actions *rpb.Actions) *rpb.Actions {
+	result := &rpb.Actions{
+		WriteAhead:       actions.WriteAhead,
+		Commits:          actions.Commits,
+		ForwardRequests:  actions.ForwardRequests,
+		StateTransfer:    actions.StateTransfer,
+		StableCheckpoint: actions.StableCheckpoint,
+		Misbehaviors:     actions.Misbehaviors,
+	}
+
+	for _, send := range actions.Send {
+		result.Send = append(result.Send, &rpb.Actions_Send{
+			Targets: send.Targets,
+			Msg:     redactMsg(send.Msg),
+		})
+	}
+
+	for _, hash := range actions.Hash {
+		origin := redactHashResult(hash.Origin)
+		if origin == hash.Origin {
+			result.Hash = append(result.Hash, hash)
+			continue
+		}
+
+		// The data of a request hash is the request data itself
+		result.Hash = append(result.Hash, &rpb.Actions_Hash{
+			Origin: origin,
+		})
+	}
+
+	for _, storeRequest := range actions.StoreRequests {
+		result.StoreRequests = append(result.StoreRequests, &pb.ForwardRequest{
+			RequestAck: storeRequest.RequestAck,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+// ResultFilter tracks the hashes, checkpoints, and state transfer which a
+// replayed state machine has requested.  Once replay diverges from the
+// recorded node (as it does after the synthetic restart which begins a
+// rotated segment), the recorded results no longer correspond to the
+// replayed requests, and must be filtered to those the replayed state
+// machine expects.
+type ResultFilter struct {
+	hashes      []*pb.HashResult
+	checkpoints map[uint64]struct{}
+	transfer    *mirbft.StateTarget
+}
+
+// NewResultFilter creates a filter which has observed no requests.
+func NewResultFilter() *ResultFilter {
+	return &ResultFilter{
+		checkpoints: map[uint64]struct{}{},
+	}
+}
+
+// ApplyActions records the requests made by the replayed state machine.
+func (rf *ResultFilter) ApplyActions(actions *mirbft.Actions) {
+	for _, hash := range actions.Hash {
+		rf.hashes = append(rf.hashes, redactHashResult(hash.Origin))
+	}
+
+	for _, commit := range actions.Commits {
+		if commit.Checkpoint != nil {
+			rf.checkpoints[commit.Checkpoint.SeqNo] = struct{}{}
+		}
+	}
+
+	if actions.StateTransfer != nil {
+		rf.transfer = actions.StateTransfer
+	}
+}
+
+// Filter returns the state event with any results which were not
+// requested removed, or nil if the event is a state transfer which was not
+// requested.  Other state events are returned unmodified.
+func (rf *ResultFilter) Filter(event *pb.StateEvent) *pb.StateEvent {
+	switch e := event.Type.(type) {
+	case *pb.StateEvent_Transfer:
+		if rf.transfer == nil || rf.transfer.SeqNo != e.Transfer.SeqNo || !bytes.Equal(rf.transfer.Value, e.Transfer.CheckpointValue) {
+			return nil
+		}
+		rf.transfer = nil
+		return event
+	case *pb.StateEvent_AddResults:
+		results := &pb.StateEvent_ActionResults{}
+
+		for _, checkpoint := range e.AddResults.Checkpoints {
+			if _, ok := rf.checkpoints[checkpoint.SeqNo]; !ok {
+				continue
+			}
+			delete(rf.checkpoints, checkpoint.SeqNo)
+			results.Checkpoints = append(results.Checkpoints, checkpoint)
+		}
+
+		for _, digest := range e.AddResults.Digests {
+			origin := redactHashResult(&pb.HashResult{Type: digest.Type})
+			for i, hash := range rf.hashes {
+				if !proto.Equal(origin, hash) {
+					continue
+				}
+				rf.hashes = append(rf.hashes[:i], rf.hashes[i+1:]...)
+				results.Digests = append(results.Digests, digest)
+				break
+			}
+		}
+
+		return &pb.StateEvent{
+			Type: &pb.StateEvent_AddResults{
+				AddResults: results,
+			},
+		}
+	default:
+		return event
+	}
+}
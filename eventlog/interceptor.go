@@ -14,11 +14,13 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/IBM/mirbft"
 	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
 	pb "github.com/IBM/mirbft/mirbftpb"
 )
@@ -51,6 +53,20 @@ func RetainRequestDataOpt() RecorderOpt {
 	return retainRequestDataOpt{}
 }
 
+type recordActionsOpt struct{}
+
+// RecordActionsOpt indicates that the actions handed to the consumer
+// should be recorded into the log, in addition to the state events.
+// The log then stands alone as an audit trail of the node's behavior,
+// and replaying it may verify that the state machine regenerates the
+// same actions.  The actions (like the state events) omit request
+// data unless RetainRequestDataOpt is also set.  Note that when batches
+// are bounded by MaxBatchBytes, replay depends on the request sizes, so
+// RetainRequestDataOpt is required for the regenerated actions to match.
+func RecordActionsOpt() RecorderOpt {
+	return recordActionsOpt{}
+}
+
 type compressionLevelOpt int
 
 // DefaultCompressionLevel is used for event capture when not overridden.
@@ -71,21 +87,58 @@ const DefaultBufferSize = 5000
 type bufferSizeOpt int
 
 // BufferSizeOpt overrides the default buffer size of the
-// interceptor buffer.  Once the buffer overflows, the state
+// interceptor buffer.  Once the buffer overflows, by default, the state
 // machine will be blocked from receiving new state events
-// until the buffer has room.
+// until the buffer has room.  See DropOnOverflowOpt and
+// SampleOnOverflowOpt for alternatives.
 func BufferSizeOpt(size int) RecorderOpt {
 	return bufferSizeOpt(size)
 }
 
+type dropOnOverflowOpt struct{}
+
+// DropOnOverflowOpt indicates that rather than block the state machine
+// when the buffer overflows, the recorder should drop events until the
+// buffer has room.  Dropped events are counted (see Dropped) and a gap
+// marker is written into the log before the next recorded event, so that
+// tools may tell the log is incomplete and refuse to replay it.
+func DropOnOverflowOpt() RecorderOpt {
+	return dropOnOverflowOpt{}
+}
+
+type sampleOnOverflowOpt uint64
+
+// SampleOnOverflowOpt behaves like DropOnOverflowOpt, except that
+// one of every rate consecutive overflowing events blocks until there
+// is room in the buffer, rather than being dropped.  This bounds how
+// badly the state machine is slowed by a slow output stream, while
+// still sampling the events which flow through it.  A rate of one is
+// equivalent to blocking on overflow, and a rate of zero to dropping.
+func SampleOnOverflowOpt(rate uint64) RecorderOpt {
+	return sampleOnOverflowOpt(rate)
+}
+
 // Recorder is intended to be used as an imlementation of the
 // mirbft.EventInterceptor interface.  It receives state events,
 // serializes them, compresses them, and writes them to a stream.
 type Recorder struct {
+	// dropped and pendingDrops are accessed atomically, and are
+	// first to ensure their alignment.
+	dropped      uint64
+	pendingDrops uint64
+
+	// pendingLoss indicates that actions which would not have been
+	// recorded, but only tracked for rotation, were dropped since the
+	// last event enqueued.  It is only accessed by the serializer.
+	pendingLoss bool
+
 	nodeID            uint64
 	timeSource        func() int64
 	compressionLevel  int
 	retainRequestData bool
+	recordActions     bool
+	sampleRate        uint64
+	rotation          *rotation
 	eventC            chan eventTime
 	doneC             chan struct{}
 	exitC             chan struct{}
@@ -95,6 +148,14 @@ type Recorder struct {
 }
 
 func NewRecorder(nodeID uint64, dest io.Writer, opts ...RecorderOpt) *Recorder {
+	i := newRecorder(nodeID, opts)
+
+	go i.run(dest)
+
+	return i
+}
+
+func newRecorder(nodeID uint64, opts []RecorderOpt) *Recorder {
 	startTime := time.Now()
 
 	i := &Recorder{
@@ -103,6 +164,7 @@ func NewRecorder(nodeID uint64, dest io.Writer, opts ...RecorderOpt) *Recorder {
 			return time.Since(startTime).Milliseconds()
 		},
 		compressionLevel: DefaultCompressionLevel,
+		sampleRate:       1,
 		eventC:           make(chan eventTime, DefaultBufferSize),
 		doneC:            make(chan struct{}),
 		exitC:            make(chan struct{}),
@@ -114,41 +176,118 @@ func NewRecorder(nodeID uint64, dest io.Writer, opts ...RecorderOpt) *Recorder {
 			i.timeSource = v
 		case retainRequestDataOpt:
 			i.retainRequestData = true
+		case recordActionsOpt:
+			i.recordActions = true
 		case compressionLevelOpt:
 			i.compressionLevel = int(v)
 		case bufferSizeOpt:
 			i.eventC = make(chan eventTime, v)
+		case dropOnOverflowOpt:
+			i.sampleRate = 0
+		case sampleOnOverflowOpt:
+			i.sampleRate = uint64(v)
 		}
 	}
 
-	go i.run(dest)
-
 	return i
 }
 
 type eventTime struct {
-	event *pb.StateEvent
-	time  int64
+	event   *pb.StateEvent
+	actions *rpb.Actions
+	time    int64
+
+	// dropped is the number of events dropped immediately
+	// prior to this one.
+	dropped uint64
+
+	// lost indicates that actions which would not have been recorded
+	// were dropped prior to this event, so the WAL is unknown.
+	lost bool
 }
 
 // Intercept takes an event and enqueues it into the event buffer.
-// If there is no room in the buffer, it blocks.  If draining the buffer
+// If there is no room in the buffer, it blocks, or drops the event,
+// according to the overflow policy.  If draining the buffer
 // to the output stream has completed (successfully or otherwise), Intercept
 // returns an error.
 func (i *Recorder) Intercept(event *pb.StateEvent) error {
-	select {
-	case i.eventC <- eventTime{
+	return i.enqueue(eventTime{
 		event: event,
 		time:  i.timeSource(),
-	}:
+	})
+}
+
+// InterceptActions takes a set of actions and, if the recorder was created
+// with RecordActionsOpt, enqueues them into the event buffer just as Intercept
+// does for state events.  Otherwise, the actions are ignored (unless the
+// recorder is rotating, which requires the WAL writes they contain).
+func (i *Recorder) InterceptActions(actions *mirbft.Actions) error {
+	if !i.recordActions && i.rotation == nil {
+		return nil
+	}
+
+	return i.enqueue(eventTime{
+		actions: ActionsProto(actions),
+		time:    i.timeSource(),
+	})
+}
+
+// Dropped returns the number of events which have been dropped because
+// the buffer overflowed.  It is always zero unless the recorder was
+// created with DropOnOverflowOpt or SampleOnOverflowOpt.  Actions which
+// a rotating recorder tracks, but does not record, are not counted.
+func (i *Recorder) Dropped() uint64 {
+	return atomic.LoadUint64(&i.dropped)
+}
+
+// enqueue is only invoked by the serializer, so pendingDrops is
+// only modified concurrently by the recorder's own stopping.
+func (i *Recorder) enqueue(event eventTime) error {
+	event.dropped = atomic.LoadUint64(&i.pendingDrops)
+	event.lost = i.pendingLoss
+
+	if i.sampleRate != 1 {
+		select {
+		case i.eventC <- event:
+			atomic.StoreUint64(&i.pendingDrops, 0)
+			i.pendingLoss = false
+			return nil
+		case <-i.exitC:
+			return i.exitError()
+		default:
+		}
+
+		if i.sampleRate == 0 || (event.dropped+1)%i.sampleRate != 0 {
+			if event.actions != nil && !i.recordActions {
+				// The actions would not have been recorded, so
+				// only the WAL snapshot is lost.
+				i.pendingLoss = true
+				return nil
+			}
+
+			atomic.AddUint64(&i.pendingDrops, 1)
+			atomic.AddUint64(&i.dropped, 1)
+			return nil
+		}
+	}
+
+	select {
+	case i.eventC <- event:
+		atomic.StoreUint64(&i.pendingDrops, 0)
+		i.pendingLoss = false
 		return nil
 	case <-i.exitC:
-		i.exitErrMutex.Lock()
-		defer i.exitErrMutex.Unlock()
-		return i.exitErr
+		return i.exitError()
 	}
 }
 
+func (i *Recorder) exitError() error {
+	i.exitErrMutex.Lock()
+	defer i.exitErrMutex.Unlock()
+	return i.exitErr
+}
+
 // Stop must be invoked to release the resources associated with this
 // Interceptor, and should only be invoked after the mir node has completely
 // exited.  The returned error
@@ -177,21 +316,128 @@ func (i *Recorder) run(dest io.Writer) (exitErr error) {
 	if err != nil {
 		return err
 	}
-	defer gzWriter.Close()
+	defer func() {
+		// The gzip writer is replaced as segments are rolled
+		if err := gzWriter.Close(); err != nil && exitErr == errStopped {
+			exitErr = errors.WithMessage(err, "could not flush stream")
+		}
+
+		if i.rotation == nil {
+			return
+		}
+
+		if err := i.rotation.close(); err != nil && exitErr == errStopped {
+			exitErr = errors.WithMessage(err, "could not close segment")
+		}
+	}()
+
+	roll := func(time int64) error {
+		if err := gzWriter.Close(); err != nil {
+			return errors.WithMessage(err, "could not flush segment")
+		}
+
+		if err := i.rotation.close(); err != nil {
+			return errors.WithMessage(err, "could not close segment")
+		}
+
+		if err := i.rotation.open(); err != nil {
+			return err
+		}
+
+		gzWriter, err = gzip.NewWriterLevel(i.rotation.writer, i.compressionLevel)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range i.rotation.prefix(i.nodeID, time) {
+			if err := WriteRecordedEvent(gzWriter, event); err != nil {
+				return err
+			}
+			i.rotation.apply(event)
+		}
+
+		return nil
+	}
+
+	gap := func(time int64, dropped uint64) error {
+		recordedEvent := &rpb.RecordedEvent{
+			NodeId: i.nodeID,
+			Time:   time,
+			Gap: &rpb.Gap{
+				Dropped: dropped,
+			},
+		}
+
+		if err := WriteRecordedEvent(gzWriter, recordedEvent); err != nil {
+			return err
+		}
+
+		if i.rotation != nil {
+			i.rotation.apply(recordedEvent)
+		}
+
+		return nil
+	}
 
 	write := func(eventTime eventTime) error {
-		var stateEvent *pb.StateEvent
-		if i.retainRequestData {
-			stateEvent = eventTime.event
+		if eventTime.dropped > 0 {
+			if err := gap(eventTime.time, eventTime.dropped); err != nil {
+				return err
+			}
+		}
+
+		if eventTime.lost && i.rotation != nil {
+			i.rotation.lose()
+		}
+
+		var recordedEvent *rpb.RecordedEvent
+		switch {
+		case eventTime.actions != nil:
+			actions := eventTime.actions
+			if !i.retainRequestData {
+				actions = RedactActions(actions)
+			}
+
+			recordedEvent = &rpb.RecordedEvent{
+				NodeId:  i.nodeID,
+				Time:    eventTime.time,
+				Actions: actions,
+			}
+		case i.retainRequestData:
+			recordedEvent = &rpb.RecordedEvent{
+				NodeId:     i.nodeID,
+				Time:       eventTime.time,
+				StateEvent: eventTime.event,
+			}
+		default:
+			recordedEvent = &rpb.RecordedEvent{
+				NodeId:     i.nodeID,
+				Time:       eventTime.time,
+				StateEvent: redactEvent(eventTime.event),
+			}
+		}
+
+		recorded := recordedEvent.Actions == nil || i.recordActions
+		if recorded {
+			if err := WriteRecordedEvent(gzWriter, recordedEvent); err != nil {
+				return err
+			}
+		}
+
+		if i.rotation == nil {
+			return nil
+		}
+
+		if recorded {
+			i.rotation.apply(recordedEvent)
 		} else {
-			stateEvent = redactEvent(eventTime.event)
+			i.rotation.track(recordedEvent)
+		}
+		if !i.rotation.due(recordedEvent) {
+			return nil
 		}
 
-		return WriteRecordedEvent(gzWriter, &rpb.RecordedEvent{
-			NodeId:     i.nodeID,
-			Time:       eventTime.time,
-			StateEvent: stateEvent,
-		})
+		return errors.WithMessage(roll(eventTime.time), "could not roll segment")
 	}
 
 	for {
@@ -205,6 +451,15 @@ func (i *Recorder) run(dest io.Writer) (exitErr error) {
 						return errors.WithMessage(err, "error serializing to stream")
 					}
 				default:
+					// Record any events dropped after the last one written
+					dropped := atomic.SwapUint64(&i.pendingDrops, 0)
+					if dropped == 0 {
+						return errStopped
+					}
+
+					if err := gap(i.timeSource(), dropped); err != nil {
+						return errors.WithMessage(err, "error serializing to stream")
+					}
 					return errStopped
 				}
 			}
@@ -230,23 +485,18 @@ func redactEvent(event *pb.StateEvent) *pb.StateEvent {
 			},
 		}
 	case *pb.StateEvent_Step:
-		switch e := d.Step.Msg.Type.(type) {
-		case *pb.Msg_ForwardRequest:
-			return &pb.StateEvent{
-				Type: &pb.StateEvent_Step{
-					Step: &pb.StateEvent_InboundMsg{
-						Source: d.Step.Source,
-						Msg: &pb.Msg{
-							Type: &pb.Msg_ForwardRequest{
-								ForwardRequest: &pb.ForwardRequest{
-									RequestAck: e.ForwardRequest.RequestAck,
-								},
-							},
-						},
-					},
+		msg := redactMsg(d.Step.Msg)
+		if msg == d.Step.Msg {
+			break
+		}
+
+		return &pb.StateEvent{
+			Type: &pb.StateEvent_Step{
+				Step: &pb.StateEvent_InboundMsg{
+					Source: d.Step.Source,
+					Msg:    msg,
 				},
-			}
-		default:
+			},
 		}
 	case *pb.StateEvent_AddResults:
 		if len(d.AddResults.Digests) == 0 {
@@ -259,33 +509,7 @@ func redactEvent(event *pb.StateEvent) *pb.StateEvent {
 		}
 
 		for i, hashResult := range d.AddResults.Digests {
-			switch e := hashResult.Type.(type) {
-			case *pb.HashResult_Request_:
-				results.Digests[i] = &pb.HashResult{
-					Digest: hashResult.Digest,
-					Type: &pb.HashResult_Request_{
-						Request: &pb.HashResult_Request{
-							Source: e.Request.Source,
-							Request: &pb.Request{
-								ClientId: e.Request.Request.ClientId,
-								ReqNo:    e.Request.Request.ReqNo,
-							},
-						},
-					},
-				}
-			case *pb.HashResult_VerifyRequest_:
-				results.Digests[i] = &pb.HashResult{
-					Digest: hashResult.Digest,
-					Type: &pb.HashResult_VerifyRequest_{
-						VerifyRequest: &pb.HashResult_VerifyRequest{
-							Source:     e.VerifyRequest.Source,
-							RequestAck: e.VerifyRequest.RequestAck,
-						},
-					},
-				}
-			default:
-				results.Digests[i] = hashResult
-			}
+			results.Digests[i] = redactHashResult(hashResult)
 		}
 
 		return &pb.StateEvent{
@@ -298,6 +522,55 @@ func redactEvent(event *pb.StateEvent) *pb.StateEvent {
 	return event
 }
 
+// redactMsg returns the message without any request data it contains, or
+// the message itself if it contains none.
+func redactMsg(msg *pb.Msg) *pb.Msg {
+	switch e := msg.Type.(type) {
+	case *pb.Msg_ForwardRequest:
+		return &pb.Msg{
+			Type: &pb.Msg_ForwardRequest{
+				ForwardRequest: &pb.ForwardRequest{
+					RequestAck: e.ForwardRequest.RequestAck,
+				},
+			},
+		}
+	default:
+		return msg
+	}
+}
+
+// redactHashResult returns the hash result without any request data it
+// references, or the hash result itself if it references none.
+func redactHashResult(hashResult *pb.HashResult) *pb.HashResult {
+	switch e := hashResult.Type.(type) {
+	case *pb.HashResult_Request_:
+		return &pb.HashResult{
+			Digest: hashResult.Digest,
+			Type: &pb.HashResult_Request_{
+				Request: &pb.HashResult_Request{
+					Source: e.Request.Source,
+					Request: &pb.Request{
+						ClientId: e.Request.Request.ClientId,
+						ReqNo:    e.Request.Request.ReqNo,
+					},
+				},
+			},
+		}
+	case *pb.HashResult_VerifyRequest_:
+		return &pb.HashResult{
+			Digest: hashResult.Digest,
+			Type: &pb.HashResult_VerifyRequest_{
+				VerifyRequest: &pb.HashResult_VerifyRequest{
+					Source:     e.VerifyRequest.Source,
+					RequestAck: e.VerifyRequest.RequestAck,
+				},
+			},
+		}
+	default:
+		return hashResult
+	}
+}
+
 func WriteRecordedEvent(writer io.Writer, event *rpb.RecordedEvent) error {
 	return writeSizePrefixedProto(writer, event)
 }
@@ -15,6 +15,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/IBM/mirbft"
 	"github.com/IBM/mirbft/eventlog"
 	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
 	pb "github.com/IBM/mirbft/mirbftpb"
@@ -49,9 +50,258 @@ var _ = Describe("Recorder", func() {
 		Expect(output.Len()).To(Equal(46))
 	})
 
+	When("recording actions", func() {
+		var actions *mirbft.Actions
+
+		BeforeEach(func() {
+			actions = &mirbft.Actions{
+				Hash: []*mirbft.HashRequest{
+					{
+						Data: [][]byte{[]byte("request-data")},
+						Origin: &pb.HashResult{
+							Type: &pb.HashResult_Request_{
+								Request: &pb.HashResult_Request{
+									Source: 2,
+									Request: &pb.Request{
+										ClientId: 3,
+										ReqNo:    4,
+										Data:     []byte("request-data"),
+									},
+								},
+							},
+						},
+					},
+				},
+				StoreRequests: []*pb.ForwardRequest{
+					{
+						RequestAck:  &pb.RequestAck{ClientId: 3, ReqNo: 4},
+						RequestData: []byte("request-data"),
+					},
+				},
+			}
+		})
+
+		readAll := func() []*rpb.RecordedEvent {
+			reader, err := eventlog.NewReader(output)
+			Expect(err).NotTo(HaveOccurred())
+
+			var result []*rpb.RecordedEvent
+			for {
+				event, err := reader.ReadEvent()
+				if err == io.EOF {
+					return result
+				}
+				Expect(err).NotTo(HaveOccurred())
+				result = append(result, event)
+			}
+		}
+
+		It("ignores the actions unless configured to record them", func() {
+			interceptor := eventlog.NewRecorder(1, output)
+			interceptor.Intercept(tickEvent)
+			err := interceptor.InterceptActions(actions)
+			Expect(err).NotTo(HaveOccurred())
+			err = interceptor.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			events := readAll()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Actions).To(BeNil())
+		})
+
+		It("writes the actions without request data", func() {
+			interceptor := eventlog.NewRecorder(
+				1,
+				output,
+				eventlog.TimeSourceOpt(func() int64 { return 2 }),
+				eventlog.RecordActionsOpt(),
+			)
+			interceptor.Intercept(tickEvent)
+			err := interceptor.InterceptActions(actions)
+			Expect(err).NotTo(HaveOccurred())
+			err = interceptor.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			events := readAll()
+			Expect(events).To(HaveLen(2))
+			Expect(events[1].NodeId).To(Equal(uint64(1)))
+			Expect(events[1].Time).To(Equal(int64(2)))
+			Expect(events[1].StateEvent).To(BeNil())
+
+			recorded := events[1].Actions
+			Expect(recorded.Hash).To(HaveLen(1))
+			Expect(recorded.Hash[0].Data).To(BeEmpty())
+			Expect(recorded.Hash[0].Origin.GetRequest().Request.Data).To(BeEmpty())
+			Expect(recorded.Hash[0].Origin.GetRequest().Request.ReqNo).To(Equal(uint64(4)))
+			Expect(recorded.StoreRequests).To(HaveLen(1))
+			Expect(recorded.StoreRequests[0].RequestData).To(BeEmpty())
+			Expect(proto.Equal(recorded, eventlog.RedactActions(eventlog.ActionsProto(actions)))).To(BeTrue())
+		})
+
+		It("retains the request data when configured to", func() {
+			interceptor := eventlog.NewRecorder(
+				1,
+				output,
+				eventlog.RecordActionsOpt(),
+				eventlog.RetainRequestDataOpt(),
+			)
+			err := interceptor.InterceptActions(actions)
+			Expect(err).NotTo(HaveOccurred())
+			err = interceptor.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			events := readAll()
+			Expect(events).To(HaveLen(1))
+			Expect(proto.Equal(events[0].Actions, eventlog.ActionsProto(actions))).To(BeTrue())
+			Expect(events[0].Actions.StoreRequests[0].RequestData).To(Equal([]byte("request-data")))
+		})
+	})
+
+	When("the output blocks and the buffer overflows", func() {
+		var (
+			blocked *blockingWriter
+			now     int64
+		)
+
+		BeforeEach(func() {
+			blocked = &blockingWriter{
+				output:   output,
+				enteredC: make(chan struct{}),
+				releaseC: make(chan struct{}),
+			}
+			now = 0
+		})
+
+		newRecorder := func(opt eventlog.RecorderOpt) *eventlog.Recorder {
+			return eventlog.NewRecorder(
+				1,
+				blocked,
+				eventlog.TimeSourceOpt(func() int64 {
+					now++
+					return now
+				}),
+				eventlog.BufferSizeOpt(1),
+				opt,
+			)
+		}
+
+		// fill intercepts one event which blocks the output, and
+		// another which fills the buffer.
+		fill := func(interceptor *eventlog.Recorder) {
+			Expect(interceptor.Intercept(tickEvent)).To(Succeed())
+			<-blocked.enteredC
+			Expect(interceptor.Intercept(tickEvent)).To(Succeed())
+		}
+
+		readAll := func() []*rpb.RecordedEvent {
+			reader, err := eventlog.NewReader(output)
+			Expect(err).NotTo(HaveOccurred())
+
+			var result []*rpb.RecordedEvent
+			for {
+				event, err := reader.ReadEvent()
+				if err == io.EOF {
+					return result
+				}
+				Expect(err).NotTo(HaveOccurred())
+				result = append(result, event)
+			}
+		}
+
+		It("drops events and records the gap", func() {
+			interceptor := newRecorder(eventlog.DropOnOverflowOpt())
+			fill(interceptor)
+			for i := 0; i < 3; i++ {
+				Expect(interceptor.Intercept(tickEvent)).To(Succeed())
+			}
+			Expect(interceptor.Dropped()).To(Equal(uint64(3)))
+
+			close(blocked.releaseC)
+			Expect(interceptor.Stop()).To(Succeed())
+
+			events := readAll()
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Time).To(Equal(int64(1)))
+			Expect(events[1].Time).To(Equal(int64(2)))
+			Expect(proto.Equal(events[2], &rpb.RecordedEvent{
+				NodeId: 1,
+				Time:   6,
+				Gap: &rpb.Gap{
+					Dropped: 3,
+				},
+			})).To(BeTrue())
+		})
+
+		It("blocks for a sample of the overflowing events", func() {
+			interceptor := newRecorder(eventlog.SampleOnOverflowOpt(2))
+			fill(interceptor)
+			Expect(interceptor.Intercept(tickEvent)).To(Succeed())
+			Expect(interceptor.Dropped()).To(Equal(uint64(1)))
+
+			sampledC := make(chan error)
+			go func() {
+				sampledC <- interceptor.Intercept(tickEvent)
+			}()
+			Consistently(sampledC).ShouldNot(Receive())
+
+			close(blocked.releaseC)
+			Eventually(sampledC).Should(Receive(BeNil()))
+			Expect(interceptor.Dropped()).To(Equal(uint64(1)))
+			Expect(interceptor.Stop()).To(Succeed())
+
+			events := readAll()
+			Expect(events).To(HaveLen(4))
+			Expect(events[1].Time).To(Equal(int64(2)))
+			Expect(proto.Equal(events[2], &rpb.RecordedEvent{
+				NodeId: 1,
+				Time:   4,
+				Gap: &rpb.Gap{
+					Dropped: 1,
+				},
+			})).To(BeTrue())
+			Expect(events[3].Time).To(Equal(int64(4)))
+			Expect(events[3].StateEvent.GetTick()).NotTo(BeNil())
+		})
+
+		It("blocks by default", func() {
+			interceptor := newRecorder(eventlog.BufferSizeOpt(1))
+			fill(interceptor)
+
+			blockedC := make(chan error)
+			go func() {
+				blockedC <- interceptor.Intercept(tickEvent)
+			}()
+			Consistently(blockedC).ShouldNot(Receive())
+
+			close(blocked.releaseC)
+			Eventually(blockedC).Should(Receive(BeNil()))
+			Expect(interceptor.Stop()).To(Succeed())
+			Expect(interceptor.Dropped()).To(BeZero())
+			Expect(readAll()).To(HaveLen(3))
+		})
+	})
+
 	// TODO, add tests with write failures, write blocking, etc. generate mock
 })
 
+// blockingWriter signals its first write, and blocks it until released.
+type blockingWriter struct {
+	output   io.Writer
+	entered  bool
+	enteredC chan struct{}
+	releaseC chan struct{}
+}
+
+func (bw *blockingWriter) Write(p []byte) (int, error) {
+	if !bw.entered {
+		bw.entered = true
+		close(bw.enteredC)
+		<-bw.releaseC
+	}
+
+	return bw.output.Write(p)
+}
+
 var _ = Describe("Reader", func() {
 
 	var (
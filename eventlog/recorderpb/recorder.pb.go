@@ -39,6 +39,21 @@ type RecordedEvent struct {
 	NodeId     uint64               `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	Time       int64                `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
 	StateEvent *mirbftpb.StateEvent `protobuf:"bytes,3,opt,name=state_event,json=stateEvent,proto3" json:"state_event,omitempty"`
+	// actions, if set instead of state_event, are the actions handed to
+	// the consumer via Ready(), recorded just prior to the ActionsReceived
+	// state event which acknowledges them.
+	Actions *Actions `protobuf:"bytes,4,opt,name=actions,proto3" json:"actions,omitempty"`
+	// segment, if set instead of state_event, begins each segment of a
+	// rotated log after the first.  The state events which follow, through
+	// complete_initialization, are synthetic.  They restart the node from a
+	// snapshot of its WAL, so replay diverges from the recorded node.
+	// If the snapshot is unknown, the segment says so, or is followed by a
+	// gap, and no synthetic state events follow.
+	Segment *Segment `protobuf:"bytes,5,opt,name=segment,proto3" json:"segment,omitempty"`
+	// gap, if set instead of state_event, indicates that the recorder
+	// dropped events rather than block the node, so the log is incomplete
+	// from this point and may not be replayed.
+	Gap *Gap `protobuf:"bytes,6,opt,name=gap,proto3" json:"gap,omitempty"`
 }
 
 func (x *RecordedEvent) Reset() {
@@ -94,6 +109,777 @@ func (x *RecordedEvent) GetStateEvent() *mirbftpb.StateEvent {
 	return nil
 }
 
+func (x *RecordedEvent) GetActions() *Actions {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *RecordedEvent) GetSegment() *Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return nil
+}
+
+func (x *RecordedEvent) GetGap() *Gap {
+	if x != nil {
+		return x.Gap
+	}
+	return nil
+}
+
+type Segment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// wal_unknown indicates that the recorder did not know the WAL of the
+	// node when the segment began, though no events were dropped, as when
+	// the recording began after the node initialized.  The node may not be
+	// replayed from this segment until it is next initialized.
+	WalUnknown bool `protobuf:"varint,2,opt,name=wal_unknown,json=walUnknown,proto3" json:"wal_unknown,omitempty"`
+}
+
+func (x *Segment) Reset() {
+	*x = Segment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segment) ProtoMessage() {}
+
+func (x *Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segment.ProtoReflect.Descriptor instead.
+func (*Segment) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Segment) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Segment) GetWalUnknown() bool {
+	if x != nil {
+		return x.WalUnknown
+	}
+	return false
+}
+
+// Gap records events dropped by the recorder.  The dropped count is zero
+// when unknown, as at the start of a segment whose WAL snapshot was lost
+// to an earlier gap.  Events the recorder only tracks, such as actions
+// which are not recorded, are never counted.
+type Gap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dropped uint64 `protobuf:"varint,1,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *Gap) Reset() {
+	*x = Gap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Gap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gap) ProtoMessage() {}
+
+func (x *Gap) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
+func (*Gap) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Gap) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Actions is the serialized form of mirbft.Actions.
+type Actions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Send             []*Actions_Send            `protobuf:"bytes,1,rep,name=send,proto3" json:"send,omitempty"`
+	Hash             []*Actions_Hash            `protobuf:"bytes,2,rep,name=hash,proto3" json:"hash,omitempty"`
+	WriteAhead       []*Actions_Write           `protobuf:"bytes,3,rep,name=write_ahead,json=writeAhead,proto3" json:"write_ahead,omitempty"`
+	Commits          []*Actions_Commit          `protobuf:"bytes,4,rep,name=commits,proto3" json:"commits,omitempty"`
+	StoreRequests    []*mirbftpb.ForwardRequest `protobuf:"bytes,5,rep,name=store_requests,json=storeRequests,proto3" json:"store_requests,omitempty"`
+	ForwardRequests  []*Actions_Forward         `protobuf:"bytes,6,rep,name=forward_requests,json=forwardRequests,proto3" json:"forward_requests,omitempty"`
+	StateTransfer    *Actions_StateTarget       `protobuf:"bytes,7,opt,name=state_transfer,json=stateTransfer,proto3" json:"state_transfer,omitempty"`
+	StableCheckpoint *Actions_StableCheckpoint  `protobuf:"bytes,8,opt,name=stable_checkpoint,json=stableCheckpoint,proto3" json:"stable_checkpoint,omitempty"`
+	Misbehaviors     []*Actions_Misbehavior     `protobuf:"bytes,9,rep,name=misbehaviors,proto3" json:"misbehaviors,omitempty"`
+}
+
+func (x *Actions) Reset() {
+	*x = Actions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions) ProtoMessage() {}
+
+func (x *Actions) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions.ProtoReflect.Descriptor instead.
+func (*Actions) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Actions) GetSend() []*Actions_Send {
+	if x != nil {
+		return x.Send
+	}
+	return nil
+}
+
+func (x *Actions) GetHash() []*Actions_Hash {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Actions) GetWriteAhead() []*Actions_Write {
+	if x != nil {
+		return x.WriteAhead
+	}
+	return nil
+}
+
+func (x *Actions) GetCommits() []*Actions_Commit {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+func (x *Actions) GetStoreRequests() []*mirbftpb.ForwardRequest {
+	if x != nil {
+		return x.StoreRequests
+	}
+	return nil
+}
+
+func (x *Actions) GetForwardRequests() []*Actions_Forward {
+	if x != nil {
+		return x.ForwardRequests
+	}
+	return nil
+}
+
+func (x *Actions) GetStateTransfer() *Actions_StateTarget {
+	if x != nil {
+		return x.StateTransfer
+	}
+	return nil
+}
+
+func (x *Actions) GetStableCheckpoint() *Actions_StableCheckpoint {
+	if x != nil {
+		return x.StableCheckpoint
+	}
+	return nil
+}
+
+func (x *Actions) GetMisbehaviors() []*Actions_Misbehavior {
+	if x != nil {
+		return x.Misbehaviors
+	}
+	return nil
+}
+
+type Actions_Send struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Targets []uint64      `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
+	Msg     *mirbftpb.Msg `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *Actions_Send) Reset() {
+	*x = Actions_Send{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Send) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Send) ProtoMessage() {}
+
+func (x *Actions_Send) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Send.ProtoReflect.Descriptor instead.
+func (*Actions_Send) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Actions_Send) GetTargets() []uint64 {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Actions_Send) GetMsg() *mirbftpb.Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type Actions_Hash struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data   [][]byte             `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Origin *mirbftpb.HashResult `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+}
+
+func (x *Actions_Hash) Reset() {
+	*x = Actions_Hash{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Hash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Hash) ProtoMessage() {}
+
+func (x *Actions_Hash) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Hash.ProtoReflect.Descriptor instead.
+func (*Actions_Hash) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Actions_Hash) GetData() [][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Actions_Hash) GetOrigin() *mirbftpb.HashResult {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+type Actions_Write struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*Actions_Write_Truncate
+	//	*Actions_Write_Append
+	Type isActions_Write_Type `protobuf_oneof:"type"`
+}
+
+func (x *Actions_Write) Reset() {
+	*x = Actions_Write{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Write) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Write) ProtoMessage() {}
+
+func (x *Actions_Write) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Write.ProtoReflect.Descriptor instead.
+func (*Actions_Write) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (m *Actions_Write) GetType() isActions_Write_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *Actions_Write) GetTruncate() uint64 {
+	if x, ok := x.GetType().(*Actions_Write_Truncate); ok {
+		return x.Truncate
+	}
+	return 0
+}
+
+func (x *Actions_Write) GetAppend() *mirbftpb.StateEvent_PersistedEntry {
+	if x, ok := x.GetType().(*Actions_Write_Append); ok {
+		return x.Append
+	}
+	return nil
+}
+
+type isActions_Write_Type interface {
+	isActions_Write_Type()
+}
+
+type Actions_Write_Truncate struct {
+	Truncate uint64 `protobuf:"varint,1,opt,name=truncate,proto3,oneof"`
+}
+
+type Actions_Write_Append struct {
+	Append *mirbftpb.StateEvent_PersistedEntry `protobuf:"bytes,2,opt,name=append,proto3,oneof"`
+}
+
+func (*Actions_Write_Truncate) isActions_Write_Type() {}
+
+func (*Actions_Write_Append) isActions_Write_Type() {}
+
+type Actions_Checkpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo         uint64                          `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	NetworkConfig *mirbftpb.NetworkState_Config   `protobuf:"bytes,2,opt,name=network_config,json=networkConfig,proto3" json:"network_config,omitempty"`
+	ClientsState  []*mirbftpb.NetworkState_Client `protobuf:"bytes,3,rep,name=clients_state,json=clientsState,proto3" json:"clients_state,omitempty"`
+}
+
+func (x *Actions_Checkpoint) Reset() {
+	*x = Actions_Checkpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Checkpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Checkpoint) ProtoMessage() {}
+
+func (x *Actions_Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Checkpoint.ProtoReflect.Descriptor instead.
+func (*Actions_Checkpoint) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 3}
+}
+
+func (x *Actions_Checkpoint) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *Actions_Checkpoint) GetNetworkConfig() *mirbftpb.NetworkState_Config {
+	if x != nil {
+		return x.NetworkConfig
+	}
+	return nil
+}
+
+func (x *Actions_Checkpoint) GetClientsState() []*mirbftpb.NetworkState_Client {
+	if x != nil {
+		return x.ClientsState
+	}
+	return nil
+}
+
+type Actions_Commit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Batch      *mirbftpb.QEntry    `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Checkpoint *Actions_Checkpoint `protobuf:"bytes,2,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+}
+
+func (x *Actions_Commit) Reset() {
+	*x = Actions_Commit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Commit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Commit) ProtoMessage() {}
+
+func (x *Actions_Commit) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Commit.ProtoReflect.Descriptor instead.
+func (*Actions_Commit) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 4}
+}
+
+func (x *Actions_Commit) GetBatch() *mirbftpb.QEntry {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *Actions_Commit) GetCheckpoint() *Actions_Checkpoint {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return nil
+}
+
+type Actions_Forward struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Targets    []uint64             `protobuf:"varint,1,rep,packed,name=targets,proto3" json:"targets,omitempty"`
+	RequestAck *mirbftpb.RequestAck `protobuf:"bytes,2,opt,name=request_ack,json=requestAck,proto3" json:"request_ack,omitempty"`
+}
+
+func (x *Actions_Forward) Reset() {
+	*x = Actions_Forward{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Forward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Forward) ProtoMessage() {}
+
+func (x *Actions_Forward) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Forward.ProtoReflect.Descriptor instead.
+func (*Actions_Forward) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 5}
+}
+
+func (x *Actions_Forward) GetTargets() []uint64 {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Actions_Forward) GetRequestAck() *mirbftpb.RequestAck {
+	if x != nil {
+		return x.RequestAck
+	}
+	return nil
+}
+
+type Actions_StateTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Actions_StateTarget) Reset() {
+	*x = Actions_StateTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_StateTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_StateTarget) ProtoMessage() {}
+
+func (x *Actions_StateTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_StateTarget.ProtoReflect.Descriptor instead.
+func (*Actions_StateTarget) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 6}
+}
+
+func (x *Actions_StateTarget) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *Actions_StateTarget) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Actions_StableCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+}
+
+func (x *Actions_StableCheckpoint) Reset() {
+	*x = Actions_StableCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_StableCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_StableCheckpoint) ProtoMessage() {}
+
+func (x *Actions_StableCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_StableCheckpoint.ProtoReflect.Descriptor instead.
+func (*Actions_StableCheckpoint) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 7}
+}
+
+func (x *Actions_StableCheckpoint) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+type Actions_Misbehavior struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node uint64          `protobuf:"varint,1,opt,name=node,proto3" json:"node,omitempty"`
+	Kind int32           `protobuf:"varint,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Msgs []*mirbftpb.Msg `protobuf:"bytes,3,rep,name=msgs,proto3" json:"msgs,omitempty"`
+}
+
+func (x *Actions_Misbehavior) Reset() {
+	*x = Actions_Misbehavior{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Actions_Misbehavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actions_Misbehavior) ProtoMessage() {}
+
+func (x *Actions_Misbehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_eventlog_recorderpb_recorder_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actions_Misbehavior.ProtoReflect.Descriptor instead.
+func (*Actions_Misbehavior) Descriptor() ([]byte, []int) {
+	return file_eventlog_recorderpb_recorder_proto_rawDescGZIP(), []int{3, 8}
+}
+
+func (x *Actions_Misbehavior) GetNode() uint64 {
+	if x != nil {
+		return x.Node
+	}
+	return 0
+}
+
+func (x *Actions_Misbehavior) GetKind() int32 {
+	if x != nil {
+		return x.Kind
+	}
+	return 0
+}
+
+func (x *Actions_Misbehavior) GetMsgs() []*mirbftpb.Msg {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
 var File_eventlog_recorderpb_recorder_proto protoreflect.FileDescriptor
 
 var file_eventlog_recorderpb_recorder_proto_rawDesc = []byte{
@@ -101,18 +887,120 @@ var file_eventlog_recorderpb_recorder_proto_rawDesc = []byte{
 	0x64, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62,
 	0x1a, 0x15, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x73, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf4, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69,
+	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a,
+	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x07,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x03, 0x67,
+	0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x70, 0x52, 0x03, 0x67, 0x61, 0x70, 0x22, 0x42,
+	0x0a, 0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x61, 0x6c, 0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x61, 0x6c, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x22, 0x1f, 0x0a, 0x03, 0x47, 0x61, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x22, 0xfa, 0x0a, 0x0a, 0x07, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x2c, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x64, 0x12, 0x2c, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x3a, 0x0a, 0x0b, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x5f, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x0a, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x41, 0x68, 0x65, 0x61, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x3f, 0x0a,
+	0x0e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x0d, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x46,
+	0x0a, 0x10, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x51,
+	0x0a, 0x11, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x10, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x43, 0x0a, 0x0c, 0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4d, 0x69, 0x73,
+	0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x62, 0x65, 0x68,
+	0x61, 0x76, 0x69, 0x6f, 0x72, 0x73, 0x1a, 0x41, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x1a, 0x48, 0x0a, 0x04, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x1a, 0x6c, 0x0a, 0x05, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x08,
+	0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00,
+	0x52, 0x08, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x61, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x69, 0x72,
 	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x2b, 0x5a, 0x29,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x49, 0x42, 0x4d, 0x2f, 0x6d,
-	0x69, 0x72, 0x62, 0x66, 0x74, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6c, 0x6f, 0x67, 0x2f, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48,
+	0x00, 0x52, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x1a, 0xad, 0x01, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x44, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x42, 0x0a,
+	0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x1a, 0x70, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x3e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x1a, 0x5a, 0x0a, 0x07, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x1a,
+	0x3a, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x29, 0x0a, 0x10, 0x53,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x1a, 0x58, 0x0a, 0x0b, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68,
+	0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a,
+	0x04, 0x6d, 0x73, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x69,
+	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73,
+	0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x49,
+	0x42, 0x4d, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6c,
+	0x6f, 0x67, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -127,18 +1015,59 @@ func file_eventlog_recorderpb_recorder_proto_rawDescGZIP() []byte {
 	return file_eventlog_recorderpb_recorder_proto_rawDescData
 }
 
-var file_eventlog_recorderpb_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_eventlog_recorderpb_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_eventlog_recorderpb_recorder_proto_goTypes = []interface{}{
-	(*RecordedEvent)(nil),       // 0: recorderpb.RecordedEvent
-	(*mirbftpb.StateEvent)(nil), // 1: mirbftpb.StateEvent
+	(*RecordedEvent)(nil),                      // 0: recorderpb.RecordedEvent
+	(*Segment)(nil),                            // 1: recorderpb.Segment
+	(*Gap)(nil),                                // 2: recorderpb.Gap
+	(*Actions)(nil),                            // 3: recorderpb.Actions
+	(*Actions_Send)(nil),                       // 4: recorderpb.Actions.Send
+	(*Actions_Hash)(nil),                       // 5: recorderpb.Actions.Hash
+	(*Actions_Write)(nil),                      // 6: recorderpb.Actions.Write
+	(*Actions_Checkpoint)(nil),                 // 7: recorderpb.Actions.Checkpoint
+	(*Actions_Commit)(nil),                     // 8: recorderpb.Actions.Commit
+	(*Actions_Forward)(nil),                    // 9: recorderpb.Actions.Forward
+	(*Actions_StateTarget)(nil),                // 10: recorderpb.Actions.StateTarget
+	(*Actions_StableCheckpoint)(nil),           // 11: recorderpb.Actions.StableCheckpoint
+	(*Actions_Misbehavior)(nil),                // 12: recorderpb.Actions.Misbehavior
+	(*mirbftpb.StateEvent)(nil),                // 13: mirbftpb.StateEvent
+	(*mirbftpb.ForwardRequest)(nil),            // 14: mirbftpb.ForwardRequest
+	(*mirbftpb.Msg)(nil),                       // 15: mirbftpb.Msg
+	(*mirbftpb.HashResult)(nil),                // 16: mirbftpb.HashResult
+	(*mirbftpb.StateEvent_PersistedEntry)(nil), // 17: mirbftpb.StateEvent.PersistedEntry
+	(*mirbftpb.NetworkState_Config)(nil),       // 18: mirbftpb.NetworkState.Config
+	(*mirbftpb.NetworkState_Client)(nil),       // 19: mirbftpb.NetworkState.Client
+	(*mirbftpb.QEntry)(nil),                    // 20: mirbftpb.QEntry
+	(*mirbftpb.RequestAck)(nil),                // 21: mirbftpb.RequestAck
 }
 var file_eventlog_recorderpb_recorder_proto_depIdxs = []int32{
-	1, // 0: recorderpb.RecordedEvent.state_event:type_name -> mirbftpb.StateEvent
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: recorderpb.RecordedEvent.state_event:type_name -> mirbftpb.StateEvent
+	3,  // 1: recorderpb.RecordedEvent.actions:type_name -> recorderpb.Actions
+	1,  // 2: recorderpb.RecordedEvent.segment:type_name -> recorderpb.Segment
+	2,  // 3: recorderpb.RecordedEvent.gap:type_name -> recorderpb.Gap
+	4,  // 4: recorderpb.Actions.send:type_name -> recorderpb.Actions.Send
+	5,  // 5: recorderpb.Actions.hash:type_name -> recorderpb.Actions.Hash
+	6,  // 6: recorderpb.Actions.write_ahead:type_name -> recorderpb.Actions.Write
+	8,  // 7: recorderpb.Actions.commits:type_name -> recorderpb.Actions.Commit
+	14, // 8: recorderpb.Actions.store_requests:type_name -> mirbftpb.ForwardRequest
+	9,  // 9: recorderpb.Actions.forward_requests:type_name -> recorderpb.Actions.Forward
+	10, // 10: recorderpb.Actions.state_transfer:type_name -> recorderpb.Actions.StateTarget
+	11, // 11: recorderpb.Actions.stable_checkpoint:type_name -> recorderpb.Actions.StableCheckpoint
+	12, // 12: recorderpb.Actions.misbehaviors:type_name -> recorderpb.Actions.Misbehavior
+	15, // 13: recorderpb.Actions.Send.msg:type_name -> mirbftpb.Msg
+	16, // 14: recorderpb.Actions.Hash.origin:type_name -> mirbftpb.HashResult
+	17, // 15: recorderpb.Actions.Write.append:type_name -> mirbftpb.StateEvent.PersistedEntry
+	18, // 16: recorderpb.Actions.Checkpoint.network_config:type_name -> mirbftpb.NetworkState.Config
+	19, // 17: recorderpb.Actions.Checkpoint.clients_state:type_name -> mirbftpb.NetworkState.Client
+	20, // 18: recorderpb.Actions.Commit.batch:type_name -> mirbftpb.QEntry
+	7,  // 19: recorderpb.Actions.Commit.checkpoint:type_name -> recorderpb.Actions.Checkpoint
+	21, // 20: recorderpb.Actions.Forward.request_ack:type_name -> mirbftpb.RequestAck
+	15, // 21: recorderpb.Actions.Misbehavior.msgs:type_name -> mirbftpb.Msg
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_eventlog_recorderpb_recorder_proto_init() }
@@ -159,6 +1088,154 @@ func file_eventlog_recorderpb_recorder_proto_init() {
 				return nil
 			}
 		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Segment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Gap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Send); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Hash); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Write); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Checkpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Commit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Forward); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_StateTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_StableCheckpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventlog_recorderpb_recorder_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Actions_Misbehavior); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_eventlog_recorderpb_recorder_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*Actions_Write_Truncate)(nil),
+		(*Actions_Write_Append)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -166,7 +1243,7 @@ func file_eventlog_recorderpb_recorder_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_eventlog_recorderpb_recorder_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,375 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+// DefaultMaxSegmentBytes is the compressed size at which a rotating
+// recorder begins a new segment when not overridden.
+const DefaultMaxSegmentBytes = 64 * 1024 * 1024
+
+// DefaultMaxSegments is the number of segments a rotating recorder
+// retains when not overridden.
+const DefaultMaxSegments = 8
+
+type maxSegmentBytesOpt int64
+
+// MaxSegmentBytesOpt overrides the compressed size at which a rotating
+// recorder begins a new segment.  Zero disables rolling by size.
+func MaxSegmentBytesOpt(bytes int64) RecorderOpt {
+	return maxSegmentBytesOpt(bytes)
+}
+
+type maxSegmentEventsOpt uint64
+
+// MaxSegmentEventsOpt sets the number of events at which a rotating
+// recorder begins a new segment.  By default, segments are rolled by
+// size only.
+func MaxSegmentEventsOpt(events uint64) RecorderOpt {
+	return maxSegmentEventsOpt(events)
+}
+
+type maxSegmentsOpt int
+
+// MaxSegmentsOpt overrides the number of segments a rotating recorder
+// retains, older segments are removed.
+func MaxSegmentsOpt(segments int) RecorderOpt {
+	return maxSegmentsOpt(segments)
+}
+
+const (
+	segmentPrefix = "eventlog-"
+	segmentSuffix = ".gz"
+)
+
+// NewRotatingRecorder creates a recorder which writes to a series of
+// segment files in dir, rather than a single stream.  A new segment is
+// begun once the current one reaches the configured size or event count,
+// and only the most recent segments are retained.  Segments are only
+// rolled between sets of actions, and each segment after the first begins
+// with a segment marker, followed by synthetic Initialize, LoadEntry, and
+// CompleteInitialization events which restart the node from a snapshot of
+// its WAL.  Because the WAL is truncated at each stable checkpoint, this
+// snapshot is anchored at the latest stable checkpoint, and any segment may
+// be replayed on its own, though replay diverges from the recorded node at
+// the restart.  If the recorder drops events (see DropOnOverflowOpt), the
+// WAL snapshot is lost until the node is next initialized, and segments
+// begun in the meantime start with a gap marker instead.  Similarly, if
+// the recording begins after the node initialized, or only actions which
+// are not recorded are dropped, segments begin with a segment marker noting
+// the WAL is unknown.
+func NewRotatingRecorder(nodeID uint64, dir string, opts ...RecorderOpt) (*Recorder, error) {
+	r := &rotation{
+		dir:         dir,
+		maxBytes:    DefaultMaxSegmentBytes,
+		maxSegments: DefaultMaxSegments,
+	}
+
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case maxSegmentBytesOpt:
+			r.maxBytes = int64(v)
+		case maxSegmentEventsOpt:
+			r.maxEvents = uint64(v)
+		case maxSegmentsOpt:
+			r.maxSegments = int(v)
+		}
+	}
+
+	if r.maxSegments < 1 {
+		return nil, errors.Errorf("at least one segment must be retained")
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	i := newRecorder(nodeID, opts)
+	i.rotation = r
+
+	go i.run(r.writer)
+
+	return i, nil
+}
+
+// Segments returns the paths of the segment files in dir, oldest first.
+func Segments(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, segmentPrefix+"*"+segmentSuffix))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not list segments")
+	}
+
+	var segments []string
+	for _, path := range paths {
+		if _, ok := segmentNumber(path); ok {
+			segments = append(segments, path)
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		a, _ := segmentNumber(segments[i])
+		b, _ := segmentNumber(segments[j])
+		return a < b
+	})
+
+	return segments, nil
+}
+
+func segmentNumber(path string) (uint64, bool) {
+	var number uint64
+	_, err := fmt.Sscanf(filepath.Base(path), segmentPrefix+"%d"+segmentSuffix, &number)
+	return number, err == nil
+}
+
+// rotation is the segment state of a rotating recorder, it is only
+// accessed from the recorder's run goroutine.
+type rotation struct {
+	dir         string
+	maxBytes    int64
+	maxEvents   uint64
+	maxSegments int
+
+	segments []string
+	next     uint64
+	file     *os.File
+	writer   *countingWriter
+	events   uint64
+	wal      walSnapshot
+}
+
+// open creates the next segment file, removing the oldest segments
+// beyond those which should be retained.
+func (r *rotation) open() error {
+	if r.segments == nil {
+		existing, err := Segments(r.dir)
+		if err != nil {
+			return err
+		}
+
+		r.segments = existing
+		if len(existing) > 0 {
+			last, _ := segmentNumber(existing[len(existing)-1])
+			r.next = last + 1
+		}
+	}
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%s%08d%s", segmentPrefix, r.next, segmentSuffix))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.WithMessage(err, "could not create segment")
+	}
+
+	r.next++
+	r.file = file
+	r.writer = &countingWriter{dest: file}
+	r.events = 0
+	r.segments = append(r.segments, path)
+
+	for len(r.segments) > r.maxSegments {
+		if err := os.Remove(r.segments[0]); err != nil && !os.IsNotExist(err) {
+			return errors.WithMessage(err, "could not remove old segment")
+		}
+		r.segments = r.segments[1:]
+	}
+
+	return nil
+}
+
+func (r *rotation) close() error {
+	return r.file.Close()
+}
+
+// apply tracks the WAL of the node from a recorded event.
+func (r *rotation) apply(event *rpb.RecordedEvent) {
+	r.events++
+	r.track(event)
+}
+
+// track tracks the WAL of the node from an event, which is not
+// necessarily recorded, as for actions when only rotating.
+func (r *rotation) track(event *rpb.RecordedEvent) {
+	switch {
+	case event.Actions != nil:
+		r.wal.applyActions(event.Actions)
+	case event.Gap != nil:
+		// Initialization may have completed during the gap, but as
+		// the WAL is lost regardless, rolling is safe.
+		r.wal.entries = nil
+		r.wal.initializing = false
+		r.wal.gapped = true
+	case event.StateEvent != nil:
+		r.wal.applyEvent(event.StateEvent)
+	}
+}
+
+// lose discards the WAL snapshot, as when actions which were not to be
+// recorded are dropped, so the WAL is unknown though no recorded events
+// were lost.
+func (r *rotation) lose() {
+	r.wal.entries = nil
+}
+
+// due indicates that a new segment should be begun.  This is only the
+// case directly after a set of actions, so that the WAL snapshot is of
+// the entries handed to the consumer, and never while the node is
+// initializing.
+func (r *rotation) due(event *rpb.RecordedEvent) bool {
+	if event.Actions == nil || r.wal.initializing {
+		return false
+	}
+
+	return (r.maxBytes != 0 && r.writer.written >= r.maxBytes) ||
+		(r.maxEvents != 0 && r.events >= r.maxEvents)
+}
+
+// prefix returns the marker and synthetic events which begin the
+// current segment.
+func (r *rotation) prefix(nodeID uint64, time int64) []*rpb.RecordedEvent {
+	result := []*rpb.RecordedEvent{
+		{
+			NodeId: nodeID,
+			Time:   time,
+			Segment: &rpb.Segment{
+				Number: r.next - 1,
+			},
+		},
+	}
+
+	if r.wal.entries == nil && r.wal.gapped {
+		// The WAL was lost to a gap, so the segment cannot be
+		// replayed on its own.
+		return append(result, &rpb.RecordedEvent{
+			NodeId: nodeID,
+			Time:   time,
+			Gap:    &rpb.Gap{},
+		})
+	}
+
+	if r.wal.entries == nil {
+		// No events were dropped, but the WAL is unknown all the same,
+		// so the segment cannot be replayed on its own.
+		result[0].Segment.WalUnknown = true
+		return result
+	}
+
+	for _, stateEvent := range r.wal.stateEvents() {
+		result = append(result, &rpb.RecordedEvent{
+			NodeId:     nodeID,
+			Time:       time,
+			StateEvent: stateEvent,
+		})
+	}
+
+	return result
+}
+
+// walSnapshot tracks the entries of a node's WAL, as loaded at
+// initialization and as written by the actions since.  The entries
+// are nil while the WAL is unknown, and gapped indicates that it was
+// lost to dropped events, rather than never known.
+type walSnapshot struct {
+	parameters   *pb.StateEvent_InitialParameters
+	entries      map[uint64]*pb.Persistent
+	initializing bool
+	gapped       bool
+}
+
+func (ws *walSnapshot) applyEvent(event *pb.StateEvent) {
+	switch e := event.Type.(type) {
+	case *pb.StateEvent_Initialize:
+		ws.parameters = e.Initialize
+		ws.entries = map[uint64]*pb.Persistent{}
+		ws.initializing = true
+		ws.gapped = false
+	case *pb.StateEvent_LoadEntry:
+		if ws.entries != nil {
+			ws.entries[e.LoadEntry.Index] = e.LoadEntry.Data
+		}
+	case *pb.StateEvent_CompleteInitialization:
+		ws.initializing = false
+	}
+}
+
+func (ws *walSnapshot) applyActions(actions *rpb.Actions) {
+	if ws.entries == nil {
+		// The recording began after the node initialized, or
+		// events were dropped, so the WAL is unknown until the
+		// node is next initialized.
+		return
+	}
+
+	for _, write := range actions.WriteAhead {
+		switch w := write.Type.(type) {
+		case *rpb.Actions_Write_Truncate:
+			for index := range ws.entries {
+				if index < w.Truncate {
+					delete(ws.entries, index)
+				}
+			}
+		case *rpb.Actions_Write_Append:
+			ws.entries[w.Append.Index] = w.Append.Data
+		}
+	}
+}
+
+// stateEvents returns the events which initialize a node from the WAL.
+func (ws *walSnapshot) stateEvents() []*pb.StateEvent {
+	indices := make([]uint64, 0, len(ws.entries))
+	for index := range ws.entries {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	result := make([]*pb.StateEvent, 0, len(indices)+2)
+	result = append(result, &pb.StateEvent{
+		Type: &pb.StateEvent_Initialize{
+			Initialize: ws.parameters,
+		},
+	})
+
+	for _, index := range indices {
+		result = append(result, &pb.StateEvent{
+			Type: &pb.StateEvent_LoadEntry{
+				LoadEntry: &pb.StateEvent_PersistedEntry{
+					Index: index,
+					Data:  ws.entries[index],
+				},
+			},
+		})
+	}
+
+	return append(result, &pb.StateEvent{
+		Type: &pb.StateEvent_CompleteInitialization{
+			CompleteInitialization: &pb.StateEvent_LoadCompleted{},
+		},
+	})
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	dest    io.Writer
+	written int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.dest.Write(p)
+	cw.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("rotation", func() {
+	var (
+		dir      string
+		recorder *Recorder
+	)
+
+	tick := &pb.StateEvent{
+		Type: &pb.StateEvent_Tick{
+			Tick: &pb.StateEvent_TickElapsed{},
+		},
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "eventlog-rotation")
+		Expect(err).NotTo(HaveOccurred())
+
+		r := &rotation{
+			dir:         dir,
+			maxSegments: 1,
+		}
+		Expect(r.open()).To(Succeed())
+
+		// The recorder is not run, so that its buffer may be
+		// filled and drained by hand.
+		recorder = newRecorder(1, []RecorderOpt{
+			BufferSizeOpt(1),
+			DropOnOverflowOpt(),
+		})
+		recorder.rotation = r
+	})
+
+	AfterEach(func() {
+		Expect(recorder.rotation.close()).To(Succeed())
+		os.RemoveAll(dir)
+	})
+
+	It("does not count dropped actions which would not be recorded", func() {
+		Expect(recorder.Intercept(tick)).To(Succeed())
+		Expect(recorder.InterceptActions(&mirbft.Actions{})).To(Succeed())
+		Expect(recorder.Dropped()).To(BeZero())
+
+		<-recorder.eventC
+		Expect(recorder.Intercept(tick)).To(Succeed())
+		event := <-recorder.eventC
+		Expect(event.dropped).To(BeZero())
+		Expect(event.lost).To(BeTrue())
+	})
+
+	It("counts dropped actions which would be recorded", func() {
+		recorder.recordActions = true
+		Expect(recorder.Intercept(tick)).To(Succeed())
+		Expect(recorder.InterceptActions(&mirbft.Actions{})).To(Succeed())
+		Expect(recorder.Dropped()).To(Equal(uint64(1)))
+
+		<-recorder.eventC
+		Expect(recorder.Intercept(tick)).To(Succeed())
+		event := <-recorder.eventC
+		Expect(event.dropped).To(Equal(uint64(1)))
+		Expect(event.lost).To(BeFalse())
+	})
+
+	It("begins a segment noting the WAL is unknown once it is lost", func() {
+		r := recorder.rotation
+		r.apply(&rpb.RecordedEvent{
+			StateEvent: &pb.StateEvent{
+				Type: &pb.StateEvent_Initialize{
+					Initialize: &pb.StateEvent_InitialParameters{},
+				},
+			},
+		})
+		r.apply(&rpb.RecordedEvent{
+			StateEvent: &pb.StateEvent{
+				Type: &pb.StateEvent_CompleteInitialization{
+					CompleteInitialization: &pb.StateEvent_LoadCompleted{},
+				},
+			},
+		})
+		r.lose()
+
+		prefix := r.prefix(1, 2)
+		Expect(prefix).To(HaveLen(1))
+		Expect(prefix[0].Segment.WalUnknown).To(BeTrue())
+	})
+})
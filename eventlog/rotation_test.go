@@ -0,0 +1,251 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventlog_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/eventlog"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("RotatingRecorder", func() {
+	var (
+		dir string
+
+		initialParameters = &pb.StateEvent_InitialParameters{
+			Id:        1,
+			BatchSize: 1,
+		}
+	)
+
+	entry := func(epoch uint64) *pb.Persistent {
+		return &pb.Persistent{
+			Type: &pb.Persistent_Suspect{
+				Suspect: &pb.Suspect{
+					Epoch: epoch,
+				},
+			},
+		}
+	}
+
+	appendEntry := func(index uint64) *mirbft.Actions {
+		return &mirbft.Actions{
+			WriteAhead: []*mirbft.Write{
+				{
+					Append: &mirbft.WALEntry{
+						Index: index,
+						Data:  entry(index),
+					},
+				},
+			},
+		}
+	}
+
+	readSegment := func(path string) []*rpb.RecordedEvent {
+		file, err := os.Open(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+
+		reader, err := eventlog.NewReader(file)
+		Expect(err).NotTo(HaveOccurred())
+
+		var result []*rpb.RecordedEvent
+		for {
+			event, err := reader.ReadEvent()
+			if err == io.EOF {
+				return result
+			}
+			Expect(err).NotTo(HaveOccurred())
+			result = append(result, event)
+		}
+	}
+
+	initialize := func(recorder *eventlog.Recorder) {
+		Expect(recorder.Intercept(&pb.StateEvent{
+			Type: &pb.StateEvent_Initialize{
+				Initialize: initialParameters,
+			},
+		})).To(Succeed())
+
+		for _, index := range []uint64{1, 2} {
+			Expect(recorder.Intercept(&pb.StateEvent{
+				Type: &pb.StateEvent_LoadEntry{
+					LoadEntry: &pb.StateEvent_PersistedEntry{
+						Index: index,
+						Data:  entry(index),
+					},
+				},
+			})).To(Succeed())
+		}
+
+		Expect(recorder.Intercept(&pb.StateEvent{
+			Type: &pb.StateEvent_CompleteInitialization{
+				CompleteInitialization: &pb.StateEvent_LoadCompleted{},
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "eventlog-rotation")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("begins each new segment with a snapshot of the WAL", func() {
+		recorder, err := eventlog.NewRotatingRecorder(
+			1,
+			dir,
+			eventlog.TimeSourceOpt(func() int64 { return 2 }),
+			eventlog.MaxSegmentEventsOpt(5),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		initialize(recorder)
+		Expect(recorder.InterceptActions(appendEntry(3))).To(Succeed())
+		Expect(recorder.Intercept(tickEvent)).To(Succeed())
+		truncate := uint64(2)
+		Expect(recorder.InterceptActions(&mirbft.Actions{
+			WriteAhead: []*mirbft.Write{{Truncate: &truncate}},
+		})).To(Succeed())
+		Expect(recorder.Intercept(tickEvent)).To(Succeed())
+		Expect(recorder.Stop()).To(Succeed())
+
+		segments, err := eventlog.Segments(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(segments).To(Equal([]string{
+			filepath.Join(dir, "eventlog-00000000.gz"),
+			filepath.Join(dir, "eventlog-00000001.gz"),
+		}))
+
+		// The actions are only used to track the WAL, not recorded
+		// nor counted
+		first := readSegment(segments[0])
+		Expect(first).To(HaveLen(5))
+		Expect(first[0].StateEvent.GetInitialize()).NotTo(BeNil())
+		Expect(first[4].StateEvent.GetTick()).NotTo(BeNil())
+
+		// The segment is rolled after the truncation, the first actions
+		// once five events were recorded
+		second := readSegment(segments[1])
+		Expect(second).To(HaveLen(6))
+		Expect(proto.Equal(second[0], &rpb.RecordedEvent{
+			NodeId:  1,
+			Time:    2,
+			Segment: &rpb.Segment{Number: 1},
+		})).To(BeTrue())
+		Expect(proto.Equal(second[1].StateEvent.GetInitialize(), initialParameters)).To(BeTrue())
+		Expect(second[2].StateEvent.GetLoadEntry().Index).To(Equal(uint64(2)))
+		Expect(second[3].StateEvent.GetLoadEntry().Index).To(Equal(uint64(3)))
+		Expect(proto.Equal(second[3].StateEvent.GetLoadEntry().Data, entry(3))).To(BeTrue())
+		Expect(second[4].StateEvent.GetCompleteInitialization()).NotTo(BeNil())
+		Expect(second[5].StateEvent.GetTick()).NotTo(BeNil())
+	})
+
+	It("does not roll while the node is initializing", func() {
+		recorder, err := eventlog.NewRotatingRecorder(
+			1,
+			dir,
+			eventlog.MaxSegmentEventsOpt(1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(recorder.Intercept(&pb.StateEvent{
+			Type: &pb.StateEvent_Initialize{
+				Initialize: initialParameters,
+			},
+		})).To(Succeed())
+		Expect(recorder.InterceptActions(appendEntry(1))).To(Succeed())
+		Expect(recorder.Stop()).To(Succeed())
+
+		segments, err := eventlog.Segments(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(segments).To(HaveLen(1))
+	})
+
+	It("notes the WAL is unknown when the recording begins after initialization", func() {
+		recorder, err := eventlog.NewRotatingRecorder(
+			1,
+			dir,
+			eventlog.TimeSourceOpt(func() int64 { return 2 }),
+			eventlog.MaxSegmentEventsOpt(1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(recorder.Intercept(tickEvent)).To(Succeed())
+		Expect(recorder.InterceptActions(appendEntry(3))).To(Succeed())
+		Expect(recorder.Intercept(tickEvent)).To(Succeed())
+		Expect(recorder.Stop()).To(Succeed())
+		Expect(recorder.Dropped()).To(BeZero())
+
+		segments, err := eventlog.Segments(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(segments).To(HaveLen(2))
+
+		// No events were dropped, so there is no gap
+		second := readSegment(segments[1])
+		Expect(second).To(HaveLen(2))
+		Expect(proto.Equal(second[0], &rpb.RecordedEvent{
+			NodeId: 1,
+			Time:   2,
+			Segment: &rpb.Segment{
+				Number:     1,
+				WalUnknown: true,
+			},
+		})).To(BeTrue())
+		Expect(second[1].StateEvent.GetTick()).NotTo(BeNil())
+	})
+
+	It("retains only the most recent segments, continuing the numbering", func() {
+		for run := 0; run < 2; run++ {
+			recorder, err := eventlog.NewRotatingRecorder(
+				1,
+				dir,
+				eventlog.MaxSegmentEventsOpt(1),
+				eventlog.MaxSegmentsOpt(3),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			initialize(recorder)
+			for index := uint64(3); index < 6; index++ {
+				Expect(recorder.InterceptActions(appendEntry(index))).To(Succeed())
+			}
+			Expect(recorder.Stop()).To(Succeed())
+		}
+
+		segments, err := eventlog.Segments(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(segments).To(Equal([]string{
+			filepath.Join(dir, "eventlog-00000005.gz"),
+			filepath.Join(dir, "eventlog-00000006.gz"),
+			filepath.Join(dir, "eventlog-00000007.gz"),
+		}))
+
+		last := readSegment(segments[2])
+		Expect(last[0].Segment.Number).To(Equal(uint64(7)))
+		Expect(last[len(last)-2].StateEvent.GetLoadEntry().Index).To(Equal(uint64(5)))
+	})
+
+	It("requires a segment be retained", func() {
+		_, err := eventlog.NewRotatingRecorder(1, dir, eventlog.MaxSegmentsOpt(0))
+		Expect(err).To(MatchError("at least one segment must be retained"))
+	})
+})
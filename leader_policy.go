@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+// LeaderPolicy selects the set of leaders for a new epoch.  Only the primary of the
+// new epoch applies its policy, the other nodes adopt the leaders the primary
+// selects in its NewEpoch message.  Still, as the state machine must be deterministic,
+// implementations must compute the leaders only from their arguments.
+type LeaderPolicy interface {
+	// Leaders returns the leaders for the epoch numbered newEpoch.  The lastEpochConfig
+	// is the config of the most recent epoch which became active, and graceful indicates
+	// whether it ended at its planned expiration (or a reconfiguration), rather than
+	// because it was suspected.  Any epochs numbered between lastEpochConfig.Number and
+	// newEpoch failed to become active.  The returned leaders must be a subset of the
+	// nodes in the network config, and must include the primary of the new epoch.
+	Leaders(networkConfig *pb.NetworkState_Config, lastEpochConfig *pb.EpochConfig, graceful bool, newEpoch uint64) []uint64
+}
+
+// epochPrimary returns the ID of the node which is the primary for the given epoch,
+// that is, the node responsible for sending the NewEpoch message.  Node IDs need not
+// be dense, as any node may be removed by reconfiguration, so the primary is selected
+// by position in the network config rather than by ID.
+func epochPrimary(networkConfig *pb.NetworkState_Config, epoch uint64) uint64 {
+	return networkConfig.Nodes[epoch%uint64(len(networkConfig.Nodes))]
+}
+
+// SuspectLeaderPolicy is the default LeaderPolicy, following the approach of
+// Mir-BFT.  When an epoch ends ungracefully, its primary is removed from the leader
+// set, as is the primary of any subsequent epoch which failed to become active.  Each
+// time an epoch ends gracefully, one removed node is restored to the leader set, so
+// that the leader set grows back to the full set of nodes over later epochs.  The
+// primary of the new epoch is always a leader.
+type SuspectLeaderPolicy struct{}
+
+func (SuspectLeaderPolicy) Leaders(networkConfig *pb.NetworkState_Config, lastEpochConfig *pb.EpochConfig, graceful bool, newEpoch uint64) []uint64 {
+	lastLeaders := map[uint64]struct{}{}
+	for _, leader := range lastEpochConfig.Leaders {
+		lastLeaders[leader] = struct{}{}
+	}
+
+	firstFailed := lastEpochConfig.Number + 1
+	if !graceful {
+		firstFailed = lastEpochConfig.Number
+	}
+
+	if newEpoch > uint64(len(networkConfig.Nodes)) && newEpoch-uint64(len(networkConfig.Nodes)) > firstFailed {
+		// Every node has been primary for a failed epoch, no need to look further back
+		firstFailed = newEpoch - uint64(len(networkConfig.Nodes))
+	}
+
+	suspects := map[uint64]struct{}{}
+	for epoch := firstFailed; epoch < newEpoch; epoch++ {
+		suspects[epochPrimary(networkConfig, epoch)] = struct{}{}
+	}
+
+	primary := epochPrimary(networkConfig, newEpoch)
+
+	// Only restore a node to the leader set if no epoch has failed since
+	// the last epoch which became active, and the primary, which is always
+	// a leader, is not itself being restored.
+	_, primaryWasLeader := lastLeaders[primary]
+	restored := len(suspects) != 0 || !primaryWasLeader
+
+	leaders := make([]uint64, 0, len(networkConfig.Nodes))
+	for _, node := range networkConfig.Nodes {
+		_, wasLeader := lastLeaders[node]
+		_, suspected := suspects[node]
+
+		switch {
+		case node == primary:
+		case wasLeader && !suspected:
+		case !wasLeader && !restored:
+			restored = true
+		default:
+			continue
+		}
+
+		leaders = append(leaders, node)
+	}
+
+	return leaders
+}
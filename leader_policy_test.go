@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("SuspectLeaderPolicy", func() {
+	networkConfig := &pb.NetworkState_Config{
+		Nodes: []uint64{0, 1, 2, 3},
+		F:     1,
+	}
+
+	DescribeTable("selects the leaders for a new epoch",
+		func(lastLeaders []uint64, lastEpoch int, graceful bool, newEpoch int, expected []uint64) {
+			leaders := mirbft.SuspectLeaderPolicy{}.Leaders(
+				networkConfig,
+				&pb.EpochConfig{
+					Number:  uint64(lastEpoch),
+					Leaders: lastLeaders,
+				},
+				graceful,
+				uint64(newEpoch),
+			)
+			Expect(leaders).To(Equal(expected))
+		},
+
+		Entry("keeps every leader after a graceful end",
+			[]uint64{0, 1, 2, 3}, 1, true, 2, []uint64{0, 1, 2, 3}),
+
+		Entry("removes the primary of an ungraceful epoch",
+			[]uint64{0, 1, 2, 3}, 1, false, 2, []uint64{0, 2, 3}),
+
+		Entry("removes the primaries of epochs which failed to start",
+			[]uint64{0, 1, 2, 3}, 1, false, 3, []uint64{0, 3}),
+
+		Entry("removes the primaries of failed epochs after a graceful end",
+			[]uint64{0, 1, 2, 3}, 1, true, 3, []uint64{0, 1, 3}),
+
+		Entry("restores one node after a graceful end",
+			[]uint64{0, 3}, 4, true, 5, []uint64{0, 1, 3}),
+
+		Entry("always includes the primary of the new epoch",
+			[]uint64{0, 3}, 4, true, 6, []uint64{0, 2, 3}),
+
+		Entry("looks back no further than one failed epoch per node",
+			[]uint64{0, 1, 2, 3}, 1, false, 9, []uint64{1}),
+	)
+
+	When("node IDs are not dense, as after a removal", func() {
+		sparseConfig := &pb.NetworkState_Config{
+			Nodes: []uint64{0, 2, 3, 5},
+			F:     1,
+		}
+
+		DescribeTable("selects primaries by their position among the nodes",
+			func(lastLeaders []uint64, lastEpoch int, graceful bool, newEpoch int, expected []uint64) {
+				leaders := mirbft.SuspectLeaderPolicy{}.Leaders(
+					sparseConfig,
+					&pb.EpochConfig{
+						Number:  uint64(lastEpoch),
+						Leaders: lastLeaders,
+					},
+					graceful,
+					uint64(newEpoch),
+				)
+				Expect(leaders).To(Equal(expected))
+			},
+
+			Entry("removes the primary of an ungraceful epoch",
+				[]uint64{0, 2, 3, 5}, 1, false, 2, []uint64{0, 3, 5}),
+
+			Entry("removes the primaries of failed epochs after a graceful end",
+				[]uint64{0, 2, 3, 5}, 2, true, 4, []uint64{0, 2, 3}),
+
+			Entry("always includes the primary of the new epoch",
+				[]uint64{0, 2}, 5, true, 7, []uint64{0, 2, 5}),
+		)
+	})
+})
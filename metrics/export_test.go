@@ -0,0 +1,17 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"github.com/IBM/mirbft/status"
+)
+
+// CollectStatus reports the metrics of the given status, rather
+// than of the status obtained from the node.
+func (c *Collector) CollectStatus(s *status.StateMachine) {
+	c.collectStatus(s)
+}
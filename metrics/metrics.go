@@ -0,0 +1,386 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics exports the state of a node as metrics, suitable for dashboards
+// and alerting.  A Collector periodically samples the status of the node, and
+// observes the actions it produces, reporting the resulting metric values to a
+// Sink.  The PrometheusSink retains the most recent values and serves them over
+// HTTP in the Prometheus text exposition format, but any other monitoring system
+// may be supported by implementing Sink.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/status"
+)
+
+type Type int
+
+const (
+	// Gauge is a metric whose value may go up and down.
+	Gauge Type = iota
+
+	// Counter is a metric whose value only increases, though it is reset
+	// when the node restarts.
+	Counter
+)
+
+// Metric describes a metric reported to a Sink.  Each value reported for
+// a metric supplies one value for each of the metric's labels.
+type Metric struct {
+	Name   string
+	Help   string
+	Type   Type
+	Labels []string
+}
+
+// Sink receives metric values from the Collector.  Implementations must be
+// safe for concurrent use.
+type Sink interface {
+	// Set records the current value of the metric, for the given label values.
+	Set(metric *Metric, value float64, labelValues ...string)
+
+	// Delete discards the value of the metric for the given label values,
+	// for instance because the node or client they identify has been removed
+	// from the network configuration.
+	Delete(metric *Metric, labelValues ...string)
+}
+
+var (
+	LowWatermark = &Metric{
+		Name: "mirbft_low_watermark",
+		Help: "The low watermark sequence number of the active epoch.",
+	}
+
+	HighWatermark = &Metric{
+		Name: "mirbft_high_watermark",
+		Help: "The high watermark sequence number of the active epoch.",
+	}
+
+	BucketSequences = &Metric{
+		Name:   "mirbft_bucket_sequences",
+		Help:   "The number of sequences within the watermarks of each bucket, by sequence state.",
+		Labels: []string{"bucket", "state"},
+	}
+
+	Checkpoints = &Metric{
+		Name:   "mirbft_checkpoints",
+		Help:   "The number of checkpoints within the watermarks, by stability.",
+		Labels: []string{"state"},
+	}
+
+	StableCheckpoint = &Metric{
+		Name: "mirbft_stable_checkpoint_seq_no",
+		Help: "The sequence number of the highest stable checkpoint.",
+	}
+
+	Epoch = &Metric{
+		Name: "mirbft_epoch",
+		Help: "The number of the current epoch.",
+	}
+
+	EpochState = &Metric{
+		Name: "mirbft_epoch_state",
+		Help: epochStateHelp(),
+	}
+
+	EpochChanges = &Metric{
+		Name: "mirbft_epoch_changes_total",
+		Help: "The number of epoch changes this node has initiated.",
+		Type: Counter,
+	}
+
+	NodeBufferMsgs = &Metric{
+		Name:   "mirbft_node_buffer_msgs",
+		Help:   "The number of messages buffered from each node.",
+		Labels: []string{"node"},
+	}
+
+	NodeBufferBytes = &Metric{
+		Name:   "mirbft_node_buffer_bytes",
+		Help:   "The size in bytes of the messages buffered from each node.",
+		Labels: []string{"node"},
+	}
+
+	ClientWindowWidth = &Metric{
+		Name:   "mirbft_client_window_width",
+		Help:   "The number of request numbers within the watermarks of each client.",
+		Labels: []string{"client"},
+	}
+
+	ClientWindowAllocated = &Metric{
+		Name:   "mirbft_client_window_allocated",
+		Help:   "The number of request numbers within the watermarks of each client for which a request has been received.",
+		Labels: []string{"client"},
+	}
+
+	ClientWindowUtilization = &Metric{
+		Name:   "mirbft_client_window_utilization",
+		Help:   "The fraction of the window of each client for which a request has been received.",
+		Labels: []string{"client"},
+	}
+
+	ReadyBatches = &Metric{
+		Name: "mirbft_ready_batches_total",
+		Help: "The number of sets of actions read from Ready.",
+		Type: Counter,
+	}
+
+	ReadyActions = &Metric{
+		Name:   "mirbft_ready_actions_total",
+		Help:   "The number of actions read from Ready, by type.",
+		Type:   Counter,
+		Labels: []string{"type"},
+	}
+
+	ReadyBatchActions = &Metric{
+		Name:   "mirbft_ready_batch_actions",
+		Help:   "The number of actions in the most recent set of actions read from Ready, by type.",
+		Labels: []string{"type"},
+	}
+)
+
+// epochStateHelp describes each value of the epoch state metric.
+func epochStateHelp() string {
+	var states []string
+	for state := status.EpochPrepending; state <= status.EpochDone; state++ {
+		states = append(states, fmt.Sprintf("%d=%s", state, state))
+	}
+	return fmt.Sprintf("The state of the current epoch, one of %s.", strings.Join(states, ", "))
+}
+
+var sequenceStates = map[status.SequenceState]string{
+	status.SequenceUninitialized:   "uninitialized",
+	status.SequenceAllocated:       "allocated",
+	status.SequencePendingRequests: "pending_requests",
+	status.SequenceReady:           "ready",
+	status.SequencePreprepared:     "preprepared",
+	status.SequencePrepared:        "prepared",
+	status.SequenceCommitted:       "committed",
+}
+
+var actionTypes = []string{
+	"send",
+	"hash",
+	"write_ahead",
+	"commit",
+	"store_request",
+	"forward_request",
+	"stable_checkpoint",
+	"state_transfer",
+	"misbehavior",
+}
+
+// Collector reports the metrics of a node to a sink.
+type Collector struct {
+	node *mirbft.Node
+	sink Sink
+
+	mutex        sync.Mutex
+	readyBatches uint64
+	readyActions map[string]uint64
+
+	// labelled holds the label values of the per node and per client metrics
+	// reported by the last collection, so that those of nodes and clients which
+	// have since been removed may be deleted.
+	labelled map[*Metric]map[string][]string
+}
+
+func NewCollector(node *mirbft.Node, sink Sink) *Collector {
+	return &Collector{
+		node:         node,
+		sink:         sink,
+		readyActions: map[string]uint64{},
+		labelled:     map[*Metric]map[string][]string{},
+	}
+}
+
+// Run collects the status metrics of the node at the given interval, until the
+// context is cancelled, or the node exits.
+func (c *Collector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.collectAndLog(ctx)
+		case <-c.node.Err():
+			// Report the final status of the node
+			c.collectAndLog(ctx)
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Collector) collectAndLog(ctx context.Context) {
+	if err := c.Collect(ctx); err != nil {
+		c.node.Config.Logger.Log(mirbft.LevelWarn, "could not collect node status for metrics", "error", err)
+	}
+}
+
+// Collect samples the status of the node and reports the resulting metrics.
+// An error is returned only if no status could be obtained.
+func (c *Collector) Collect(ctx context.Context) error {
+	s, err := c.node.Status(ctx)
+	if s == nil {
+		return err
+	}
+
+	c.collectStatus(s)
+	return nil
+}
+
+func (c *Collector) collectStatus(s *status.StateMachine) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	labelled := map[*Metric]map[string][]string{}
+	setLabelled := func(metric *Metric, value float64, labelValues ...string) {
+		values, ok := labelled[metric]
+		if !ok {
+			values = map[string][]string{}
+			labelled[metric] = values
+		}
+		values[strings.Join(labelValues, ",")] = labelValues
+		c.sink.Set(metric, value, labelValues...)
+	}
+
+	c.sink.Set(LowWatermark, float64(s.LowWatermark))
+	c.sink.Set(HighWatermark, float64(s.HighWatermark))
+
+	for _, bucket := range s.Buckets {
+		counts := map[status.SequenceState]int{}
+		for _, state := range bucket.Sequences {
+			counts[state]++
+		}
+
+		bucketID := strconv.FormatUint(bucket.ID, 10)
+		for state, name := range sequenceStates {
+			setLabelled(BucketSequences, float64(counts[state]), bucketID, name)
+		}
+	}
+
+	var stable, committed, local, pending int
+	var highestStable uint64
+	for _, cp := range s.Checkpoints {
+		switch {
+		case cp.Stable:
+			stable++
+			if cp.SeqNo > highestStable {
+				highestStable = cp.SeqNo
+			}
+		case cp.NetQuorum:
+			committed++
+		case cp.LocalDecision:
+			local++
+		default:
+			pending++
+		}
+	}
+	c.sink.Set(Checkpoints, float64(stable), "stable")
+	c.sink.Set(Checkpoints, float64(committed), "net_quorum")
+	c.sink.Set(Checkpoints, float64(local), "local_decision")
+	c.sink.Set(Checkpoints, float64(pending), "pending")
+	c.sink.Set(StableCheckpoint, float64(highestStable))
+
+	if s.EpochTracker != nil {
+		c.sink.Set(Epoch, float64(s.EpochTracker.LastActiveEpoch))
+		c.sink.Set(EpochState, float64(s.EpochTracker.State))
+		c.sink.Set(EpochChanges, float64(s.EpochTracker.EpochChangesSent))
+	}
+
+	for _, nb := range s.NodeBuffers {
+		id := strconv.FormatUint(nb.ID, 10)
+		setLabelled(NodeBufferMsgs, float64(nb.Msgs), id)
+		setLabelled(NodeBufferBytes, float64(nb.Size), id)
+	}
+
+	for _, cw := range s.ClientWindows {
+		id := strconv.FormatUint(cw.ClientID, 10)
+		width := cw.HighWatermark - cw.LowWatermark + 1
+		allocated := 0
+		for _, a := range cw.Allocated {
+			if a != 0 {
+				allocated++
+			}
+		}
+
+		setLabelled(ClientWindowWidth, float64(width), id)
+		setLabelled(ClientWindowAllocated, float64(allocated), id)
+		setLabelled(ClientWindowUtilization, float64(allocated)/float64(width), id)
+	}
+
+	for metric, previous := range c.labelled {
+		for key, labelValues := range previous {
+			if _, ok := labelled[metric][key]; !ok {
+				c.sink.Delete(metric, labelValues...)
+			}
+		}
+	}
+	c.labelled = labelled
+}
+
+// ObserveActions reports the sizes of a set of actions read from Ready.
+func (c *Collector) ObserveActions(actions *mirbft.Actions) {
+	sizes := map[string]int{
+		"send":            len(actions.Send),
+		"hash":            len(actions.Hash),
+		"write_ahead":     len(actions.WriteAhead),
+		"commit":          len(actions.Commits),
+		"store_request":   len(actions.StoreRequests),
+		"forward_request": len(actions.ForwardRequests),
+		"misbehavior":     len(actions.Misbehaviors),
+	}
+
+	if actions.StableCheckpoint != nil {
+		sizes["stable_checkpoint"] = 1
+	}
+
+	if actions.StateTransfer != nil {
+		sizes["state_transfer"] = 1
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.readyBatches++
+	c.sink.Set(ReadyBatches, float64(c.readyBatches))
+
+	for _, actionType := range actionTypes {
+		c.readyActions[actionType] += uint64(sizes[actionType])
+		c.sink.Set(ReadyActions, float64(c.readyActions[actionType]), actionType)
+		c.sink.Set(ReadyBatchActions, float64(sizes[actionType]), actionType)
+	}
+}
+
+// InstrumentProcessor wraps the processor so that the sizes of the actions
+// it processes are observed, for instance, for use with mirbft.Run.
+func (c *Collector) InstrumentProcessor(processor mirbft.ActionsProcessor) mirbft.ActionsProcessor {
+	return &instrumentedProcessor{
+		collector: c,
+		processor: processor,
+	}
+}
+
+type instrumentedProcessor struct {
+	collector *Collector
+	processor mirbft.ActionsProcessor
+}
+
+func (ip *instrumentedProcessor) Process(actions *mirbft.Actions) (*mirbft.ActionResults, error) {
+	ip.collector.ObserveActions(actions)
+	return ip.processor.Process(actions)
+}
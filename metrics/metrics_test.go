@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/metrics"
+	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/status"
+)
+
+type fakeProcessor struct {
+	processed int
+}
+
+func (fp *fakeProcessor) Process(actions *mirbft.Actions) (*mirbft.ActionResults, error) {
+	fp.processed++
+	return &mirbft.ActionResults{}, nil
+}
+
+var _ = Describe("Collector", func() {
+	var (
+		node      *mirbft.Node
+		sink      *metrics.PrometheusSink
+		collector *metrics.Collector
+	)
+
+	BeforeEach(func() {
+		var err error
+		node, err = mirbft.StartNewNode(
+			&mirbft.Config{
+				ID:                   0,
+				BatchSize:            1,
+				SuspectTicks:         4,
+				HeartbeatTicks:       2,
+				NewEpochTimeoutTicks: 8,
+				BufferSize:           5 * 1024 * 1024,
+				Logger:               mirbft.ConsoleWarnLogger,
+			},
+			mirbft.StandardInitialNetworkState(4, 0),
+			[]byte("fake-application-state"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		sink = metrics.NewPrometheusSink()
+		collector = metrics.NewCollector(node, sink)
+	})
+
+	AfterEach(func() {
+		node.Stop()
+	})
+
+	exposition := func() string {
+		var buffer bytes.Buffer
+		_, err := sink.WriteTo(&buffer)
+		Expect(err).NotTo(HaveOccurred())
+		return buffer.String()
+	}
+
+	It("reports the status of the node", func() {
+		err := collector.Collect(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		output := exposition()
+		Expect(output).To(ContainSubstring("# TYPE mirbft_low_watermark gauge\nmirbft_low_watermark 1\n"))
+		Expect(output).To(ContainSubstring("# TYPE mirbft_epoch_changes_total counter\nmirbft_epoch_changes_total 0\n"))
+		Expect(output).To(ContainSubstring("mirbft_epoch 1\n"))
+		Expect(output).To(ContainSubstring(`mirbft_bucket_sequences{bucket="3",state="uninitialized"} `))
+		Expect(output).To(ContainSubstring(`mirbft_checkpoints{state="stable"} 1`))
+		Expect(output).To(ContainSubstring(`mirbft_node_buffer_msgs{node="2"} 0`))
+		Expect(output).To(ContainSubstring(`mirbft_client_window_width{client="0"} 101`))
+		Expect(output).To(ContainSubstring(`mirbft_client_window_allocated{client="0"} 0`))
+		Expect(output).To(ContainSubstring(`# HELP mirbft_epoch_state The state of the current epoch, one of 0=prepending, 1=pending,`))
+		Expect(output).To(ContainSubstring(fmt.Sprintf("%d=in_progress", status.EpochInProgress)))
+	})
+
+	It("discards the values of nodes and clients which are no longer reported", func() {
+		collector.CollectStatus(&status.StateMachine{
+			NodeBuffers: []*status.NodeBuffer{{ID: 0}, {ID: 1}},
+			ClientWindows: []*status.ClientTracker{
+				{ClientID: 0, HighWatermark: 100},
+				{ClientID: 1, HighWatermark: 100},
+			},
+		})
+		output := exposition()
+		Expect(output).To(ContainSubstring(`mirbft_node_buffer_msgs{node="1"} 0`))
+		Expect(output).To(ContainSubstring(`mirbft_client_window_width{client="1"} 101`))
+
+		// Node 1 and client 1 are removed by a reconfiguration
+		collector.CollectStatus(&status.StateMachine{
+			NodeBuffers: []*status.NodeBuffer{{ID: 0}},
+			ClientWindows: []*status.ClientTracker{
+				{ClientID: 0, HighWatermark: 100},
+			},
+		})
+		output = exposition()
+		Expect(output).To(ContainSubstring(`mirbft_node_buffer_msgs{node="0"} 0`))
+		Expect(output).NotTo(ContainSubstring(`node="1"`))
+		Expect(output).To(ContainSubstring(`mirbft_client_window_width{client="0"} 101`))
+		Expect(output).NotTo(ContainSubstring(`client="1"`))
+	})
+
+	When("messages are buffered from another node", func() {
+		BeforeEach(func() {
+			// A message for a future epoch is buffered
+			err := node.Step(context.Background(), 2, &pb.Msg{
+				Type: &pb.Msg_Prepare{
+					Prepare: &pb.Prepare{
+						SeqNo:  5,
+						Epoch:  5,
+						Digest: []byte("digest"),
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the buffer occupancy", func() {
+			Eventually(func() string {
+				Expect(collector.Collect(context.Background())).To(Succeed())
+				return exposition()
+			}).Should(ContainSubstring(`mirbft_node_buffer_msgs{node="2"} 1`))
+			Expect(exposition()).To(ContainSubstring(`mirbft_node_buffer_msgs{node="1"} 0`))
+		})
+	})
+
+	It("reports the sizes of the actions processed", func() {
+		processor := &fakeProcessor{}
+		instrumented := collector.InstrumentProcessor(processor)
+
+		_, err := instrumented.Process(&mirbft.Actions{
+			Send: []mirbft.Send{{}, {}},
+			Hash: []*mirbft.HashRequest{{}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		stableCheckpoint := uint64(20)
+		_, err = instrumented.Process(&mirbft.Actions{
+			Send:             []mirbft.Send{{}},
+			StableCheckpoint: &stableCheckpoint,
+			StateTransfer:    &mirbft.StateTarget{SeqNo: 40},
+			Misbehaviors:     []*mirbft.Misbehavior{{}, {}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processor.processed).To(Equal(2))
+
+		output := exposition()
+		Expect(output).To(ContainSubstring("mirbft_ready_batches_total 2\n"))
+		Expect(output).To(ContainSubstring(`mirbft_ready_actions_total{type="send"} 3`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_actions_total{type="hash"} 1`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_batch_actions{type="send"} 1`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_batch_actions{type="hash"} 0`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_actions_total{type="stable_checkpoint"} 1`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_actions_total{type="state_transfer"} 1`))
+		Expect(output).To(ContainSubstring(`mirbft_ready_actions_total{type="misbehavior"} 2`))
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrometheusSink is a Sink which retains the most recent value of each metric,
+// and serves them over HTTP in the Prometheus text exposition format.
+type PrometheusSink struct {
+	mutex   sync.Mutex
+	metrics map[string]*metricValues
+}
+
+type metricValues struct {
+	metric *Metric
+	values map[string]float64 // keyed by the formatted labels
+}
+
+func NewPrometheusSink() *PrometheusSink {
+	return &PrometheusSink{
+		metrics: map[string]*metricValues{},
+	}
+}
+
+func (ps *PrometheusSink) Set(metric *Metric, value float64, labelValues ...string) {
+	labels := formatLabels(metric.Labels, labelValues)
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	mv, ok := ps.metrics[metric.Name]
+	if !ok {
+		mv = &metricValues{
+			metric: metric,
+			values: map[string]float64{},
+		}
+		ps.metrics[metric.Name] = mv
+	}
+
+	mv.values[labels] = value
+}
+
+func (ps *PrometheusSink) Delete(metric *Metric, labelValues ...string) {
+	labels := formatLabels(metric.Labels, labelValues)
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	mv, ok := ps.metrics[metric.Name]
+	if !ok {
+		return
+	}
+
+	delete(mv.values, labels)
+}
+
+// formatLabels renders the label names and values in the exposition format,
+// for instance '{bucket="0",state="committed"}'.
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		sb.WriteString(name)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(value))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+// WriteTo writes every metric in the Prometheus text exposition format.  The
+// metrics, and the values of each metric, are written in a stable order.
+func (ps *PrometheusSink) WriteTo(w io.Writer) (int64, error) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	names := make([]string, 0, len(ps.metrics))
+	for name := range ps.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{writer: bufio.NewWriter(w)}
+	for _, name := range names {
+		mv := ps.metrics[name]
+
+		metricType := "gauge"
+		if mv.metric.Type == Counter {
+			metricType = "counter"
+		}
+
+		fmt.Fprintf(cw, "# HELP %s %s\n", name, mv.metric.Help)
+		fmt.Fprintf(cw, "# TYPE %s %s\n", name, metricType)
+
+		labels := make([]string, 0, len(mv.values))
+		for label := range mv.values {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			fmt.Fprintf(cw, "%s%s %s\n", name, label, strconv.FormatFloat(mv.values[label], 'g', -1, 64))
+		}
+	}
+
+	if cw.err != nil {
+		return cw.count, cw.err
+	}
+
+	return cw.count, cw.writer.Flush()
+}
+
+func (ps *PrometheusSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	ps.WriteTo(w)
+}
+
+type countingWriter struct {
+	writer *bufio.Writer
+	count  int64
+	err    error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.writer.Write(p)
+	cw.count += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft/metrics"
+)
+
+var _ = Describe("PrometheusSink", func() {
+	var (
+		sink    *metrics.PrometheusSink
+		labeled = &metrics.Metric{
+			Name:   "test_labeled",
+			Help:   "A labeled test metric.",
+			Type:   metrics.Counter,
+			Labels: []string{"name"},
+		}
+		unlabeled = &metrics.Metric{
+			Name: "test_unlabeled",
+			Help: "An unlabeled test metric.",
+		}
+	)
+
+	BeforeEach(func() {
+		sink = metrics.NewPrometheusSink()
+		sink.Set(unlabeled, 1.5)
+		sink.Set(labeled, 3, "b")
+		sink.Set(labeled, 2, `a "quoted"\ value`)
+		sink.Set(unlabeled, 2.5)
+	})
+
+	It("discards deleted values", func() {
+		sink.Delete(labeled, "b")
+
+		recorder := httptest.NewRecorder()
+		sink.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).NotTo(ContainSubstring(`test_labeled{name="b"}`))
+		Expect(string(body)).To(ContainSubstring(`test_labeled{name="a \"quoted\"\\ value"} 2`))
+	})
+
+	It("serves the latest values in the text exposition format", func() {
+		recorder := httptest.NewRecorder()
+		sink.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		Expect(recorder.Code).To(Equal(200))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4; charset=utf-8"))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal(`# HELP test_labeled A labeled test metric.
+# TYPE test_labeled counter
+test_labeled{name="a \"quoted\"\\ value"} 2
+test_labeled{name="b"} 3
+# HELP test_unlabeled An unlabeled test metric.
+# TYPE test_unlabeled gauge
+test_unlabeled 2.5
+`))
+	})
+})
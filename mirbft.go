@@ -16,6 +16,7 @@ package mirbft
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
 	"github.com/IBM/mirbft/status"
@@ -24,6 +25,28 @@ import (
 
 var ErrStopped = fmt.Errorf("stopped at caller request")
 
+// ErrRemoved is the exit error of a node which has been removed from the
+// network configuration.
+var ErrRemoved = fmt.Errorf("removed from the network configuration")
+
+// ErrClientRemoved is returned by a ClientProposer whose client has been
+// removed from the network state.
+var ErrClientRemoved = fmt.Errorf("client removed from the network state")
+
+// RequestsDroppedError is returned by a ClientProposer when a ClientWidth
+// reconfiguration shrank the client's window below requests the proposer
+// had already accepted.  The state machine discarded these requests, so they
+// must be proposed again once the window allows.  The request passed to the
+// Propose call which returns this error was not accepted either.
+type RequestsDroppedError struct {
+	ClientID uint64
+	ReqNos   []uint64
+}
+
+func (e *RequestsDroppedError) Error() string {
+	return fmt.Sprintf("client %d window shrank, dropping accepted requests %v", e.ClientID, e.ReqNos)
+}
+
 // WALStorage gives the state machine access to the most recently persisted state as
 // requested by a previous instance of the state machine.
 type WALStorage interface {
@@ -35,10 +58,10 @@ type WALStorage interface {
 
 type RequestStorage interface {
 	// Uncommitted must invoke forEach on each uncommitted entry in the
-	// request store.  These requests must have been safely committed
-	// to the request store before the actions which contained them
-	// send the corresponding RequestAck.
-	Uncommitted(forEach func(*pb.RequestAck)) error
+	// request store, along with the size in bytes of the request data.
+	// These requests must have been safely committed to the request store
+	// before the actions which contained them send the corresponding RequestAck.
+	Uncommitted(forEach func(ack *pb.RequestAck, size int)) error
 }
 
 // Node is the local instance of the MirBFT state machine through which the calling application
@@ -54,11 +77,24 @@ type ClientProposer struct {
 	blocking     bool
 	clientID     uint64
 	clientWaiter *clientWaiter
+	accepted     map[uint64]struct{} // request numbers accepted within the watermarks
 	s            *serializer
 }
 
+// Propose submits a request to the state machine once it falls within the
+// client's window.  An accepted request is eventually committed, unless a
+// ClientWidth reconfiguration shrinks the window below it, in which case a
+// later Propose returns a RequestsDroppedError.
 // TODO, change requestData to bytes
 func (cp *ClientProposer) Propose(ctx context.Context, requestData *pb.Request) error {
+	select {
+	case <-cp.clientWaiter.expired:
+		if err := cp.refreshClientWaiter(ctx); err != nil {
+			return err
+		}
+	default:
+	}
+
 	for {
 		if requestData.ReqNo < cp.clientWaiter.lowWatermark {
 			return errors.Errorf("request %d below watermarks, lowWatermark=%d", requestData.ReqNo, cp.clientWaiter.lowWatermark)
@@ -88,24 +124,8 @@ func (cp *ClientProposer) Propose(ctx context.Context, requestData *pb.Request)
 			}
 		}
 
-		replyC := make(chan *clientWaiter, 1)
-		select {
-		case cp.s.clientC <- &clientReq{
-			clientID: requestData.ClientId,
-			replyC:   replyC,
-		}:
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-cp.s.errC:
-			return cp.s.getExitErr()
-		}
-
-		select {
-		case cp.clientWaiter = <-replyC:
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-cp.s.errC:
-			return cp.s.getExitErr()
+		if err := cp.refreshClientWaiter(ctx); err != nil {
+			return err
 		}
 	}
 
@@ -113,6 +133,7 @@ func (cp *ClientProposer) Propose(ctx context.Context, requestData *pb.Request)
 	case cp.s.propC <- &pb.StateEvent_Proposal{
 		Request: requestData,
 	}:
+		cp.accepted[requestData.ReqNo] = struct{}{}
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -121,6 +142,66 @@ func (cp *ClientProposer) Propose(ctx context.Context, requestData *pb.Request)
 	}
 }
 
+// refreshClientWaiter replaces an expired client waiter with the current one.
+// Accepted requests below the new watermarks have committed and are forgotten,
+// while accepted requests above the new watermarks were dropped because the
+// client window shrank, and are reported in a RequestsDroppedError.
+func (cp *ClientProposer) refreshClientWaiter(ctx context.Context) error {
+	if cp.clientWaiter.removed {
+		return ErrClientRemoved
+	}
+
+	replyC := make(chan *clientWaiter, 1)
+	select {
+	case cp.s.clientC <- &clientReq{
+		clientID: cp.clientID,
+		replyC:   replyC,
+	}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cp.s.errC:
+		return cp.s.getExitErr()
+	}
+
+	var cw *clientWaiter
+	select {
+	case cw = <-replyC:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cp.s.errC:
+		return cp.s.getExitErr()
+	}
+
+	if cw == nil {
+		return ErrClientRemoved
+	}
+	cp.clientWaiter = cw
+
+	var dropped []uint64
+	for reqNo := range cp.accepted {
+		switch {
+		case reqNo < cw.lowWatermark:
+			delete(cp.accepted, reqNo)
+		case reqNo > cw.highWatermark:
+			delete(cp.accepted, reqNo)
+			dropped = append(dropped, reqNo)
+		}
+	}
+
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	sort.Slice(dropped, func(i, j int) bool {
+		return dropped[i] < dropped[j]
+	})
+
+	return &RequestsDroppedError{
+		ClientID: cp.clientID,
+		ReqNos:   dropped,
+	}
+}
+
 // TODO, maybe get rid of the varargs and go with a simple count?
 func StandardInitialNetworkState(nodeCount int, clientIDs ...uint64) *pb.NetworkState {
 	nodes := []uint64{}
@@ -155,20 +236,25 @@ func StandardInitialNetworkState(nodeCount int, clientIDs ...uint64) *pb.Network
 
 type dummyReqStore struct{}
 
-func (dummyReqStore) Uncommitted(forEach func(*pb.RequestAck)) error {
+func (dummyReqStore) Uncommitted(forEach func(*pb.RequestAck, int)) error {
 	return nil
 }
 
 type dummyWAL struct {
+	initialSeqNo           uint64
 	initialNetworkState    *pb.NetworkState
 	initialCheckpointValue []byte
+
+	// transfer indicates that the application does not yet have the
+	// initial state, and must state transfer to it.
+	transfer bool
 }
 
 func (dw *dummyWAL) LoadAll(forEach func(uint64, *pb.Persistent)) error {
 	forEach(1, &pb.Persistent{
 		Type: &pb.Persistent_CEntry{
 			CEntry: &pb.CEntry{
-				SeqNo:           0,
+				SeqNo:           dw.initialSeqNo,
 				CheckpointValue: dw.initialCheckpointValue,
 				NetworkState:    dw.initialNetworkState,
 			},
@@ -186,6 +272,17 @@ func (dw *dummyWAL) LoadAll(forEach func(uint64, *pb.Persistent)) error {
 		},
 	})
 
+	if dw.transfer {
+		forEach(3, &pb.Persistent{
+			Type: &pb.Persistent_TEntry{
+				TEntry: &pb.TEntry{
+					SeqNo: dw.initialSeqNo,
+					Value: dw.initialCheckpointValue,
+				},
+			},
+		})
+	}
+
 	return nil
 }
 
@@ -208,6 +305,36 @@ func StartNewNode(
 	)
 }
 
+// JoinNetwork creates a node to join an established network.  The bootstrapCheckpoint
+// must be a stable checkpoint of the network, including its network state.  The first
+// actions returned by the node will be to persist the bootstrap checkpoint to the WAL,
+// so that on subsequent starts RestartNode should be invoked instead, and to state
+// transfer to the bootstrap checkpoint.  Until the node's ID appears in the network
+// configuration, the node only observes the network, transferring to newer checkpoints
+// as it learns of them.  So, unless the bootstrap network configuration already includes
+// the node, it must be started before the reconfiguration which adds it is applied.
+// Once added, the node participates as any other, and once removed, it exits with
+// ErrRemoved.
+func JoinNetwork(
+	config *Config,
+	bootstrapCheckpoint *pb.CEntry,
+) (*Node, error) {
+	if bootstrapCheckpoint.NetworkState == nil {
+		return nil, errors.Errorf("bootstrap checkpoint must include the network state")
+	}
+
+	return RestartNode(
+		config,
+		&dummyWAL{
+			initialSeqNo:           bootstrapCheckpoint.SeqNo,
+			initialNetworkState:    bootstrapCheckpoint.NetworkState,
+			initialCheckpointValue: bootstrapCheckpoint.CheckpointValue,
+			transfer:               true,
+		},
+		dummyReqStore{},
+	)
+}
+
 // RestartNode should be invoked for any subsequent starts of the Node.  It reads
 // the supplied WAL and Request store to initialize the state machine and therefore
 // does not require network parameters as they are embedded into the WAL.
@@ -244,7 +371,18 @@ func WaitForRoom(shouldBlock bool) ClientProposerOption {
 
 // Stop terminates the resources associated with the node
 func (n *Node) Stop() {
-	n.s.stop()
+	n.s.stop(ErrStopped)
+}
+
+// StopWithError terminates the resources associated with the node, like Stop,
+// but records the supplied error as the exit error of the node.  This error is
+// subsequently returned by Status, and by any other method which fails because
+// the node has exited.  It is intended to be called when processing the actions
+// fails, for instance because the disk is full, so that the cause of the failure
+// may be reported by whatever observes Err().  If the node has already exited,
+// the original exit error is retained.
+func (n *Node) StopWithError(err error) {
+	n.s.stop(err)
 }
 
 // ClientProposer returns a new ClientProposer for a given clientID.  It is the caller's
@@ -290,6 +428,7 @@ func (n *Node) ClientProposer(ctx context.Context, clientID uint64, options ...C
 		blocking:     blocking,
 		clientID:     clientID,
 		clientWaiter: cw,
+		accepted:     map[uint64]struct{}{},
 		s:            n.s,
 	}, nil
 }
@@ -365,7 +504,8 @@ func (n *Node) Ready() <-chan Actions {
 // the library will close this channel, and set an exit status.  The consumer may
 // wish to call Status() to get the cause of the exit, and a best effort exit status.
 // If the exit was caused gracefully (by closing the done channel), then ErrStopped
-// is returned.
+// is returned.  If the exit was caused by StopWithError, then the supplied error is
+// returned.
 func (n *Node) Err() <-chan struct{} {
 	return n.s.errC
 }
@@ -395,9 +535,12 @@ func (n *Node) AddResults(results ActionResults) error {
 	}
 
 	for i, hashResult := range results.Digests {
-		// TODO, I really don't like this... but it should be safe
-		hashResult.Request.Origin.Digest = hashResult.Digest
-		stateEventResults.Digests[i] = hashResult.Request.Origin
+		// The origin is not modified, as the actions which carried it
+		// may still be referenced, for instance by an actions interceptor.
+		stateEventResults.Digests[i] = &pb.HashResult{
+			Digest: hashResult.Digest,
+			Type:   hashResult.Request.Origin.Type,
+		}
 	}
 
 	for i, cr := range results.Checkpoints {
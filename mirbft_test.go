@@ -2,6 +2,7 @@ package mirbft_test
 
 import (
 	"compress/gzip"
+	"crypto/ed25519"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -10,7 +11,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/IBM/mirbft"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
 	. "github.com/IBM/mirbft/testengine"
+
+	"google.golang.org/protobuf/proto"
 )
 
 var _ = Describe("Mirbft", func() {
@@ -133,6 +139,124 @@ var _ = Describe("Mirbft", func() {
 		})
 	})
 
+	When("the primary of the first epoch is silenced", func() {
+		BeforeEach(func() {
+			recorder.Mangler = For(MatchMsgs().FromNodes(1)).Drop()
+			for _, clientConfig := range recorder.ClientConfigs {
+				clientConfig.Total = 20
+			}
+		})
+
+		It("removes it from the leader set and still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range recording.Nodes {
+				status := node.PlaybackNode.StateMachine.Status()
+				if status.NodeID == 1 {
+					continue
+				}
+				Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">", uint64(1)))
+				Expect(status.EpochTracker.Leaders).NotTo(BeEmpty())
+				Expect(status.EpochTracker.Leaders).NotTo(ContainElement(uint64(1)))
+			}
+		})
+	})
+
+	When("a node crashes after suspecting the epoch", func() {
+		BeforeEach(func() {
+			recorder.Mangler = ChainMangler{
+				For(MatchMsgs().FromNodes(1)).Drop(),
+				Once(MatchMsgs().FromNode(2).OfTypeSuspect()).CrashAndRestartAfter(10, recorder.RecorderNodeConfigs[2].InitParms),
+			}
+			for _, clientConfig := range recorder.ClientConfigs {
+				clientConfig.Total = 20
+			}
+		})
+
+		It("resumes suspecting the epoch and still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := recording.Nodes[2].PlaybackNode.StateMachine.Status()
+			Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">", uint64(1)))
+
+			suspects := map[uint64]int{}
+			recording.Nodes[2].WAL.LoadAll(func(_ uint64, p *pb.Persistent) {
+				if suspect, ok := p.Type.(*pb.Persistent_Suspect); ok {
+					suspects[suspect.Suspect.Epoch]++
+				}
+			})
+			for epoch, count := range suspects {
+				Expect(count).To(Equal(1), "epoch %d suspected more than once", epoch)
+			}
+		})
+	})
+
+	When("a node crashes during an active epoch", func() {
+		BeforeEach(func() {
+			// The new epoch is persisted just before the echo is sent,
+			// so the node restarts into the epoch before it has
+			// preprepared anything, and may resume it immediately.
+			recorder.Mangler = Once(MatchMsgs().FromNode(0).OfTypeNewEpochEcho()).CrashAndRestartAfter(10, recorder.RecorderNodeConfigs[0].InitParms)
+			for _, clientConfig := range recorder.ClientConfigs {
+				clientConfig.Total = 20
+			}
+		})
+
+		It("resumes the epoch and still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := recording.Nodes[0].PlaybackNode.StateMachine.Status()
+			Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">=", uint64(1)))
+		})
+
+		It("releases the messages buffered for the epochs which were abandoned", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range recording.Nodes {
+				status := node.PlaybackNode.StateMachine.Status()
+				for _, nodeBuffer := range status.NodeBuffers {
+					Expect(nodeBuffer.Msgs).To(Equal(0), "node %d buffers messages from node %d", status.NodeID, nodeBuffer.ID)
+					Expect(nodeBuffer.Size).To(Equal(0))
+				}
+			}
+		})
+	})
+
+	When("a node crashes after suspecting the epoch change", func() {
+		BeforeEach(func() {
+			recorder.Mangler = ChainMangler{
+				For(MatchMsgs().FromNodes(1)).Drop(),
+				Until(MatchMsgs().FromNode(3).OfTypeSuspect().WithEpoch(2)).Do(
+					For(MatchMsgs().FromNodes(2).OfTypeNewEpoch()).Drop(),
+				),
+				Once(MatchMsgs().FromNode(3).OfTypeSuspect().WithEpoch(2)).CrashAndRestartAfter(10, recorder.RecorderNodeConfigs[3].InitParms),
+			}
+			for _, clientConfig := range recorder.ClientConfigs {
+				clientConfig.Total = 20
+			}
+		})
+
+		It("resumes suspecting the epoch change and still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := recording.Nodes[3].PlaybackNode.StateMachine.Status()
+			Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">", uint64(1)))
+
+			suspects := 0
+			recording.Nodes[3].WAL.LoadAll(func(_ uint64, p *pb.Persistent) {
+				if suspect, ok := p.Type.(*pb.Persistent_Suspect); ok && suspect.Suspect.Epoch == 2 {
+					suspects++
+				}
+			})
+			Expect(suspects).To(Equal(1))
+		})
+	})
+
 	When("the third node is silenced", func() {
 		BeforeEach(func() {
 			recorder.Mangler = For(MatchMsgs().FromNodes(3)).Drop()
@@ -147,6 +271,35 @@ var _ = Describe("Mirbft", func() {
 		})
 	})
 
+	When("a node computes a divergent checkpoint", func() {
+		BeforeEach(func() {
+			recorder.RecorderNodeConfigs[3].InitParms.TransferOnCheckpointDivergence = true
+			recorder.RecorderNodeConfigs[3].DivergentCheckpoints = []uint64{40}
+		})
+
+		It("reports the divergence, transfers to the network checkpoint, and still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			misbehaviors := recording.Nodes[3].Misbehaviors
+			Expect(misbehaviors).To(HaveLen(1))
+			Expect(misbehaviors[0].Node).To(Equal(uint64(3)))
+			Expect(misbehaviors[0].Kind).To(Equal(mirbft.DivergentCheckpoint))
+			Expect(misbehaviors[0].Msgs).To(HaveLen(2))
+
+			localCheckpoint := misbehaviors[0].Msgs[0].Type.(*pb.Msg_Checkpoint).Checkpoint
+			networkCheckpoint := misbehaviors[0].Msgs[1].Type.(*pb.Msg_Checkpoint).Checkpoint
+			Expect(localCheckpoint.SeqNo).To(Equal(uint64(40)))
+			Expect(networkCheckpoint.SeqNo).To(Equal(uint64(40)))
+			Expect(localCheckpoint.Value).NotTo(Equal(networkCheckpoint.Value))
+
+			transferred := recording.Nodes[3].State.CheckpointsBySeqNo[40].Value.(*pb.CheckpointResult)
+			expected := recording.Nodes[0].State.CheckpointsBySeqNo[40].Value.(*pb.CheckpointResult)
+			Expect(transferred.Value).To(Equal(networkCheckpoint.Value))
+			Expect(expected.Value).To(Equal(networkCheckpoint.Value))
+		})
+	})
+
 	When("the third node starts late", func() {
 		BeforeEach(func() {
 			recorder.Mangler = Until(MatchMsgs().FromNode(1).OfTypeCheckpoint().WithSequence(20)).Do(For(MatchNodeStartup().ForNode(3)).Delay(500))
@@ -161,6 +314,266 @@ var _ = Describe("Mirbft", func() {
 		})
 	})
 
+	When("the network is reconfigured", func() {
+		var newConfig *pb.NetworkState_Config
+
+		BeforeEach(func() {
+			newConfig = proto.Clone(recorder.NetworkState.Config).(*pb.NetworkState_Config)
+			newConfig.NumberOfBuckets = 2
+			recorder.ReconfigPoints = []*ReconfigPoint{
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_NewConfig{
+							NewConfig: newConfig,
+						},
+					},
+				},
+			}
+		})
+
+		It("delivers all requests under the new configuration", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range recording.Nodes {
+				Expect(proto.Equal(node.State.LastCheckpoint().NetworkState.Config, newConfig)).To(BeTrue())
+				status := node.PlaybackNode.StateMachine.Status()
+				Expect(status.Buckets).To(HaveLen(2))
+			}
+		})
+
+		When("a leader fails before the reconfiguration is stable", func() {
+			BeforeEach(func() {
+				// Commit the reconfiguration in the second checkpoint window, so that
+				// the window which applies it is preprepared only after node 1 fails.
+				recorder.ReconfigPoints[0].ReqNo = 30
+				recorder.Mangler = After(MatchMsgs().FromNode(1).OfTypeCheckpoint().WithSequence(20)).Do(For(MatchMsgs().FromNode(1)).Drop())
+			})
+
+			It("still delivers all requests under the new configuration", func() {
+				_, err := recording.DrainClients(50000)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, node := range recording.Nodes {
+					Expect(proto.Equal(node.State.LastCheckpoint().NetworkState.Config, newConfig)).To(BeTrue())
+				}
+			})
+		})
+
+		When("a node changes epochs before it sees the reconfiguration become stable", func() {
+			BeforeEach(func() {
+				// Node 0 never learns that the checkpoint which applies the
+				// reconfiguration is stable, and the first sequence after it
+				// never commits, so the epoch change which follows carries
+				// final preprepares beyond node 0's stop sequence.
+				recorder.Mangler = ChainMangler{
+					For(MatchMsgs().ToNode(0).OfTypeCheckpoint().WithSequence(40)).Drop(),
+					Until(MatchMsgs().OfTypeNewEpoch().WithEpoch(3)).Do(For(MatchMsgs().OfTypeCommit().WithSequence(41)).Drop()),
+				}
+			})
+
+			It("reinitializes under the new configuration", func() {
+				_, err := recording.DrainClients(50000)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, node := range recording.Nodes {
+					Expect(proto.Equal(node.State.LastCheckpoint().NetworkState.Config, newConfig)).To(BeTrue())
+					status := node.PlaybackNode.StateMachine.Status()
+					Expect(status.Buckets).To(HaveLen(2))
+				}
+
+				// Node 0 reinitialized and committed the next window itself,
+				// rather than transferring beyond the reconfiguration
+				checkpoint, ok := recording.Nodes[0].State.CheckpointsBySeqNo[80]
+				Expect(ok).To(BeTrue())
+				Expect(proto.Equal(checkpoint.Value.(*pb.CheckpointResult).NetworkState.Config, newConfig)).To(BeTrue())
+			})
+		})
+	})
+
+	When("the network grows and then shrinks", func() {
+		var initialConfig, grownConfig *pb.NetworkState_Config
+
+		BeforeEach(func() {
+			recorder = BasicRecorder(7, 4, 100)
+			recorder.NetworkState = mirbft.StandardInitialNetworkState(4, 0, 1, 2, 3)
+			initialConfig = recorder.NetworkState.Config
+			grownConfig = mirbft.StandardInitialNetworkState(7).Config
+
+			recorder.ReconfigPoints = []*ReconfigPoint{
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_NewConfig{
+							NewConfig: grownConfig,
+						},
+					},
+				},
+				{
+					ClientID: 1,
+					ReqNo:    50,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_NewConfig{
+							NewConfig: initialConfig,
+						},
+					},
+				},
+			}
+		})
+
+		It("adds the new nodes, and later removes them", func() {
+			_, err := recording.DrainClients(100000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i, node := range recording.Nodes {
+				Expect(proto.Equal(node.State.LastCheckpoint().NetworkState.Config, initialConfig)).To(BeTrue())
+				if i < 4 {
+					continue
+				}
+
+				// The joined nodes committed under the grown configuration until removed
+				Expect(node.State.Checkpoints.Len()).To(BeNumerically(">", 2))
+				status := node.PlaybackNode.StateMachine.Status()
+				Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">", 0))
+			}
+		})
+	})
+
+	When("a node other than the last is removed", func() {
+		var (
+			shrunkConfig *pb.NetworkState_Config
+			suspected    map[uint64]struct{}
+		)
+
+		BeforeEach(func() {
+			recorder = BasicRecorder(5, 4, 100)
+
+			// Record any epochs suspected.  The epochs are short, so that they
+			// end gracefully as they expire, and the primary of every epoch
+			// after the removal must be a remaining node for none to fail.
+			suspected = map[uint64]struct{}{}
+			recorder.Mangler = InlineMangler(func(random int, event *rpb.RecordedEvent) []MangleResult {
+				if suspect := event.StateEvent.GetStep().GetMsg().GetSuspect(); suspect != nil {
+					suspected[suspect.Epoch] = struct{}{}
+				}
+				return []MangleResult{{Event: event}}
+			})
+
+			shrunkConfig = proto.Clone(recorder.NetworkState.Config).(*pb.NetworkState_Config)
+			shrunkConfig.Nodes = []uint64{0, 2, 3, 4}
+			shrunkConfig.MaxEpochLength = uint64(shrunkConfig.CheckpointInterval) * 2
+
+			recorder.ReconfigPoints = []*ReconfigPoint{
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_NewConfig{
+							NewConfig: shrunkConfig,
+						},
+					},
+				},
+			}
+		})
+
+		It("selects primaries among the remaining nodes", func() {
+			_, err := recording.DrainClients(100000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i, node := range recording.Nodes {
+				if i == 1 {
+					continue
+				}
+				Expect(proto.Equal(node.State.LastCheckpoint().NetworkState.Config, shrunkConfig)).To(BeTrue())
+
+				// Beyond epoch 5, whose primary would be node 1 were
+				// primaries selected by ID
+				status := node.PlaybackNode.StateMachine.Status()
+				Expect(status.EpochTracker.LastActiveEpoch).To(BeNumerically(">", 5))
+			}
+
+			Expect(suspected).To(BeEmpty())
+		})
+	})
+
+	When("client widths are changed", func() {
+		BeforeEach(func() {
+			recorder.ReconfigPoints = []*ReconfigPoint{
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_ClientWidth_{
+							ClientWidth: &pb.Reconfiguration_ClientWidth{
+								Id:    1,
+								Width: 200,
+							},
+						},
+					},
+				},
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_ClientWidth_{
+							ClientWidth: &pb.Reconfiguration_ClientWidth{
+								Id:    2,
+								Width: 20,
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("delivers all requests under the new widths", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range recording.Nodes {
+				clients := node.State.LastCheckpoint().NetworkState.Clients
+				Expect(clients).To(HaveLen(4))
+				Expect(clients[1].Width).To(Equal(uint32(200)))
+				Expect(clients[2].Width).To(Equal(uint32(20)))
+			}
+		})
+	})
+
+	When("a client is removed", func() {
+		BeforeEach(func() {
+			recorder.ReconfigPoints = []*ReconfigPoint{
+				{
+					ClientID: 0,
+					ReqNo:    10,
+					Reconfiguration: &pb.Reconfiguration{
+						Type: &pb.Reconfiguration_RemoveClient{
+							RemoveClient: 3,
+						},
+					},
+				},
+			}
+		})
+
+		It("delivers the remaining clients' requests and drops the removed client", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range recording.Nodes {
+				clients := node.State.LastCheckpoint().NetworkState.Clients
+				Expect(clients).To(HaveLen(3))
+				for _, client := range clients {
+					Expect(client.Id).NotTo(Equal(uint64(3)))
+				}
+
+				status := node.PlaybackNode.StateMachine.Status()
+				Expect(status.ClientWindows).To(HaveLen(3))
+			}
+		})
+	})
+
 	When("the network loses 2 percent of messages", func() {
 		BeforeEach(func() {
 			recorder.Mangler = For(MatchMsgs().AtPercent(2)).Drop()
@@ -178,7 +591,11 @@ var _ = Describe("Mirbft", func() {
 			for _, clientConfig := range recorder.ClientConfigs {
 				clientConfig.Total = 20
 			}
-			// TODO, we need to configure a very short ack re-transmit interval
+			for _, nodeConfig := range recorder.RecorderNodeConfigs {
+				nodeConfig.InitParms.AckResendTicks = 2
+				nodeConfig.InitParms.AckResendBackoff = pb.StateEvent_InitialParameters_EXPONENTIAL
+				nodeConfig.InitParms.AckResendMaxTicks = 8
+			}
 		})
 
 		It("still delivers all requests", func() {
@@ -219,4 +636,70 @@ var _ = Describe("Mirbft", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	When("clients sign their requests", func() {
+		BeforeEach(func() {
+			verifier := &mirbft.Ed25519RequestVerifier{
+				PublicKeys: map[uint64]ed25519.PublicKey{},
+			}
+
+			for _, clientConfig := range recorder.ClientConfigs {
+				seed := make([]byte, ed25519.SeedSize)
+				seed[0] = byte(clientConfig.ID)
+				clientConfig.SigningKey = ed25519.NewKeyFromSeed(seed)
+				clientConfig.Total = 20
+				verifier.PublicKeys[clientConfig.ID] = clientConfig.SigningKey.Public().(ed25519.PublicKey)
+			}
+
+			recorder.RequestVerifier = verifier
+		})
+
+		It("still delivers all requests", func() {
+			_, err := recording.DrainClients(50000)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("one client's requests are forged", func() {
+			BeforeEach(func() {
+				seed := make([]byte, ed25519.SeedSize)
+				seed[0] = 0xff
+				recorder.ClientConfigs[3].SigningKey = ed25519.NewKeyFromSeed(seed)
+			})
+
+			It("delivers only the genuine requests", func() {
+				genuineDone := func() bool {
+					for _, node := range recording.Nodes {
+						if node.State.Checkpoints.Len() == 0 {
+							return false
+						}
+						for _, client := range node.State.LastCheckpoint().NetworkState.Clients {
+							if client.Id != 3 && client.LowWatermark != 20 {
+								return false
+							}
+						}
+					}
+					return true
+				}
+
+				for i := 0; !genuineDone(); i++ {
+					Expect(i).To(BeNumerically("<", 50000))
+					err := recording.Step()
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				for _, node := range recording.Nodes {
+					for _, client := range node.State.LastCheckpoint().NetworkState.Clients {
+						if client.Id == 3 {
+							Expect(client.LowWatermark).To(Equal(uint64(0)))
+						}
+					}
+
+					err := node.ReqStore.Uncommitted(func(ack *pb.RequestAck, _ int) {
+						Expect(ack.ClientId).NotTo(Equal(uint64(3)))
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+		})
+	})
 })
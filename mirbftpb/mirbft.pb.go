@@ -30,6 +30,52 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type StateEvent_InitialParameters_Backoff int32
+
+const (
+	StateEvent_InitialParameters_LINEAR      StateEvent_InitialParameters_Backoff = 0
+	StateEvent_InitialParameters_EXPONENTIAL StateEvent_InitialParameters_Backoff = 1
+)
+
+// Enum value maps for StateEvent_InitialParameters_Backoff.
+var (
+	StateEvent_InitialParameters_Backoff_name = map[int32]string{
+		0: "LINEAR",
+		1: "EXPONENTIAL",
+	}
+	StateEvent_InitialParameters_Backoff_value = map[string]int32{
+		"LINEAR":      0,
+		"EXPONENTIAL": 1,
+	}
+)
+
+func (x StateEvent_InitialParameters_Backoff) Enum() *StateEvent_InitialParameters_Backoff {
+	p := new(StateEvent_InitialParameters_Backoff)
+	*p = x
+	return p
+}
+
+func (x StateEvent_InitialParameters_Backoff) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StateEvent_InitialParameters_Backoff) Descriptor() protoreflect.EnumDescriptor {
+	return file_mirbft_proto_enumTypes[0].Descriptor()
+}
+
+func (StateEvent_InitialParameters_Backoff) Type() protoreflect.EnumType {
+	return &file_mirbft_proto_enumTypes[0]
+}
+
+func (x StateEvent_InitialParameters_Backoff) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StateEvent_InitialParameters_Backoff.Descriptor instead.
+func (StateEvent_InitialParameters_Backoff) EnumDescriptor() ([]byte, []int) {
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 0, 0}
+}
+
 // NetworkState contains the configuration agreed to by all nodes in the network
 // as well as the current client statuses.  NetworkState must be reflected in the
 // state digest for checkpoints.  The easiest way to accomplish this is by serializing
@@ -115,6 +161,7 @@ type Reconfiguration struct {
 	//	*Reconfiguration_NewClient_
 	//	*Reconfiguration_RemoveClient
 	//	*Reconfiguration_NewConfig
+	//	*Reconfiguration_ClientWidth_
 	Type isReconfiguration_Type `protobuf_oneof:"type"`
 }
 
@@ -178,6 +225,13 @@ func (x *Reconfiguration) GetNewConfig() *NetworkState_Config {
 	return nil
 }
 
+func (x *Reconfiguration) GetClientWidth() *Reconfiguration_ClientWidth {
+	if x, ok := x.GetType().(*Reconfiguration_ClientWidth_); ok {
+		return x.ClientWidth
+	}
+	return nil
+}
+
 type isReconfiguration_Type interface {
 	isReconfiguration_Type()
 }
@@ -194,12 +248,18 @@ type Reconfiguration_NewConfig struct {
 	NewConfig *NetworkState_Config `protobuf:"bytes,3,opt,name=new_config,json=newConfig,proto3,oneof"`
 }
 
+type Reconfiguration_ClientWidth_ struct {
+	ClientWidth *Reconfiguration_ClientWidth `protobuf:"bytes,4,opt,name=client_width,json=clientWidth,proto3,oneof"`
+}
+
 func (*Reconfiguration_NewClient_) isReconfiguration_Type() {}
 
 func (*Reconfiguration_RemoveClient) isReconfiguration_Type() {}
 
 func (*Reconfiguration_NewConfig) isReconfiguration_Type() {}
 
+func (*Reconfiguration_ClientWidth_) isReconfiguration_Type() {}
+
 // Persistent contains data that should be persited by lib user
 type Persistent struct {
 	state         protoimpl.MessageState
@@ -782,6 +842,8 @@ type Msg struct {
 	//	*Msg_FetchRequest
 	//	*Msg_ForwardRequest
 	//	*Msg_RequestAck
+	//	*Msg_SnapshotRequest
+	//	*Msg_SnapshotChunk
 	Type isMsg_Type `protobuf_oneof:"type"`
 }
 
@@ -929,6 +991,20 @@ func (x *Msg) GetRequestAck() *RequestAck {
 	return nil
 }
 
+func (x *Msg) GetSnapshotRequest() *SnapshotRequest {
+	if x, ok := x.GetType().(*Msg_SnapshotRequest); ok {
+		return x.SnapshotRequest
+	}
+	return nil
+}
+
+func (x *Msg) GetSnapshotChunk() *SnapshotChunk {
+	if x, ok := x.GetType().(*Msg_SnapshotChunk); ok {
+		return x.SnapshotChunk
+	}
+	return nil
+}
+
 type isMsg_Type interface {
 	isMsg_Type()
 }
@@ -993,6 +1069,14 @@ type Msg_RequestAck struct {
 	RequestAck *RequestAck `protobuf:"bytes,15,opt,name=request_ack,json=requestAck,proto3,oneof"`
 }
 
+type Msg_SnapshotRequest struct {
+	SnapshotRequest *SnapshotRequest `protobuf:"bytes,16,opt,name=snapshot_request,json=snapshotRequest,proto3,oneof"`
+}
+
+type Msg_SnapshotChunk struct {
+	SnapshotChunk *SnapshotChunk `protobuf:"bytes,17,opt,name=snapshot_chunk,json=snapshotChunk,proto3,oneof"`
+}
+
 func (*Msg_Preprepare) isMsg_Type() {}
 
 func (*Msg_Prepare) isMsg_Type() {}
@@ -1023,6 +1107,10 @@ func (*Msg_ForwardRequest) isMsg_Type() {}
 
 func (*Msg_RequestAck) isMsg_Type() {}
 
+func (*Msg_SnapshotRequest) isMsg_Type() {}
+
+func (*Msg_SnapshotChunk) isMsg_Type() {}
+
 type FetchBatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1196,6 +1284,171 @@ func (x *ForwardRequest) GetRequestData() []byte {
 	return nil
 }
 
+// SnapshotRequest asks a peer for the portion of its application snapshot
+// for the checkpoint identified by seq_no and value, beginning at offset.
+// It is not consumed by the state machine, but by the statetransfer package.
+type SnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo  uint64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Value  []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mirbft_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mirbft_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_mirbft_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SnapshotRequest) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *SnapshotRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SnapshotRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// SnapshotChunk is sent in response to a SnapshotRequest.  If the peer no
+// longer (or does not yet) have the requested snapshot, unavailable is set
+// and the remaining fields are unset.  The network_state is included only in
+// the chunk at offset zero.
+type SnapshotChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeqNo        uint64        `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Value        []byte        `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Offset       uint64        `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	TotalSize    uint64        `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Data         []byte        `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	NetworkState *NetworkState `protobuf:"bytes,6,opt,name=network_state,json=networkState,proto3" json:"network_state,omitempty"`
+	Unavailable  bool          `protobuf:"varint,7,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+}
+
+func (x *SnapshotChunk) Reset() {
+	*x = SnapshotChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mirbft_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunk) ProtoMessage() {}
+
+func (x *SnapshotChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_mirbft_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunk.ProtoReflect.Descriptor instead.
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return file_mirbft_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SnapshotChunk) GetSeqNo() uint64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SnapshotChunk) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetTotalSize() uint64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SnapshotChunk) GetNetworkState() *NetworkState {
+	if x != nil {
+		return x.NetworkState
+	}
+	return nil
+}
+
+func (x *SnapshotChunk) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
 type Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1209,7 +1462,7 @@ type Request struct {
 func (x *Request) Reset() {
 	*x = Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[14]
+		mi := &file_mirbft_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1222,7 +1475,7 @@ func (x *Request) String() string {
 func (*Request) ProtoMessage() {}
 
 func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[14]
+	mi := &file_mirbft_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1235,7 +1488,7 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request.ProtoReflect.Descriptor instead.
 func (*Request) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{14}
+	return file_mirbft_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Request) GetClientId() uint64 {
@@ -1272,7 +1525,7 @@ type RequestAck struct {
 func (x *RequestAck) Reset() {
 	*x = RequestAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[15]
+		mi := &file_mirbft_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1285,7 +1538,7 @@ func (x *RequestAck) String() string {
 func (*RequestAck) ProtoMessage() {}
 
 func (x *RequestAck) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[15]
+	mi := &file_mirbft_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1551,7 @@ func (x *RequestAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestAck.ProtoReflect.Descriptor instead.
 func (*RequestAck) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{15}
+	return file_mirbft_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RequestAck) GetClientId() uint64 {
@@ -1335,7 +1588,7 @@ type Preprepare struct {
 func (x *Preprepare) Reset() {
 	*x = Preprepare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[16]
+		mi := &file_mirbft_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1348,7 +1601,7 @@ func (x *Preprepare) String() string {
 func (*Preprepare) ProtoMessage() {}
 
 func (x *Preprepare) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[16]
+	mi := &file_mirbft_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1361,7 +1614,7 @@ func (x *Preprepare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Preprepare.ProtoReflect.Descriptor instead.
 func (*Preprepare) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{16}
+	return file_mirbft_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Preprepare) GetSeqNo() uint64 {
@@ -1398,7 +1651,7 @@ type Prepare struct {
 func (x *Prepare) Reset() {
 	*x = Prepare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[17]
+		mi := &file_mirbft_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1411,7 +1664,7 @@ func (x *Prepare) String() string {
 func (*Prepare) ProtoMessage() {}
 
 func (x *Prepare) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[17]
+	mi := &file_mirbft_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,7 +1677,7 @@ func (x *Prepare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Prepare.ProtoReflect.Descriptor instead.
 func (*Prepare) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{17}
+	return file_mirbft_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Prepare) GetSeqNo() uint64 {
@@ -1461,7 +1714,7 @@ type Commit struct {
 func (x *Commit) Reset() {
 	*x = Commit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[18]
+		mi := &file_mirbft_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1474,7 +1727,7 @@ func (x *Commit) String() string {
 func (*Commit) ProtoMessage() {}
 
 func (x *Commit) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[18]
+	mi := &file_mirbft_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,7 +1740,7 @@ func (x *Commit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Commit.ProtoReflect.Descriptor instead.
 func (*Commit) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{18}
+	return file_mirbft_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Commit) GetSeqNo() uint64 {
@@ -1523,7 +1776,7 @@ type Checkpoint struct {
 func (x *Checkpoint) Reset() {
 	*x = Checkpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[19]
+		mi := &file_mirbft_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1536,7 +1789,7 @@ func (x *Checkpoint) String() string {
 func (*Checkpoint) ProtoMessage() {}
 
 func (x *Checkpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[19]
+	mi := &file_mirbft_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1549,7 +1802,7 @@ func (x *Checkpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
 func (*Checkpoint) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{19}
+	return file_mirbft_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Checkpoint) GetSeqNo() uint64 {
@@ -1577,7 +1830,7 @@ type Suspect struct {
 func (x *Suspect) Reset() {
 	*x = Suspect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[20]
+		mi := &file_mirbft_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1590,7 +1843,7 @@ func (x *Suspect) String() string {
 func (*Suspect) ProtoMessage() {}
 
 func (x *Suspect) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[20]
+	mi := &file_mirbft_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1603,7 +1856,7 @@ func (x *Suspect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Suspect.ProtoReflect.Descriptor instead.
 func (*Suspect) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{20}
+	return file_mirbft_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Suspect) GetEpoch() uint64 {
@@ -1638,7 +1891,7 @@ type EpochChange struct {
 func (x *EpochChange) Reset() {
 	*x = EpochChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[21]
+		mi := &file_mirbft_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1651,7 +1904,7 @@ func (x *EpochChange) String() string {
 func (*EpochChange) ProtoMessage() {}
 
 func (x *EpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[21]
+	mi := &file_mirbft_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1664,7 +1917,7 @@ func (x *EpochChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChange.ProtoReflect.Descriptor instead.
 func (*EpochChange) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{21}
+	return file_mirbft_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *EpochChange) GetNewEpoch() uint64 {
@@ -1715,7 +1968,7 @@ type EpochChangeAck struct {
 func (x *EpochChangeAck) Reset() {
 	*x = EpochChangeAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[22]
+		mi := &file_mirbft_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1728,7 +1981,7 @@ func (x *EpochChangeAck) String() string {
 func (*EpochChangeAck) ProtoMessage() {}
 
 func (x *EpochChangeAck) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[22]
+	mi := &file_mirbft_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1741,7 +1994,7 @@ func (x *EpochChangeAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChangeAck.ProtoReflect.Descriptor instead.
 func (*EpochChangeAck) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{22}
+	return file_mirbft_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *EpochChangeAck) GetOriginator() uint64 {
@@ -1772,7 +2025,7 @@ type EpochConfig struct {
 func (x *EpochConfig) Reset() {
 	*x = EpochConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[23]
+		mi := &file_mirbft_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1785,7 +2038,7 @@ func (x *EpochConfig) String() string {
 func (*EpochConfig) ProtoMessage() {}
 
 func (x *EpochConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[23]
+	mi := &file_mirbft_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1798,7 +2051,7 @@ func (x *EpochConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochConfig.ProtoReflect.Descriptor instead.
 func (*EpochConfig) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{23}
+	return file_mirbft_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *EpochConfig) GetNumber() uint64 {
@@ -1839,7 +2092,7 @@ type NewEpochConfig struct {
 func (x *NewEpochConfig) Reset() {
 	*x = NewEpochConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[24]
+		mi := &file_mirbft_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1852,7 +2105,7 @@ func (x *NewEpochConfig) String() string {
 func (*NewEpochConfig) ProtoMessage() {}
 
 func (x *NewEpochConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[24]
+	mi := &file_mirbft_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1865,7 +2118,7 @@ func (x *NewEpochConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpochConfig.ProtoReflect.Descriptor instead.
 func (*NewEpochConfig) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{24}
+	return file_mirbft_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *NewEpochConfig) GetConfig() *EpochConfig {
@@ -1913,7 +2166,7 @@ type NewEpoch struct {
 func (x *NewEpoch) Reset() {
 	*x = NewEpoch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[25]
+		mi := &file_mirbft_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1926,7 +2179,7 @@ func (x *NewEpoch) String() string {
 func (*NewEpoch) ProtoMessage() {}
 
 func (x *NewEpoch) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[25]
+	mi := &file_mirbft_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1939,7 +2192,7 @@ func (x *NewEpoch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpoch.ProtoReflect.Descriptor instead.
 func (*NewEpoch) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{25}
+	return file_mirbft_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *NewEpoch) GetNewConfig() *NewEpochConfig {
@@ -1980,7 +2233,7 @@ type StateEvent struct {
 func (x *StateEvent) Reset() {
 	*x = StateEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[26]
+		mi := &file_mirbft_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1993,7 +2246,7 @@ func (x *StateEvent) String() string {
 func (*StateEvent) ProtoMessage() {}
 
 func (x *StateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[26]
+	mi := &file_mirbft_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2006,7 +2259,7 @@ func (x *StateEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent.ProtoReflect.Descriptor instead.
 func (*StateEvent) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26}
+	return file_mirbft_proto_rawDescGZIP(), []int{28}
 }
 
 func (m *StateEvent) GetType() isStateEvent_Type {
@@ -2168,7 +2421,7 @@ type HashResult struct {
 func (x *HashResult) Reset() {
 	*x = HashResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[27]
+		mi := &file_mirbft_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2181,7 +2434,7 @@ func (x *HashResult) String() string {
 func (*HashResult) ProtoMessage() {}
 
 func (x *HashResult) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[27]
+	mi := &file_mirbft_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2194,7 +2447,7 @@ func (x *HashResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult.ProtoReflect.Descriptor instead.
 func (*HashResult) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27}
+	return file_mirbft_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *HashResult) GetDigest() []byte {
@@ -2294,7 +2547,7 @@ type CheckpointResult struct {
 func (x *CheckpointResult) Reset() {
 	*x = CheckpointResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[28]
+		mi := &file_mirbft_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2307,7 +2560,7 @@ func (x *CheckpointResult) String() string {
 func (*CheckpointResult) ProtoMessage() {}
 
 func (x *CheckpointResult) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[28]
+	mi := &file_mirbft_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2320,7 +2573,7 @@ func (x *CheckpointResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckpointResult.ProtoReflect.Descriptor instead.
 func (*CheckpointResult) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{28}
+	return file_mirbft_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CheckpointResult) GetSeqNo() uint64 {
@@ -2410,7 +2663,7 @@ type NetworkState_Config struct {
 func (x *NetworkState_Config) Reset() {
 	*x = NetworkState_Config{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[29]
+		mi := &file_mirbft_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2423,7 +2676,7 @@ func (x *NetworkState_Config) String() string {
 func (*NetworkState_Config) ProtoMessage() {}
 
 func (x *NetworkState_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[29]
+	mi := &file_mirbft_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2506,7 +2759,7 @@ type NetworkState_Client struct {
 func (x *NetworkState_Client) Reset() {
 	*x = NetworkState_Client{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[30]
+		mi := &file_mirbft_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2519,7 +2772,7 @@ func (x *NetworkState_Client) String() string {
 func (*NetworkState_Client) ProtoMessage() {}
 
 func (x *NetworkState_Client) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[30]
+	mi := &file_mirbft_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2582,7 +2835,7 @@ type Reconfiguration_NewClient struct {
 func (x *Reconfiguration_NewClient) Reset() {
 	*x = Reconfiguration_NewClient{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[31]
+		mi := &file_mirbft_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2595,7 +2848,7 @@ func (x *Reconfiguration_NewClient) String() string {
 func (*Reconfiguration_NewClient) ProtoMessage() {}
 
 func (x *Reconfiguration_NewClient) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[31]
+	mi := &file_mirbft_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2625,6 +2878,69 @@ func (x *Reconfiguration_NewClient) GetWidth() uint32 {
 	return 0
 }
 
+// ClientWidth changes the width of a client's request window.  The window
+// never shrinks below the client's highest committed request.  Note that
+// shrinking the window weakens what a ClientProposer promises: requests
+// it accepted are ordinarily eventually committed, but any uncommitted
+// requests above the shrunken window are discarded on every node, even
+// if they were already accepted and acked.  Each ClientProposer reports
+// the requests it accepted which were discarded in a RequestsDroppedError,
+// and they must be proposed again once the window allows.
+type Reconfiguration_ClientWidth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Width uint32 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+}
+
+func (x *Reconfiguration_ClientWidth) Reset() {
+	*x = Reconfiguration_ClientWidth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mirbft_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reconfiguration_ClientWidth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconfiguration_ClientWidth) ProtoMessage() {}
+
+func (x *Reconfiguration_ClientWidth) ProtoReflect() protoreflect.Message {
+	mi := &file_mirbft_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconfiguration_ClientWidth.ProtoReflect.Descriptor instead.
+func (*Reconfiguration_ClientWidth) Descriptor() ([]byte, []int) {
+	return file_mirbft_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Reconfiguration_ClientWidth) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reconfiguration_ClientWidth) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
 type EpochChange_SetEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2638,7 +2954,7 @@ type EpochChange_SetEntry struct {
 func (x *EpochChange_SetEntry) Reset() {
 	*x = EpochChange_SetEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[32]
+		mi := &file_mirbft_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2651,7 +2967,7 @@ func (x *EpochChange_SetEntry) String() string {
 func (*EpochChange_SetEntry) ProtoMessage() {}
 
 func (x *EpochChange_SetEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[32]
+	mi := &file_mirbft_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2664,7 +2980,7 @@ func (x *EpochChange_SetEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpochChange_SetEntry.ProtoReflect.Descriptor instead.
 func (*EpochChange_SetEntry) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{21, 0}
+	return file_mirbft_proto_rawDescGZIP(), []int{23, 0}
 }
 
 func (x *EpochChange_SetEntry) GetEpoch() uint64 {
@@ -2700,7 +3016,7 @@ type NewEpoch_RemoteEpochChange struct {
 func (x *NewEpoch_RemoteEpochChange) Reset() {
 	*x = NewEpoch_RemoteEpochChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[33]
+		mi := &file_mirbft_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2713,7 +3029,7 @@ func (x *NewEpoch_RemoteEpochChange) String() string {
 func (*NewEpoch_RemoteEpochChange) ProtoMessage() {}
 
 func (x *NewEpoch_RemoteEpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[33]
+	mi := &file_mirbft_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2726,7 +3042,7 @@ func (x *NewEpoch_RemoteEpochChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewEpoch_RemoteEpochChange.ProtoReflect.Descriptor instead.
 func (*NewEpoch_RemoteEpochChange) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{25, 0}
+	return file_mirbft_proto_rawDescGZIP(), []int{27, 0}
 }
 
 func (x *NewEpoch_RemoteEpochChange) GetNodeId() uint64 {
@@ -2748,18 +3064,29 @@ type StateEvent_InitialParameters struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                   uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	BatchSize            uint32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
-	HeartbeatTicks       uint32 `protobuf:"varint,3,opt,name=heartbeat_ticks,json=heartbeatTicks,proto3" json:"heartbeat_ticks,omitempty"`
-	SuspectTicks         uint32 `protobuf:"varint,4,opt,name=suspect_ticks,json=suspectTicks,proto3" json:"suspect_ticks,omitempty"`
-	NewEpochTimeoutTicks uint32 `protobuf:"varint,5,opt,name=new_epoch_timeout_ticks,json=newEpochTimeoutTicks,proto3" json:"new_epoch_timeout_ticks,omitempty"`
-	BufferSize           uint32 `protobuf:"varint,6,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	Id                             uint64                               `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BatchSize                      uint32                               `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	HeartbeatTicks                 uint32                               `protobuf:"varint,3,opt,name=heartbeat_ticks,json=heartbeatTicks,proto3" json:"heartbeat_ticks,omitempty"`
+	SuspectTicks                   uint32                               `protobuf:"varint,4,opt,name=suspect_ticks,json=suspectTicks,proto3" json:"suspect_ticks,omitempty"`
+	NewEpochTimeoutTicks           uint32                               `protobuf:"varint,5,opt,name=new_epoch_timeout_ticks,json=newEpochTimeoutTicks,proto3" json:"new_epoch_timeout_ticks,omitempty"`
+	BufferSize                     uint32                               `protobuf:"varint,6,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	MaxBatchBytes                  uint32                               `protobuf:"varint,7,opt,name=max_batch_bytes,json=maxBatchBytes,proto3" json:"max_batch_bytes,omitempty"`
+	MinBatchSize                   uint32                               `protobuf:"varint,8,opt,name=min_batch_size,json=minBatchSize,proto3" json:"min_batch_size,omitempty"`
+	BatchTimeoutTicks              uint32                               `protobuf:"varint,9,opt,name=batch_timeout_ticks,json=batchTimeoutTicks,proto3" json:"batch_timeout_ticks,omitempty"`
+	AdaptiveBatchSize              bool                                 `protobuf:"varint,10,opt,name=adaptive_batch_size,json=adaptiveBatchSize,proto3" json:"adaptive_batch_size,omitempty"`
+	CorrectFetchTicks              uint32                               `protobuf:"varint,11,opt,name=correct_fetch_ticks,json=correctFetchTicks,proto3" json:"correct_fetch_ticks,omitempty"`
+	FetchTimeoutTicks              uint32                               `protobuf:"varint,12,opt,name=fetch_timeout_ticks,json=fetchTimeoutTicks,proto3" json:"fetch_timeout_ticks,omitempty"`
+	AckResendTicks                 uint32                               `protobuf:"varint,13,opt,name=ack_resend_ticks,json=ackResendTicks,proto3" json:"ack_resend_ticks,omitempty"`
+	AckResendBackoff               StateEvent_InitialParameters_Backoff `protobuf:"varint,14,opt,name=ack_resend_backoff,json=ackResendBackoff,proto3,enum=mirbftpb.StateEvent_InitialParameters_Backoff" json:"ack_resend_backoff,omitempty"`
+	AckResendMaxTicks              uint32                               `protobuf:"varint,15,opt,name=ack_resend_max_ticks,json=ackResendMaxTicks,proto3" json:"ack_resend_max_ticks,omitempty"`
+	TransferOnCheckpointDivergence bool                                 `protobuf:"varint,16,opt,name=transfer_on_checkpoint_divergence,json=transferOnCheckpointDivergence,proto3" json:"transfer_on_checkpoint_divergence,omitempty"`
+	TransferRetryTicks             uint32                               `protobuf:"varint,17,opt,name=transfer_retry_ticks,json=transferRetryTicks,proto3" json:"transfer_retry_ticks,omitempty"`
 }
 
 func (x *StateEvent_InitialParameters) Reset() {
 	*x = StateEvent_InitialParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[34]
+		mi := &file_mirbft_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2772,7 +3099,7 @@ func (x *StateEvent_InitialParameters) String() string {
 func (*StateEvent_InitialParameters) ProtoMessage() {}
 
 func (x *StateEvent_InitialParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[34]
+	mi := &file_mirbft_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2785,7 +3112,7 @@ func (x *StateEvent_InitialParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_InitialParameters.ProtoReflect.Descriptor instead.
 func (*StateEvent_InitialParameters) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 0}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 0}
 }
 
 func (x *StateEvent_InitialParameters) GetId() uint64 {
@@ -2830,6 +3157,83 @@ func (x *StateEvent_InitialParameters) GetBufferSize() uint32 {
 	return 0
 }
 
+func (x *StateEvent_InitialParameters) GetMaxBatchBytes() uint32 {
+	if x != nil {
+		return x.MaxBatchBytes
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetMinBatchSize() uint32 {
+	if x != nil {
+		return x.MinBatchSize
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetBatchTimeoutTicks() uint32 {
+	if x != nil {
+		return x.BatchTimeoutTicks
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetAdaptiveBatchSize() bool {
+	if x != nil {
+		return x.AdaptiveBatchSize
+	}
+	return false
+}
+
+func (x *StateEvent_InitialParameters) GetCorrectFetchTicks() uint32 {
+	if x != nil {
+		return x.CorrectFetchTicks
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetFetchTimeoutTicks() uint32 {
+	if x != nil {
+		return x.FetchTimeoutTicks
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetAckResendTicks() uint32 {
+	if x != nil {
+		return x.AckResendTicks
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetAckResendBackoff() StateEvent_InitialParameters_Backoff {
+	if x != nil {
+		return x.AckResendBackoff
+	}
+	return StateEvent_InitialParameters_LINEAR
+}
+
+func (x *StateEvent_InitialParameters) GetAckResendMaxTicks() uint32 {
+	if x != nil {
+		return x.AckResendMaxTicks
+	}
+	return 0
+}
+
+func (x *StateEvent_InitialParameters) GetTransferOnCheckpointDivergence() bool {
+	if x != nil {
+		return x.TransferOnCheckpointDivergence
+	}
+	return false
+}
+
+func (x *StateEvent_InitialParameters) GetTransferRetryTicks() uint32 {
+	if x != nil {
+		return x.TransferRetryTicks
+	}
+	return 0
+}
+
 type StateEvent_PersistedEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2842,7 +3246,7 @@ type StateEvent_PersistedEntry struct {
 func (x *StateEvent_PersistedEntry) Reset() {
 	*x = StateEvent_PersistedEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[35]
+		mi := &file_mirbft_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2855,7 +3259,7 @@ func (x *StateEvent_PersistedEntry) String() string {
 func (*StateEvent_PersistedEntry) ProtoMessage() {}
 
 func (x *StateEvent_PersistedEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[35]
+	mi := &file_mirbft_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2868,7 +3272,7 @@ func (x *StateEvent_PersistedEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_PersistedEntry.ProtoReflect.Descriptor instead.
 func (*StateEvent_PersistedEntry) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 1}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 1}
 }
 
 func (x *StateEvent_PersistedEntry) GetIndex() uint64 {
@@ -2891,12 +3295,13 @@ type StateEvent_OutstandingRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	RequestAck *RequestAck `protobuf:"bytes,1,opt,name=request_ack,json=requestAck,proto3" json:"request_ack,omitempty"`
+	Size       uint64      `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // the size in bytes of the request data
 }
 
 func (x *StateEvent_OutstandingRequest) Reset() {
 	*x = StateEvent_OutstandingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[36]
+		mi := &file_mirbft_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2909,7 +3314,7 @@ func (x *StateEvent_OutstandingRequest) String() string {
 func (*StateEvent_OutstandingRequest) ProtoMessage() {}
 
 func (x *StateEvent_OutstandingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[36]
+	mi := &file_mirbft_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2922,7 +3327,7 @@ func (x *StateEvent_OutstandingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_OutstandingRequest.ProtoReflect.Descriptor instead.
 func (*StateEvent_OutstandingRequest) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 2}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 2}
 }
 
 func (x *StateEvent_OutstandingRequest) GetRequestAck() *RequestAck {
@@ -2932,6 +3337,13 @@ func (x *StateEvent_OutstandingRequest) GetRequestAck() *RequestAck {
 	return nil
 }
 
+func (x *StateEvent_OutstandingRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 type StateEvent_LoadCompleted struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2941,7 +3353,7 @@ type StateEvent_LoadCompleted struct {
 func (x *StateEvent_LoadCompleted) Reset() {
 	*x = StateEvent_LoadCompleted{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[37]
+		mi := &file_mirbft_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2954,7 +3366,7 @@ func (x *StateEvent_LoadCompleted) String() string {
 func (*StateEvent_LoadCompleted) ProtoMessage() {}
 
 func (x *StateEvent_LoadCompleted) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[37]
+	mi := &file_mirbft_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2967,7 +3379,7 @@ func (x *StateEvent_LoadCompleted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_LoadCompleted.ProtoReflect.Descriptor instead.
 func (*StateEvent_LoadCompleted) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 3}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 3}
 }
 
 type StateEvent_ActionResults struct {
@@ -2982,7 +3394,7 @@ type StateEvent_ActionResults struct {
 func (x *StateEvent_ActionResults) Reset() {
 	*x = StateEvent_ActionResults{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[38]
+		mi := &file_mirbft_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2995,7 +3407,7 @@ func (x *StateEvent_ActionResults) String() string {
 func (*StateEvent_ActionResults) ProtoMessage() {}
 
 func (x *StateEvent_ActionResults) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[38]
+	mi := &file_mirbft_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3008,7 +3420,7 @@ func (x *StateEvent_ActionResults) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_ActionResults.ProtoReflect.Descriptor instead.
 func (*StateEvent_ActionResults) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 4}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 4}
 }
 
 func (x *StateEvent_ActionResults) GetDigests() []*HashResult {
@@ -3036,7 +3448,7 @@ type StateEvent_Proposal struct {
 func (x *StateEvent_Proposal) Reset() {
 	*x = StateEvent_Proposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[39]
+		mi := &file_mirbft_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3049,7 +3461,7 @@ func (x *StateEvent_Proposal) String() string {
 func (*StateEvent_Proposal) ProtoMessage() {}
 
 func (x *StateEvent_Proposal) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[39]
+	mi := &file_mirbft_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3062,7 +3474,7 @@ func (x *StateEvent_Proposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_Proposal.ProtoReflect.Descriptor instead.
 func (*StateEvent_Proposal) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 5}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 5}
 }
 
 func (x *StateEvent_Proposal) GetRequest() *Request {
@@ -3084,7 +3496,7 @@ type StateEvent_InboundMsg struct {
 func (x *StateEvent_InboundMsg) Reset() {
 	*x = StateEvent_InboundMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[40]
+		mi := &file_mirbft_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3097,7 +3509,7 @@ func (x *StateEvent_InboundMsg) String() string {
 func (*StateEvent_InboundMsg) ProtoMessage() {}
 
 func (x *StateEvent_InboundMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[40]
+	mi := &file_mirbft_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3110,7 +3522,7 @@ func (x *StateEvent_InboundMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_InboundMsg.ProtoReflect.Descriptor instead.
 func (*StateEvent_InboundMsg) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 6}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 6}
 }
 
 func (x *StateEvent_InboundMsg) GetSource() uint64 {
@@ -3136,7 +3548,7 @@ type StateEvent_TickElapsed struct {
 func (x *StateEvent_TickElapsed) Reset() {
 	*x = StateEvent_TickElapsed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[41]
+		mi := &file_mirbft_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3149,7 +3561,7 @@ func (x *StateEvent_TickElapsed) String() string {
 func (*StateEvent_TickElapsed) ProtoMessage() {}
 
 func (x *StateEvent_TickElapsed) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[41]
+	mi := &file_mirbft_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3162,7 +3574,7 @@ func (x *StateEvent_TickElapsed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_TickElapsed.ProtoReflect.Descriptor instead.
 func (*StateEvent_TickElapsed) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 7}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 7}
 }
 
 type StateEvent_Ready struct {
@@ -3174,7 +3586,7 @@ type StateEvent_Ready struct {
 func (x *StateEvent_Ready) Reset() {
 	*x = StateEvent_Ready{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[42]
+		mi := &file_mirbft_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3187,7 +3599,7 @@ func (x *StateEvent_Ready) String() string {
 func (*StateEvent_Ready) ProtoMessage() {}
 
 func (x *StateEvent_Ready) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[42]
+	mi := &file_mirbft_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3200,7 +3612,7 @@ func (x *StateEvent_Ready) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateEvent_Ready.ProtoReflect.Descriptor instead.
 func (*StateEvent_Ready) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{26, 8}
+	return file_mirbft_proto_rawDescGZIP(), []int{28, 8}
 }
 
 type HashResult_Request struct {
@@ -3215,7 +3627,7 @@ type HashResult_Request struct {
 func (x *HashResult_Request) Reset() {
 	*x = HashResult_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[43]
+		mi := &file_mirbft_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3228,7 +3640,7 @@ func (x *HashResult_Request) String() string {
 func (*HashResult_Request) ProtoMessage() {}
 
 func (x *HashResult_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[43]
+	mi := &file_mirbft_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3241,7 +3653,7 @@ func (x *HashResult_Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult_Request.ProtoReflect.Descriptor instead.
 func (*HashResult_Request) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27, 0}
+	return file_mirbft_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *HashResult_Request) GetSource() uint64 {
@@ -3271,7 +3683,7 @@ type HashResult_VerifyRequest struct {
 func (x *HashResult_VerifyRequest) Reset() {
 	*x = HashResult_VerifyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[44]
+		mi := &file_mirbft_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3284,7 +3696,7 @@ func (x *HashResult_VerifyRequest) String() string {
 func (*HashResult_VerifyRequest) ProtoMessage() {}
 
 func (x *HashResult_VerifyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[44]
+	mi := &file_mirbft_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3297,7 +3709,7 @@ func (x *HashResult_VerifyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult_VerifyRequest.ProtoReflect.Descriptor instead.
 func (*HashResult_VerifyRequest) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27, 1}
+	return file_mirbft_proto_rawDescGZIP(), []int{29, 1}
 }
 
 func (x *HashResult_VerifyRequest) GetSource() uint64 {
@@ -3335,7 +3747,7 @@ type HashResult_Batch struct {
 func (x *HashResult_Batch) Reset() {
 	*x = HashResult_Batch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[45]
+		mi := &file_mirbft_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3348,7 +3760,7 @@ func (x *HashResult_Batch) String() string {
 func (*HashResult_Batch) ProtoMessage() {}
 
 func (x *HashResult_Batch) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[45]
+	mi := &file_mirbft_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3361,7 +3773,7 @@ func (x *HashResult_Batch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult_Batch.ProtoReflect.Descriptor instead.
 func (*HashResult_Batch) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27, 2}
+	return file_mirbft_proto_rawDescGZIP(), []int{29, 2}
 }
 
 func (x *HashResult_Batch) GetSource() uint64 {
@@ -3406,7 +3818,7 @@ type HashResult_VerifyBatch struct {
 func (x *HashResult_VerifyBatch) Reset() {
 	*x = HashResult_VerifyBatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[46]
+		mi := &file_mirbft_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3419,7 +3831,7 @@ func (x *HashResult_VerifyBatch) String() string {
 func (*HashResult_VerifyBatch) ProtoMessage() {}
 
 func (x *HashResult_VerifyBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[46]
+	mi := &file_mirbft_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3432,7 +3844,7 @@ func (x *HashResult_VerifyBatch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult_VerifyBatch.ProtoReflect.Descriptor instead.
 func (*HashResult_VerifyBatch) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27, 3}
+	return file_mirbft_proto_rawDescGZIP(), []int{29, 3}
 }
 
 func (x *HashResult_VerifyBatch) GetSource() uint64 {
@@ -3476,7 +3888,7 @@ type HashResult_EpochChange struct {
 func (x *HashResult_EpochChange) Reset() {
 	*x = HashResult_EpochChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mirbft_proto_msgTypes[47]
+		mi := &file_mirbft_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3489,7 +3901,7 @@ func (x *HashResult_EpochChange) String() string {
 func (*HashResult_EpochChange) ProtoMessage() {}
 
 func (x *HashResult_EpochChange) ProtoReflect() protoreflect.Message {
-	mi := &file_mirbft_proto_msgTypes[47]
+	mi := &file_mirbft_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3502,7 +3914,7 @@ func (x *HashResult_EpochChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HashResult_EpochChange.ProtoReflect.Descriptor instead.
 func (*HashResult_EpochChange) Descriptor() ([]byte, []int) {
-	return file_mirbft_proto_rawDescGZIP(), []int{27, 4}
+	return file_mirbft_proto_rawDescGZIP(), []int{29, 4}
 }
 
 func (x *HashResult_EpochChange) GetSource() uint64 {
@@ -3569,7 +3981,7 @@ var file_mirbft_proto_rawDesc = []byte{
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x6f, 0x77, 0x57, 0x61, 0x74, 0x65, 0x72,
 	0x6d, 0x61, 0x72, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
 	0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xf9, 0x01, 0x0a, 0x0f,
+	0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xfa, 0x02, 0x0a, 0x0f,
 	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
 	0x44, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52,
@@ -3581,397 +3993,473 @@ var file_mirbft_proto_rawDesc = []byte{
 	0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77,
 	0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48,
-	0x00, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x31, 0x0a, 0x09,
-	0x4e, 0x65, 0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x42,
-	0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x82, 0x03, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x73,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x71, 0x5f, 0x65, 0x6e, 0x74, 0x72,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
-	0x70, 0x62, 0x2e, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x71, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
-	0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x2b, 0x0a, 0x07, 0x63, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x63, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a,
-	0x07, 0x6e, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x48, 0x00, 0x52, 0x06, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x66, 0x5f,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69,
-	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x46, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52,
-	0x06, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2f, 0x0a, 0x09, 0x65, 0x5f, 0x63, 0x5f, 0x65,
-	0x6e, 0x74, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72,
-	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52,
-	0x07, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x74, 0x5f, 0x65, 0x6e,
-	0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x74,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x73,
-	0x70, 0x65, 0x63, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x59, 0x0a, 0x06,
-	0x4e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x38, 0x0a,
-	0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45,
-	0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x4b, 0x0a, 0x06, 0x46, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x41, 0x0a, 0x11, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d,
-	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x6e, 0x64, 0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x07, 0x45, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x21, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x22, 0x35, 0x0a, 0x06, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x69, 0x0a, 0x06, 0x51, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x06, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15,
-	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x87, 0x01,
-	0x0a, 0x06, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
+	0x00, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x4a, 0x0a, 0x0c,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x64, 0x74, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x57, 0x69, 0x64, 0x74, 0x68, 0x1a, 0x31, 0x0a, 0x09, 0x4e, 0x65, 0x77, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x1a, 0x33, 0x0a, 0x0b, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x82, 0x03, 0x0a, 0x0a, 0x50, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x71, 0x5f, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
+	0x74, 0x70, 0x62, 0x2e, 0x51, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x71, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x63, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b,
+	0x0a, 0x07, 0x6e, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x66,
+	0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
+	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x46, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x06, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2f, 0x0a, 0x09, 0x65, 0x5f, 0x63, 0x5f,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69,
+	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x07, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x74, 0x5f, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x59, 0x0a,
+	0x06, 0x4e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x38,
+	0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x4b, 0x0a, 0x06, 0x46, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x41, 0x0a, 0x11, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x6e, 0x64, 0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x07, 0x45, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x21, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x06, 0x54, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a,
+	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
+	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x69, 0x0a, 0x06, 0x51, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x06, 0x50, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x87,
+	0x01, 0x0a, 0x06, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71,
+	0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f,
+	0x12, 0x29, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3b, 0x0a, 0x0d, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xff, 0x07, 0x0a, 0x03, 0x4d, 0x73, 0x67,
+	0x12, 0x36, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72,
+	0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62,
+	0x66, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x73,
+	0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d,
+	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x3a, 0x0a, 0x0c, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x09,
+	0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x48, 0x00, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12,
+	0x40, 0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x63, 0x68,
+	0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x48, 0x00, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x45, 0x63, 0x68,
+	0x6f, 0x12, 0x42, 0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72,
+	0x65, 0x61, 0x64, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x37, 0x0a, 0x0b, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x48, 0x00, 0x52, 0x0a, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3d,
+	0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52,
+	0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3b, 0x0a,
+	0x0d, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x65,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x46,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x37, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x46, 0x0a, 0x10, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x40, 0x0a, 0x0e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
+	0x74, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3b, 0x0a, 0x0a, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
 	0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12,
-	0x29, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3b, 0x0a, 0x0d, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xf5, 0x06, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x12,
-	0x36, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x50,
-	0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x65,
-	0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61,
-	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
-	0x74, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70,
-	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a,
-	0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x75,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69,
-	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x48, 0x00,
-	0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x3a, 0x0a, 0x0c, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x09, 0x6e,
-	0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f,
-	0x63, 0x68, 0x48, 0x00, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x40,
-	0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x63, 0x68, 0x6f,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x48, 0x00, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x45, 0x63, 0x68, 0x6f,
-	0x12, 0x42, 0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72, 0x65,
-	0x61, 0x64, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52,
-	0x65, 0x61, 0x64, 0x79, 0x12, 0x37, 0x0a, 0x0b, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x62, 0x61,
-	0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48,
-	0x00, 0x52, 0x0a, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3d, 0x0a,
-	0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c,
+	0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x76, 0x0a, 0x0c, 0x46, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x37,
+	0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22,
+	0x6a, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x56, 0x0a, 0x0f, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x22, 0xe6, 0x01, 0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a,
+	0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
-	0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0c,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3b, 0x0a, 0x0d,
-	0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x65, 0x74,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x66, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x46, 0x6f,
-	0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37,
-	0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x0f, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0x3b, 0x0a, 0x0a, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a,
-	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
-	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x76, 0x0a, 0x0c,
-	0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61,
-	0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52,
-	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x22, 0x6a, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69,
-	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63,
-	0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a,
-	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61,
-	0x22, 0x51, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f,
-	0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12,
-	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x58, 0x0a, 0x0a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63,
-	0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15,
-	0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a,
-	0x0a, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x51, 0x0a, 0x07,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x58, 0x0a, 0x0a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x0a, 0x50, 0x72, 0x65,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x22, 0x4e, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73,
 	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
 	0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63,
-	0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x05, 0x62,
-	0x61, 0x74, 0x63, 0x68, 0x22, 0x4e, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12,
-	0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06,
-	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x22, 0x4d, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x15,
-	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x22, 0x39, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1f,
-	0x0a, 0x07, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22,
-	0x9d, 0x02, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
-	0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x0b,
-	0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x05, 0x70, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45,
-	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x04, 0x70, 0x53, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x71, 0x5f, 0x73,
-	0x65, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
-	0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
-	0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x71, 0x53, 0x65, 0x74, 0x1a, 0x4f,
-	0x0a, 0x08, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
-	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x22, 0x4d, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65,
+	0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
 	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22,
-	0x6a, 0x0a, 0x0e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63,
-	0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x38, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
-	0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b,
-	0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x45,
-	0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12,
-	0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65,
-	0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb3, 0x01, 0x0a, 0x0e,
-	0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2d,
-	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a,
-	0x13, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72,
-	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x52, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72,
-	0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
-	0x73, 0x22, 0xd4, 0x01, 0x0a, 0x08, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x37,
-	0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65,
-	0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x6e, 0x65,
-	0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x49, 0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63, 0x68,
-	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x39, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x15, 0x0a,
+	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
+	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1f, 0x0a, 0x07, 0x53, 0x75,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x9d, 0x02, 0x0a, 0x0b,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x33, 0x0a, 0x05, 0x70, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x04, 0x70, 0x53, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x71, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x71, 0x53, 0x65, 0x74, 0x1a, 0x4f, 0x0a, 0x08, 0x53, 0x65,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x6a, 0x0a, 0x0e, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x38, 0x0a,
+	0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x07, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x6c, 0x61, 0x6e,
+	0x6e, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x13, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
+	0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x12, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x2b, 0x0a, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x50, 0x72, 0x65, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x22, 0xd4, 0x01,
+	0x0a, 0x08, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x6e, 0x65,
+	0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
 	0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f,
-	0x63, 0x68, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x52, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x73, 0x1a, 0x44, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xed, 0x0a, 0x0a, 0x0a, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x48, 0x0a, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6d, 0x69,
-	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x12, 0x44, 0x0a, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x65, 0x72, 0x73,
-	0x69, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x6f,
-	0x61, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
-	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5d, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x6f, 0x61,
-	0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x16, 0x63, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62,
+	0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x49, 0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x69, 0x72,
+	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x44,
+	0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x22, 0xed, 0x0f, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x48, 0x0a, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x48,
+	0x00, 0x52, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x12, 0x44, 0x0a,
+	0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6d, 0x69, 0x72, 0x62,
 	0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x48, 0x00, 0x52,
-	0x0a, 0x61, 0x64, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48,
-	0x00, 0x52, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x07, 0x70,
-	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d,
-	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x70,
-	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x62, 0x6f, 0x75,
-	0x6e, 0x64, 0x4d, 0x73, 0x67, 0x48, 0x00, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x36, 0x0a,
-	0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x69,
+	0x4f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x5d, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x45, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x64,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x72, 0x62,
+	0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x08, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
+	0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x73,
+	0x67, 0x48, 0x00, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x69,
+	0x63, 0x6b, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x12, 0x47, 0x0a, 0x10, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x69,
 	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52,
-	0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x47, 0x0a, 0x10, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x48, 0x00, 0x52, 0x0f, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x1a, 0xe8,
-	0x01, 0x0a, 0x11, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
-	0x74, 0x65, 0x72, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
-	0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x68, 0x65,
-	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x23, 0x0a, 0x0d,
-	0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x69, 0x63, 0x6b,
-	0x73, 0x12, 0x35, 0x0a, 0x17, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x14, 0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66,
-	0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x62,
-	0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0x50, 0x0a, 0x0e, 0x50, 0x65, 0x72,
-	0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x4b, 0x0a, 0x12, 0x4f,
-	0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x1a, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x61, 0x64,
-	0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x1a, 0x7d, 0x0a, 0x0d, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69,
-	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x68,
-	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x1a, 0x37, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70,
-	0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x45, 0x0a, 0x0a, 0x49, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x12,
+	0x74, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x48, 0x00, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x1a, 0xd4, 0x06, 0x0a, 0x11, 0x49,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x74, 0x69, 0x63,
+	0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x35, 0x0a,
+	0x17, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14,
+	0x6e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x54,
+	0x69, 0x63, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a,
+	0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x54, 0x69,
+	0x63, 0x6b, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x11, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x54, 0x69,
+	0x63, 0x6b, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x11, 0x66, 0x65, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x54, 0x69,
+	0x63, 0x6b, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x61,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x5c, 0x0a,
+	0x12, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x6d, 0x69, 0x72, 0x62,
+	0x66, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x10, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x2f, 0x0a, 0x14, 0x61,
+	0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x49, 0x0a, 0x21,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x4f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x44, 0x69, 0x76,
+	0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x22, 0x26, 0x0a, 0x07, 0x42, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x49, 0x4e, 0x45, 0x41, 0x52, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10,
+	0x01, 0x1a, 0x50, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x1a, 0x5f, 0x0a, 0x12, 0x4f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x1a, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x1a, 0x7d, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
+	0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x69,
+	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x1a, 0x37, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x0a,
+	0x0a, 0x49, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4d, 0x73, 0x67, 0x52,
+	0x03, 0x6d, 0x73, 0x67, 0x1a, 0x0d, 0x0a, 0x0b, 0x54, 0x69, 0x63, 0x6b, 0x45, 0x6c, 0x61, 0x70,
+	0x73, 0x65, 0x64, 0x1a, 0x07, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x42, 0x06, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x22, 0xeb, 0x07, 0x0a, 0x0a, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d,
+	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x48, 0x00, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x45, 0x0a, 0x0c, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x45, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
+	0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4b, 0x0a, 0x0e, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x4e, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e,
-	0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x1a, 0x0d, 0x0a, 0x0b, 0x54, 0x69, 0x63, 0x6b,
-	0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x1a, 0x07, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79,
-	0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xeb, 0x07, 0x0a, 0x0a, 0x48, 0x61, 0x73,
-	0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
-	0x38, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
-	0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x45, 0x0a,
-	0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48,
-	0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x62,
-	0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x69, 0x72,
-	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b,
-	0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4b, 0x0a, 0x0e, 0x76,
-	0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x48,
-	0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x4e, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d,
-	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x81, 0x01, 0x0a, 0x0d, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63,
-	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x85, 0x01, 0x0a,
-	0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x37, 0x0a, 0x0c, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x41, 0x63, 0x6b, 0x73, 0x1a, 0x9e, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06,
-	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
-	0x71, 0x4e, 0x6f, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61,
-	0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52,
-	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f,
-	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x1a, 0x77, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x12, 0x38, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72,
-	0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x06,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73,
-	0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
-	0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3b, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x49, 0x42, 0x4d, 0x2f, 0x6d, 0x69, 0x72, 0x62,
-	0x66, 0x74, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66,
+	0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x81, 0x01, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x35,
+	0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x85, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73,
+	0x1a, 0x9e, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f,
+	0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12,
+	0x37, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x1a, 0x77, 0x0a, 0x0b, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x12, 0x38, 0x0a, 0x0c, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x70,
+	0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x3b, 0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69,
+	0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x64, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x49, 0x42, 0x4d, 0x2f, 0x6d, 0x69, 0x72, 0x62, 0x66, 0x74, 0x2f, 0x6d,
+	0x69, 0x72, 0x62, 0x66, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3986,133 +4474,143 @@ func file_mirbft_proto_rawDescGZIP() []byte {
 	return file_mirbft_proto_rawDescData
 }
 
-var file_mirbft_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_mirbft_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mirbft_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_mirbft_proto_goTypes = []interface{}{
-	(*NetworkState)(nil),                  // 0: mirbftpb.NetworkState
-	(*Reconfiguration)(nil),               // 1: mirbftpb.Reconfiguration
-	(*Persistent)(nil),                    // 2: mirbftpb.Persistent
-	(*NEntry)(nil),                        // 3: mirbftpb.NEntry
-	(*FEntry)(nil),                        // 4: mirbftpb.FEntry
-	(*ECEntry)(nil),                       // 5: mirbftpb.ECEntry
-	(*TEntry)(nil),                        // 6: mirbftpb.TEntry
-	(*QEntry)(nil),                        // 7: mirbftpb.QEntry
-	(*PEntry)(nil),                        // 8: mirbftpb.PEntry
-	(*CEntry)(nil),                        // 9: mirbftpb.CEntry
-	(*Msg)(nil),                           // 10: mirbftpb.Msg
-	(*FetchBatch)(nil),                    // 11: mirbftpb.FetchBatch
-	(*ForwardBatch)(nil),                  // 12: mirbftpb.ForwardBatch
-	(*ForwardRequest)(nil),                // 13: mirbftpb.ForwardRequest
-	(*Request)(nil),                       // 14: mirbftpb.Request
-	(*RequestAck)(nil),                    // 15: mirbftpb.RequestAck
-	(*Preprepare)(nil),                    // 16: mirbftpb.Preprepare
-	(*Prepare)(nil),                       // 17: mirbftpb.Prepare
-	(*Commit)(nil),                        // 18: mirbftpb.Commit
-	(*Checkpoint)(nil),                    // 19: mirbftpb.Checkpoint
-	(*Suspect)(nil),                       // 20: mirbftpb.Suspect
-	(*EpochChange)(nil),                   // 21: mirbftpb.EpochChange
-	(*EpochChangeAck)(nil),                // 22: mirbftpb.EpochChangeAck
-	(*EpochConfig)(nil),                   // 23: mirbftpb.EpochConfig
-	(*NewEpochConfig)(nil),                // 24: mirbftpb.NewEpochConfig
-	(*NewEpoch)(nil),                      // 25: mirbftpb.NewEpoch
-	(*StateEvent)(nil),                    // 26: mirbftpb.StateEvent
-	(*HashResult)(nil),                    // 27: mirbftpb.HashResult
-	(*CheckpointResult)(nil),              // 28: mirbftpb.CheckpointResult
-	(*NetworkState_Config)(nil),           // 29: mirbftpb.NetworkState.Config
-	(*NetworkState_Client)(nil),           // 30: mirbftpb.NetworkState.Client
-	(*Reconfiguration_NewClient)(nil),     // 31: mirbftpb.Reconfiguration.NewClient
-	(*EpochChange_SetEntry)(nil),          // 32: mirbftpb.EpochChange.SetEntry
-	(*NewEpoch_RemoteEpochChange)(nil),    // 33: mirbftpb.NewEpoch.RemoteEpochChange
-	(*StateEvent_InitialParameters)(nil),  // 34: mirbftpb.StateEvent.InitialParameters
-	(*StateEvent_PersistedEntry)(nil),     // 35: mirbftpb.StateEvent.PersistedEntry
-	(*StateEvent_OutstandingRequest)(nil), // 36: mirbftpb.StateEvent.OutstandingRequest
-	(*StateEvent_LoadCompleted)(nil),      // 37: mirbftpb.StateEvent.LoadCompleted
-	(*StateEvent_ActionResults)(nil),      // 38: mirbftpb.StateEvent.ActionResults
-	(*StateEvent_Proposal)(nil),           // 39: mirbftpb.StateEvent.Proposal
-	(*StateEvent_InboundMsg)(nil),         // 40: mirbftpb.StateEvent.InboundMsg
-	(*StateEvent_TickElapsed)(nil),        // 41: mirbftpb.StateEvent.TickElapsed
-	(*StateEvent_Ready)(nil),              // 42: mirbftpb.StateEvent.Ready
-	(*HashResult_Request)(nil),            // 43: mirbftpb.HashResult.Request
-	(*HashResult_VerifyRequest)(nil),      // 44: mirbftpb.HashResult.VerifyRequest
-	(*HashResult_Batch)(nil),              // 45: mirbftpb.HashResult.Batch
-	(*HashResult_VerifyBatch)(nil),        // 46: mirbftpb.HashResult.VerifyBatch
-	(*HashResult_EpochChange)(nil),        // 47: mirbftpb.HashResult.EpochChange
+	(StateEvent_InitialParameters_Backoff)(0), // 0: mirbftpb.StateEvent.InitialParameters.Backoff
+	(*NetworkState)(nil),                      // 1: mirbftpb.NetworkState
+	(*Reconfiguration)(nil),                   // 2: mirbftpb.Reconfiguration
+	(*Persistent)(nil),                        // 3: mirbftpb.Persistent
+	(*NEntry)(nil),                            // 4: mirbftpb.NEntry
+	(*FEntry)(nil),                            // 5: mirbftpb.FEntry
+	(*ECEntry)(nil),                           // 6: mirbftpb.ECEntry
+	(*TEntry)(nil),                            // 7: mirbftpb.TEntry
+	(*QEntry)(nil),                            // 8: mirbftpb.QEntry
+	(*PEntry)(nil),                            // 9: mirbftpb.PEntry
+	(*CEntry)(nil),                            // 10: mirbftpb.CEntry
+	(*Msg)(nil),                               // 11: mirbftpb.Msg
+	(*FetchBatch)(nil),                        // 12: mirbftpb.FetchBatch
+	(*ForwardBatch)(nil),                      // 13: mirbftpb.ForwardBatch
+	(*ForwardRequest)(nil),                    // 14: mirbftpb.ForwardRequest
+	(*SnapshotRequest)(nil),                   // 15: mirbftpb.SnapshotRequest
+	(*SnapshotChunk)(nil),                     // 16: mirbftpb.SnapshotChunk
+	(*Request)(nil),                           // 17: mirbftpb.Request
+	(*RequestAck)(nil),                        // 18: mirbftpb.RequestAck
+	(*Preprepare)(nil),                        // 19: mirbftpb.Preprepare
+	(*Prepare)(nil),                           // 20: mirbftpb.Prepare
+	(*Commit)(nil),                            // 21: mirbftpb.Commit
+	(*Checkpoint)(nil),                        // 22: mirbftpb.Checkpoint
+	(*Suspect)(nil),                           // 23: mirbftpb.Suspect
+	(*EpochChange)(nil),                       // 24: mirbftpb.EpochChange
+	(*EpochChangeAck)(nil),                    // 25: mirbftpb.EpochChangeAck
+	(*EpochConfig)(nil),                       // 26: mirbftpb.EpochConfig
+	(*NewEpochConfig)(nil),                    // 27: mirbftpb.NewEpochConfig
+	(*NewEpoch)(nil),                          // 28: mirbftpb.NewEpoch
+	(*StateEvent)(nil),                        // 29: mirbftpb.StateEvent
+	(*HashResult)(nil),                        // 30: mirbftpb.HashResult
+	(*CheckpointResult)(nil),                  // 31: mirbftpb.CheckpointResult
+	(*NetworkState_Config)(nil),               // 32: mirbftpb.NetworkState.Config
+	(*NetworkState_Client)(nil),               // 33: mirbftpb.NetworkState.Client
+	(*Reconfiguration_NewClient)(nil),         // 34: mirbftpb.Reconfiguration.NewClient
+	(*Reconfiguration_ClientWidth)(nil),       // 35: mirbftpb.Reconfiguration.ClientWidth
+	(*EpochChange_SetEntry)(nil),              // 36: mirbftpb.EpochChange.SetEntry
+	(*NewEpoch_RemoteEpochChange)(nil),        // 37: mirbftpb.NewEpoch.RemoteEpochChange
+	(*StateEvent_InitialParameters)(nil),      // 38: mirbftpb.StateEvent.InitialParameters
+	(*StateEvent_PersistedEntry)(nil),         // 39: mirbftpb.StateEvent.PersistedEntry
+	(*StateEvent_OutstandingRequest)(nil),     // 40: mirbftpb.StateEvent.OutstandingRequest
+	(*StateEvent_LoadCompleted)(nil),          // 41: mirbftpb.StateEvent.LoadCompleted
+	(*StateEvent_ActionResults)(nil),          // 42: mirbftpb.StateEvent.ActionResults
+	(*StateEvent_Proposal)(nil),               // 43: mirbftpb.StateEvent.Proposal
+	(*StateEvent_InboundMsg)(nil),             // 44: mirbftpb.StateEvent.InboundMsg
+	(*StateEvent_TickElapsed)(nil),            // 45: mirbftpb.StateEvent.TickElapsed
+	(*StateEvent_Ready)(nil),                  // 46: mirbftpb.StateEvent.Ready
+	(*HashResult_Request)(nil),                // 47: mirbftpb.HashResult.Request
+	(*HashResult_VerifyRequest)(nil),          // 48: mirbftpb.HashResult.VerifyRequest
+	(*HashResult_Batch)(nil),                  // 49: mirbftpb.HashResult.Batch
+	(*HashResult_VerifyBatch)(nil),            // 50: mirbftpb.HashResult.VerifyBatch
+	(*HashResult_EpochChange)(nil),            // 51: mirbftpb.HashResult.EpochChange
 }
 var file_mirbft_proto_depIdxs = []int32{
-	29, // 0: mirbftpb.NetworkState.config:type_name -> mirbftpb.NetworkState.Config
-	30, // 1: mirbftpb.NetworkState.clients:type_name -> mirbftpb.NetworkState.Client
-	1,  // 2: mirbftpb.NetworkState.pending_reconfigurations:type_name -> mirbftpb.Reconfiguration
-	31, // 3: mirbftpb.Reconfiguration.new_client:type_name -> mirbftpb.Reconfiguration.NewClient
-	29, // 4: mirbftpb.Reconfiguration.new_config:type_name -> mirbftpb.NetworkState.Config
-	7,  // 5: mirbftpb.Persistent.q_entry:type_name -> mirbftpb.QEntry
-	8,  // 6: mirbftpb.Persistent.p_entry:type_name -> mirbftpb.PEntry
-	9,  // 7: mirbftpb.Persistent.c_entry:type_name -> mirbftpb.CEntry
-	3,  // 8: mirbftpb.Persistent.n_entry:type_name -> mirbftpb.NEntry
-	4,  // 9: mirbftpb.Persistent.f_entry:type_name -> mirbftpb.FEntry
-	5,  // 10: mirbftpb.Persistent.e_c_entry:type_name -> mirbftpb.ECEntry
-	6,  // 11: mirbftpb.Persistent.t_entry:type_name -> mirbftpb.TEntry
-	20, // 12: mirbftpb.Persistent.suspect:type_name -> mirbftpb.Suspect
-	23, // 13: mirbftpb.NEntry.epoch_config:type_name -> mirbftpb.EpochConfig
-	23, // 14: mirbftpb.FEntry.ends_epoch_config:type_name -> mirbftpb.EpochConfig
-	15, // 15: mirbftpb.QEntry.requests:type_name -> mirbftpb.RequestAck
-	0,  // 16: mirbftpb.CEntry.network_state:type_name -> mirbftpb.NetworkState
-	16, // 17: mirbftpb.Msg.preprepare:type_name -> mirbftpb.Preprepare
-	17, // 18: mirbftpb.Msg.prepare:type_name -> mirbftpb.Prepare
-	18, // 19: mirbftpb.Msg.commit:type_name -> mirbftpb.Commit
-	19, // 20: mirbftpb.Msg.checkpoint:type_name -> mirbftpb.Checkpoint
-	20, // 21: mirbftpb.Msg.suspect:type_name -> mirbftpb.Suspect
-	21, // 22: mirbftpb.Msg.epoch_change:type_name -> mirbftpb.EpochChange
-	22, // 23: mirbftpb.Msg.epoch_change_ack:type_name -> mirbftpb.EpochChangeAck
-	25, // 24: mirbftpb.Msg.new_epoch:type_name -> mirbftpb.NewEpoch
-	24, // 25: mirbftpb.Msg.new_epoch_echo:type_name -> mirbftpb.NewEpochConfig
-	24, // 26: mirbftpb.Msg.new_epoch_ready:type_name -> mirbftpb.NewEpochConfig
-	11, // 27: mirbftpb.Msg.fetch_batch:type_name -> mirbftpb.FetchBatch
-	12, // 28: mirbftpb.Msg.forward_batch:type_name -> mirbftpb.ForwardBatch
-	15, // 29: mirbftpb.Msg.fetch_request:type_name -> mirbftpb.RequestAck
-	13, // 30: mirbftpb.Msg.forward_request:type_name -> mirbftpb.ForwardRequest
-	15, // 31: mirbftpb.Msg.request_ack:type_name -> mirbftpb.RequestAck
-	15, // 32: mirbftpb.ForwardBatch.request_acks:type_name -> mirbftpb.RequestAck
-	15, // 33: mirbftpb.ForwardRequest.request_ack:type_name -> mirbftpb.RequestAck
-	15, // 34: mirbftpb.Preprepare.batch:type_name -> mirbftpb.RequestAck
-	19, // 35: mirbftpb.EpochChange.checkpoints:type_name -> mirbftpb.Checkpoint
-	32, // 36: mirbftpb.EpochChange.p_set:type_name -> mirbftpb.EpochChange.SetEntry
-	32, // 37: mirbftpb.EpochChange.q_set:type_name -> mirbftpb.EpochChange.SetEntry
-	21, // 38: mirbftpb.EpochChangeAck.epoch_change:type_name -> mirbftpb.EpochChange
-	23, // 39: mirbftpb.NewEpochConfig.config:type_name -> mirbftpb.EpochConfig
-	19, // 40: mirbftpb.NewEpochConfig.starting_checkpoint:type_name -> mirbftpb.Checkpoint
-	24, // 41: mirbftpb.NewEpoch.new_config:type_name -> mirbftpb.NewEpochConfig
-	33, // 42: mirbftpb.NewEpoch.epoch_changes:type_name -> mirbftpb.NewEpoch.RemoteEpochChange
-	34, // 43: mirbftpb.StateEvent.initialize:type_name -> mirbftpb.StateEvent.InitialParameters
-	35, // 44: mirbftpb.StateEvent.load_entry:type_name -> mirbftpb.StateEvent.PersistedEntry
-	36, // 45: mirbftpb.StateEvent.load_request:type_name -> mirbftpb.StateEvent.OutstandingRequest
-	37, // 46: mirbftpb.StateEvent.complete_initialization:type_name -> mirbftpb.StateEvent.LoadCompleted
-	38, // 47: mirbftpb.StateEvent.add_results:type_name -> mirbftpb.StateEvent.ActionResults
-	9,  // 48: mirbftpb.StateEvent.Transfer:type_name -> mirbftpb.CEntry
-	39, // 49: mirbftpb.StateEvent.propose:type_name -> mirbftpb.StateEvent.Proposal
-	40, // 50: mirbftpb.StateEvent.step:type_name -> mirbftpb.StateEvent.InboundMsg
-	41, // 51: mirbftpb.StateEvent.tick:type_name -> mirbftpb.StateEvent.TickElapsed
-	42, // 52: mirbftpb.StateEvent.actions_received:type_name -> mirbftpb.StateEvent.Ready
-	43, // 53: mirbftpb.HashResult.request:type_name -> mirbftpb.HashResult.Request
-	45, // 54: mirbftpb.HashResult.batch:type_name -> mirbftpb.HashResult.Batch
-	47, // 55: mirbftpb.HashResult.epoch_change:type_name -> mirbftpb.HashResult.EpochChange
-	46, // 56: mirbftpb.HashResult.verify_batch:type_name -> mirbftpb.HashResult.VerifyBatch
-	44, // 57: mirbftpb.HashResult.verify_request:type_name -> mirbftpb.HashResult.VerifyRequest
-	0,  // 58: mirbftpb.CheckpointResult.network_state:type_name -> mirbftpb.NetworkState
-	2,  // 59: mirbftpb.StateEvent.PersistedEntry.data:type_name -> mirbftpb.Persistent
-	15, // 60: mirbftpb.StateEvent.OutstandingRequest.request_ack:type_name -> mirbftpb.RequestAck
-	27, // 61: mirbftpb.StateEvent.ActionResults.digests:type_name -> mirbftpb.HashResult
-	28, // 62: mirbftpb.StateEvent.ActionResults.checkpoints:type_name -> mirbftpb.CheckpointResult
-	14, // 63: mirbftpb.StateEvent.Proposal.request:type_name -> mirbftpb.Request
-	10, // 64: mirbftpb.StateEvent.InboundMsg.msg:type_name -> mirbftpb.Msg
-	14, // 65: mirbftpb.HashResult.Request.request:type_name -> mirbftpb.Request
-	15, // 66: mirbftpb.HashResult.VerifyRequest.request_ack:type_name -> mirbftpb.RequestAck
-	15, // 67: mirbftpb.HashResult.Batch.request_acks:type_name -> mirbftpb.RequestAck
-	15, // 68: mirbftpb.HashResult.VerifyBatch.request_acks:type_name -> mirbftpb.RequestAck
-	21, // 69: mirbftpb.HashResult.EpochChange.epoch_change:type_name -> mirbftpb.EpochChange
-	70, // [70:70] is the sub-list for method output_type
-	70, // [70:70] is the sub-list for method input_type
-	70, // [70:70] is the sub-list for extension type_name
-	70, // [70:70] is the sub-list for extension extendee
-	0,  // [0:70] is the sub-list for field type_name
+	32, // 0: mirbftpb.NetworkState.config:type_name -> mirbftpb.NetworkState.Config
+	33, // 1: mirbftpb.NetworkState.clients:type_name -> mirbftpb.NetworkState.Client
+	2,  // 2: mirbftpb.NetworkState.pending_reconfigurations:type_name -> mirbftpb.Reconfiguration
+	34, // 3: mirbftpb.Reconfiguration.new_client:type_name -> mirbftpb.Reconfiguration.NewClient
+	32, // 4: mirbftpb.Reconfiguration.new_config:type_name -> mirbftpb.NetworkState.Config
+	35, // 5: mirbftpb.Reconfiguration.client_width:type_name -> mirbftpb.Reconfiguration.ClientWidth
+	8,  // 6: mirbftpb.Persistent.q_entry:type_name -> mirbftpb.QEntry
+	9,  // 7: mirbftpb.Persistent.p_entry:type_name -> mirbftpb.PEntry
+	10, // 8: mirbftpb.Persistent.c_entry:type_name -> mirbftpb.CEntry
+	4,  // 9: mirbftpb.Persistent.n_entry:type_name -> mirbftpb.NEntry
+	5,  // 10: mirbftpb.Persistent.f_entry:type_name -> mirbftpb.FEntry
+	6,  // 11: mirbftpb.Persistent.e_c_entry:type_name -> mirbftpb.ECEntry
+	7,  // 12: mirbftpb.Persistent.t_entry:type_name -> mirbftpb.TEntry
+	23, // 13: mirbftpb.Persistent.suspect:type_name -> mirbftpb.Suspect
+	26, // 14: mirbftpb.NEntry.epoch_config:type_name -> mirbftpb.EpochConfig
+	26, // 15: mirbftpb.FEntry.ends_epoch_config:type_name -> mirbftpb.EpochConfig
+	18, // 16: mirbftpb.QEntry.requests:type_name -> mirbftpb.RequestAck
+	1,  // 17: mirbftpb.CEntry.network_state:type_name -> mirbftpb.NetworkState
+	19, // 18: mirbftpb.Msg.preprepare:type_name -> mirbftpb.Preprepare
+	20, // 19: mirbftpb.Msg.prepare:type_name -> mirbftpb.Prepare
+	21, // 20: mirbftpb.Msg.commit:type_name -> mirbftpb.Commit
+	22, // 21: mirbftpb.Msg.checkpoint:type_name -> mirbftpb.Checkpoint
+	23, // 22: mirbftpb.Msg.suspect:type_name -> mirbftpb.Suspect
+	24, // 23: mirbftpb.Msg.epoch_change:type_name -> mirbftpb.EpochChange
+	25, // 24: mirbftpb.Msg.epoch_change_ack:type_name -> mirbftpb.EpochChangeAck
+	28, // 25: mirbftpb.Msg.new_epoch:type_name -> mirbftpb.NewEpoch
+	27, // 26: mirbftpb.Msg.new_epoch_echo:type_name -> mirbftpb.NewEpochConfig
+	27, // 27: mirbftpb.Msg.new_epoch_ready:type_name -> mirbftpb.NewEpochConfig
+	12, // 28: mirbftpb.Msg.fetch_batch:type_name -> mirbftpb.FetchBatch
+	13, // 29: mirbftpb.Msg.forward_batch:type_name -> mirbftpb.ForwardBatch
+	18, // 30: mirbftpb.Msg.fetch_request:type_name -> mirbftpb.RequestAck
+	14, // 31: mirbftpb.Msg.forward_request:type_name -> mirbftpb.ForwardRequest
+	18, // 32: mirbftpb.Msg.request_ack:type_name -> mirbftpb.RequestAck
+	15, // 33: mirbftpb.Msg.snapshot_request:type_name -> mirbftpb.SnapshotRequest
+	16, // 34: mirbftpb.Msg.snapshot_chunk:type_name -> mirbftpb.SnapshotChunk
+	18, // 35: mirbftpb.ForwardBatch.request_acks:type_name -> mirbftpb.RequestAck
+	18, // 36: mirbftpb.ForwardRequest.request_ack:type_name -> mirbftpb.RequestAck
+	1,  // 37: mirbftpb.SnapshotChunk.network_state:type_name -> mirbftpb.NetworkState
+	18, // 38: mirbftpb.Preprepare.batch:type_name -> mirbftpb.RequestAck
+	22, // 39: mirbftpb.EpochChange.checkpoints:type_name -> mirbftpb.Checkpoint
+	36, // 40: mirbftpb.EpochChange.p_set:type_name -> mirbftpb.EpochChange.SetEntry
+	36, // 41: mirbftpb.EpochChange.q_set:type_name -> mirbftpb.EpochChange.SetEntry
+	24, // 42: mirbftpb.EpochChangeAck.epoch_change:type_name -> mirbftpb.EpochChange
+	26, // 43: mirbftpb.NewEpochConfig.config:type_name -> mirbftpb.EpochConfig
+	22, // 44: mirbftpb.NewEpochConfig.starting_checkpoint:type_name -> mirbftpb.Checkpoint
+	27, // 45: mirbftpb.NewEpoch.new_config:type_name -> mirbftpb.NewEpochConfig
+	37, // 46: mirbftpb.NewEpoch.epoch_changes:type_name -> mirbftpb.NewEpoch.RemoteEpochChange
+	38, // 47: mirbftpb.StateEvent.initialize:type_name -> mirbftpb.StateEvent.InitialParameters
+	39, // 48: mirbftpb.StateEvent.load_entry:type_name -> mirbftpb.StateEvent.PersistedEntry
+	40, // 49: mirbftpb.StateEvent.load_request:type_name -> mirbftpb.StateEvent.OutstandingRequest
+	41, // 50: mirbftpb.StateEvent.complete_initialization:type_name -> mirbftpb.StateEvent.LoadCompleted
+	42, // 51: mirbftpb.StateEvent.add_results:type_name -> mirbftpb.StateEvent.ActionResults
+	10, // 52: mirbftpb.StateEvent.Transfer:type_name -> mirbftpb.CEntry
+	43, // 53: mirbftpb.StateEvent.propose:type_name -> mirbftpb.StateEvent.Proposal
+	44, // 54: mirbftpb.StateEvent.step:type_name -> mirbftpb.StateEvent.InboundMsg
+	45, // 55: mirbftpb.StateEvent.tick:type_name -> mirbftpb.StateEvent.TickElapsed
+	46, // 56: mirbftpb.StateEvent.actions_received:type_name -> mirbftpb.StateEvent.Ready
+	47, // 57: mirbftpb.HashResult.request:type_name -> mirbftpb.HashResult.Request
+	49, // 58: mirbftpb.HashResult.batch:type_name -> mirbftpb.HashResult.Batch
+	51, // 59: mirbftpb.HashResult.epoch_change:type_name -> mirbftpb.HashResult.EpochChange
+	50, // 60: mirbftpb.HashResult.verify_batch:type_name -> mirbftpb.HashResult.VerifyBatch
+	48, // 61: mirbftpb.HashResult.verify_request:type_name -> mirbftpb.HashResult.VerifyRequest
+	1,  // 62: mirbftpb.CheckpointResult.network_state:type_name -> mirbftpb.NetworkState
+	0,  // 63: mirbftpb.StateEvent.InitialParameters.ack_resend_backoff:type_name -> mirbftpb.StateEvent.InitialParameters.Backoff
+	3,  // 64: mirbftpb.StateEvent.PersistedEntry.data:type_name -> mirbftpb.Persistent
+	18, // 65: mirbftpb.StateEvent.OutstandingRequest.request_ack:type_name -> mirbftpb.RequestAck
+	30, // 66: mirbftpb.StateEvent.ActionResults.digests:type_name -> mirbftpb.HashResult
+	31, // 67: mirbftpb.StateEvent.ActionResults.checkpoints:type_name -> mirbftpb.CheckpointResult
+	17, // 68: mirbftpb.StateEvent.Proposal.request:type_name -> mirbftpb.Request
+	11, // 69: mirbftpb.StateEvent.InboundMsg.msg:type_name -> mirbftpb.Msg
+	17, // 70: mirbftpb.HashResult.Request.request:type_name -> mirbftpb.Request
+	18, // 71: mirbftpb.HashResult.VerifyRequest.request_ack:type_name -> mirbftpb.RequestAck
+	18, // 72: mirbftpb.HashResult.Batch.request_acks:type_name -> mirbftpb.RequestAck
+	18, // 73: mirbftpb.HashResult.VerifyBatch.request_acks:type_name -> mirbftpb.RequestAck
+	24, // 74: mirbftpb.HashResult.EpochChange.epoch_change:type_name -> mirbftpb.EpochChange
+	75, // [75:75] is the sub-list for method output_type
+	75, // [75:75] is the sub-list for method input_type
+	75, // [75:75] is the sub-list for extension type_name
+	75, // [75:75] is the sub-list for extension extendee
+	0,  // [0:75] is the sub-list for field type_name
 }
 
 func init() { file_mirbft_proto_init() }
@@ -4290,7 +4788,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*SnapshotRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4302,7 +4800,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestAck); i {
+			switch v := v.(*SnapshotChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4314,7 +4812,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Preprepare); i {
+			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4326,7 +4824,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Prepare); i {
+			switch v := v.(*RequestAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4338,7 +4836,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Commit); i {
+			switch v := v.(*Preprepare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4350,7 +4848,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Checkpoint); i {
+			switch v := v.(*Prepare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4362,7 +4860,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Suspect); i {
+			switch v := v.(*Commit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4374,7 +4872,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChange); i {
+			switch v := v.(*Checkpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4386,7 +4884,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChangeAck); i {
+			switch v := v.(*Suspect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4398,7 +4896,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochConfig); i {
+			switch v := v.(*EpochChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4410,7 +4908,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewEpochConfig); i {
+			switch v := v.(*EpochChangeAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4422,7 +4920,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewEpoch); i {
+			switch v := v.(*EpochConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4434,7 +4932,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent); i {
+			switch v := v.(*NewEpochConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4446,7 +4944,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashResult); i {
+			switch v := v.(*NewEpoch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4458,7 +4956,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckpointResult); i {
+			switch v := v.(*StateEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4470,7 +4968,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkState_Config); i {
+			switch v := v.(*HashResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4482,7 +4980,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkState_Client); i {
+			switch v := v.(*CheckpointResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4494,7 +4992,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Reconfiguration_NewClient); i {
+			switch v := v.(*NetworkState_Config); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4506,7 +5004,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpochChange_SetEntry); i {
+			switch v := v.(*NetworkState_Client); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4518,7 +5016,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewEpoch_RemoteEpochChange); i {
+			switch v := v.(*Reconfiguration_NewClient); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4530,7 +5028,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_InitialParameters); i {
+			switch v := v.(*Reconfiguration_ClientWidth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4542,7 +5040,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_PersistedEntry); i {
+			switch v := v.(*EpochChange_SetEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4554,7 +5052,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_OutstandingRequest); i {
+			switch v := v.(*NewEpoch_RemoteEpochChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4566,7 +5064,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_LoadCompleted); i {
+			switch v := v.(*StateEvent_InitialParameters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4578,7 +5076,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_ActionResults); i {
+			switch v := v.(*StateEvent_PersistedEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4590,7 +5088,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_Proposal); i {
+			switch v := v.(*StateEvent_OutstandingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4602,7 +5100,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_InboundMsg); i {
+			switch v := v.(*StateEvent_LoadCompleted); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4614,7 +5112,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_TickElapsed); i {
+			switch v := v.(*StateEvent_ActionResults); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4626,7 +5124,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateEvent_Ready); i {
+			switch v := v.(*StateEvent_Proposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4638,7 +5136,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashResult_Request); i {
+			switch v := v.(*StateEvent_InboundMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4650,7 +5148,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashResult_VerifyRequest); i {
+			switch v := v.(*StateEvent_TickElapsed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4662,7 +5160,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashResult_Batch); i {
+			switch v := v.(*StateEvent_Ready); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4674,7 +5172,7 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HashResult_VerifyBatch); i {
+			switch v := v.(*HashResult_Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4686,6 +5184,42 @@ func file_mirbft_proto_init() {
 			}
 		}
 		file_mirbft_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashResult_VerifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mirbft_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashResult_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mirbft_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashResult_VerifyBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mirbft_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HashResult_EpochChange); i {
 			case 0:
 				return &v.state
@@ -4702,6 +5236,7 @@ func file_mirbft_proto_init() {
 		(*Reconfiguration_NewClient_)(nil),
 		(*Reconfiguration_RemoveClient)(nil),
 		(*Reconfiguration_NewConfig)(nil),
+		(*Reconfiguration_ClientWidth_)(nil),
 	}
 	file_mirbft_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*Persistent_QEntry)(nil),
@@ -4729,8 +5264,10 @@ func file_mirbft_proto_init() {
 		(*Msg_FetchRequest)(nil),
 		(*Msg_ForwardRequest)(nil),
 		(*Msg_RequestAck)(nil),
+		(*Msg_SnapshotRequest)(nil),
+		(*Msg_SnapshotChunk)(nil),
 	}
-	file_mirbft_proto_msgTypes[26].OneofWrappers = []interface{}{
+	file_mirbft_proto_msgTypes[28].OneofWrappers = []interface{}{
 		(*StateEvent_Initialize)(nil),
 		(*StateEvent_LoadEntry)(nil),
 		(*StateEvent_LoadRequest)(nil),
@@ -4742,7 +5279,7 @@ func file_mirbft_proto_init() {
 		(*StateEvent_Tick)(nil),
 		(*StateEvent_ActionsReceived)(nil),
 	}
-	file_mirbft_proto_msgTypes[27].OneofWrappers = []interface{}{
+	file_mirbft_proto_msgTypes[29].OneofWrappers = []interface{}{
 		(*HashResult_Request_)(nil),
 		(*HashResult_Batch_)(nil),
 		(*HashResult_EpochChange_)(nil),
@@ -4754,13 +5291,14 @@ func file_mirbft_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mirbft_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   48,
+			NumEnums:      1,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_mirbft_proto_goTypes,
 		DependencyIndexes: file_mirbft_proto_depIdxs,
+		EnumInfos:         file_mirbft_proto_enumTypes,
 		MessageInfos:      file_mirbft_proto_msgTypes,
 	}.Build()
 	File_mirbft_proto = out.File
@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/eventlog"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+)
+
+// replay reads a log and applies each event to a set of state machines,
+// so that two logs may be stepped through in lockstep.  Recorded actions
+// are not verified as the events are applied, the diff compares them.
+type replay struct {
+	name   string
+	reader *eventlog.Reader
+	s      *stateMachines
+	index  uint64
+}
+
+func newReplay(name string, input io.Reader) (*replay, error) {
+	reader, err := eventlog.NewReader(input)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "bad %s input file", name)
+	}
+
+	// The state machine logs are not of interest when diffing.
+	s := newStateMachines(ioutil.Discard, mirbft.LevelError)
+	s.unverified = true
+
+	return &replay{
+		name:   name,
+		reader: reader,
+		s:      s,
+	}, nil
+}
+
+// next returns the next event which is not excluded by node ID, or nil
+// once the log is exhausted.
+func (r *replay) next(nodeIDs []uint64) (*rpb.RecordedEvent, error) {
+	for {
+		event, err := r.reader.ReadEvent()
+		if err != nil {
+			if err == io.EOF {
+				return nil, nil
+			}
+
+			return nil, errors.WithMessagef(err, "failed reading %s input", r.name)
+		}
+
+		r.index++
+
+		if excludedByNodeID(event, nodeIDs) {
+			continue
+		}
+
+		return event, nil
+	}
+}
+
+// writeEvent prints the event along with the index it was read at.
+func (r *replay) writeEvent(output io.Writer, event *rpb.RecordedEvent, truncateBytes bool) error {
+	text, err := textFormat(event, truncateBytes)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal event")
+	}
+
+	fmt.Fprintf(output, "%s % 6d %s\n", r.name, r.index, text)
+	return nil
+}
+
+// writeStatus prints the status of the state machine of the event's node,
+// as of the last event applied.
+func (r *replay) writeStatus(output io.Writer, event *rpb.RecordedEvent) {
+	node, ok := r.s.nodes[event.NodeId]
+	if !ok {
+		fmt.Fprintf(output, "%s node %d status: not initialized\n", r.name, event.NodeId)
+		return
+	}
+
+	fmt.Fprintf(output, "%s node %d status:\n%s\n", r.name, event.NodeId, node.machine.Status().Pretty())
+}
+
+// actionsDiff returns the names of the kinds of actions which differ
+// between a and b, in the order they are declared.
+func actionsDiff(a, b *rpb.Actions) []string {
+	if a == nil {
+		a = &rpb.Actions{}
+	}
+
+	if b == nil {
+		b = &rpb.Actions{}
+	}
+
+	var result []string
+	fields := a.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		aSubset, bSubset := &rpb.Actions{}, &rpb.Actions{}
+		if a.ProtoReflect().Has(field) {
+			aSubset.ProtoReflect().Set(field, a.ProtoReflect().Get(field))
+		}
+		if b.ProtoReflect().Has(field) {
+			bSubset.ProtoReflect().Set(field, b.ProtoReflect().Get(field))
+		}
+
+		if !proto.Equal(aSubset, bSubset) {
+			result = append(result, string(field.Name()))
+		}
+	}
+
+	return result
+}
+
+// writeActionsDiff prints the kinds of actions which differ, and both
+// sets of actions.
+func writeActionsDiff(output io.Writer, aName string, a *rpb.Actions, bName string, b *rpb.Actions, truncateBytes bool) error {
+	fmt.Fprintf(output, "actions differ in: %v\n", actionsDiff(a, b))
+	for _, side := range []struct {
+		name    string
+		actions *rpb.Actions
+	}{
+		{aName, a},
+		{bName, b},
+	} {
+		text, err := textFormat(side.actions, truncateBytes)
+		if err != nil {
+			return errors.WithMessage(err, "could not marshal actions")
+		}
+		fmt.Fprintf(output, "%s actions: %s\n", side.name, text)
+	}
+
+	return nil
+}
+
+func (a *arguments) executeDiff(output io.Writer) error {
+	if a.other == nil {
+		return a.executeVerifyDiff(output)
+	}
+
+	return a.executeLockstepDiff(output)
+}
+
+// executeLockstepDiff steps through the input and the other log together,
+// reporting the first event at which they differ.  When both logs recorded
+// actions, as when recorded by different builds of the state machine, the
+// first difference is often in the recorded actions.
+func (a *arguments) executeLockstepDiff(output io.Writer) error {
+	defer a.input.Close()
+	defer a.other.Close()
+
+	left, err := newReplay("input", a.input)
+	if err != nil {
+		return err
+	}
+
+	right, err := newReplay("other", a.other)
+	if err != nil {
+		return err
+	}
+
+	for {
+		leftEvent, err := left.next(a.nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		rightEvent, err := right.next(a.nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case leftEvent == nil && rightEvent == nil:
+			fmt.Fprintf(output, "no divergence found after %d input events and %d other events\n", left.index, right.index)
+			return nil
+		case leftEvent == nil:
+			if err := right.writeEvent(output, rightEvent, !a.verboseText); err != nil {
+				return err
+			}
+			right.writeStatus(output, rightEvent)
+			return errors.Errorf("input ended at index %d, but other continues at index %d", left.index, right.index)
+		case rightEvent == nil:
+			if err := left.writeEvent(output, leftEvent, !a.verboseText); err != nil {
+				return err
+			}
+			left.writeStatus(output, leftEvent)
+			return errors.Errorf("other ended at index %d, but input continues at index %d", right.index, left.index)
+		}
+
+		if !proto.Equal(leftEvent, rightEvent) {
+			if err := left.writeEvent(output, leftEvent, !a.verboseText); err != nil {
+				return err
+			}
+
+			if err := right.writeEvent(output, rightEvent, !a.verboseText); err != nil {
+				return err
+			}
+
+			divergence := "events"
+			if leftEvent.Actions != nil && rightEvent.Actions != nil && leftEvent.NodeId == rightEvent.NodeId {
+				divergence = "actions"
+				if err := writeActionsDiff(output, left.name, leftEvent.Actions, right.name, rightEvent.Actions, !a.verboseText); err != nil {
+					return err
+				}
+			}
+
+			left.writeStatus(output, leftEvent)
+			right.writeStatus(output, rightEvent)
+
+			return errors.Errorf("%s diverge at input index %d, other index %d", divergence, left.index, right.index)
+		}
+
+		if _, err := left.s.apply(leftEvent); err != nil {
+			return errors.WithMessage(err, left.name)
+		}
+
+		if _, err := right.s.apply(rightEvent); err != nil {
+			return errors.WithMessage(err, right.name)
+		}
+	}
+}
+
+// executeVerifyDiff replays the input against this build of the state
+// machine, reporting the first recorded actions which differ from the
+// actions this build regenerates.
+func (a *arguments) executeVerifyDiff(output io.Writer) error {
+	defer a.input.Close()
+
+	r, err := newReplay("input", a.input)
+	if err != nil {
+		return err
+	}
+
+	actionsEvents := 0
+	for {
+		event, err := r.next(a.nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		if event == nil {
+			if actionsEvents == 0 {
+				return errors.Errorf("input recorded no actions to compare against after %d events", r.index)
+			}
+
+			fmt.Fprintf(output, "no divergence from the regenerated actions found after %d events, with %d recorded actions\n", r.index, actionsEvents)
+			return nil
+		}
+
+		if event.Actions == nil {
+			if _, err := r.s.apply(event); err != nil {
+				return err
+			}
+			continue
+		}
+
+		node, ok := r.s.nodes[event.NodeId]
+		if !ok {
+			return errors.Errorf("malformed log: node %d recorded actions without initializing first.", event.NodeId)
+		}
+
+		if node.results != nil {
+			// Replay has diverged from the recorded node at a rotated
+			// segment start, so there is nothing to compare.
+			continue
+		}
+
+		actionsEvents++
+
+		regenerated, recorded, match := node.compareActions(event.Actions)
+		if match {
+			continue
+		}
+
+		if err := r.writeEvent(output, event, !a.verboseText); err != nil {
+			return err
+		}
+
+		if err := writeActionsDiff(output, "recorded", recorded, "regenerated", regenerated, !a.verboseText); err != nil {
+			return err
+		}
+
+		r.writeStatus(output, event)
+
+		return errors.Errorf("actions diverge from the regenerated actions at input index %d", r.index)
+	}
+}
@@ -8,7 +8,11 @@ SPDX-License-Identifier: Apache-2.0
 // It understands the format encoded via github.com/IBM/mirbft/eventlog
 // and is able to parse and filter these log files.  It is also able to
 // play them against an identical version of the state machine for problem
-// reproduction and debugging.
+// reproduction and debugging (verifying any recorded actions against those
+// regenerated by the state machine, and refusing logs from which the recorder
+// dropped events), to summarize them per node (via the stats
+// command) for triaging large logs, and to find the first point at which
+// a log diverges from this build, or two logs diverge (via the diff command).
 package main
 
 import (
@@ -21,6 +25,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/alecthomas/kingpin.v2"
 
 	"github.com/IBM/mirbft"
@@ -41,6 +46,11 @@ var (
 		"Propose",
 		"AddResults",
 		"ActionsReceived",
+		"LoadRequest",
+		"StateTransfer",
+		"Actions",
+		"Segment",
+		"Gap",
 	}
 
 	allMsgTypes = []string{
@@ -111,6 +121,10 @@ type arguments struct {
 	notStepTypes  []string
 	statusIndices []uint64
 	verboseText   bool
+	stats         bool
+	statsFormat   string
+	diff          bool
+	other         io.ReadCloser
 }
 
 type namedLogger struct {
@@ -142,23 +156,69 @@ type stateMachines struct {
 	logLevel mirbft.LogLevel
 	nodes    map[uint64]*stateMachine
 	output   io.Writer
+
+	// segmentStarts are the nodes whose next Initialize event is the
+	// synthetic restart which begins a rotated segment.
+	segmentStarts map[uint64]struct{}
+
+	// unverified disables the verification of recorded actions as they
+	// are applied, for callers which compare the actions themselves.
+	unverified bool
 }
 
 type stateMachine struct {
 	machine        *mirbft.StateMachine
 	pendingActions *mirbft.Actions
 	executionTime  time.Duration
+
+	// loadedEntries are the entries loaded since the node initialized,
+	// until the first actions are received.  When a node starts from its
+	// bootstrap WAL, the serializer re-persists these entries, so they
+	// may prefix the first recorded actions.
+	loadedEntries []*pb.StateEvent_PersistedEntry
+
+	// results is set once the node has been restarted synthetically, as at
+	// the start of a rotated segment.  Replay then diverges from the
+	// recorded node, so recorded results are filtered to those which were
+	// requested, and recorded actions are not verified.
+	results *eventlog.ResultFilter
 }
 
 func newStateMachines(output io.Writer, logLevel mirbft.LogLevel) *stateMachines {
 	return &stateMachines{
-		output:   output,
-		logLevel: logLevel,
-		nodes:    map[uint64]*stateMachine{},
+		output:        output,
+		logLevel:      logLevel,
+		nodes:         map[uint64]*stateMachine{},
+		segmentStarts: map[uint64]struct{}{},
 	}
 }
 
 func (s *stateMachines) apply(event *rpb.RecordedEvent) (receivedActions *mirbft.Actions, err error) {
+	if event.Actions != nil {
+		node, ok := s.nodes[event.NodeId]
+		if !ok {
+			return nil, errors.Errorf("malformed log: node %d recorded actions without initializing first.", event.NodeId)
+		}
+
+		if s.unverified {
+			return nil, nil
+		}
+
+		return nil, node.verifyActions(event.NodeId, event.Actions)
+	}
+
+	if event.Segment != nil {
+		if event.Segment.WalUnknown {
+			return nil, errors.Errorf("cannot replay segment %d: the recorder did not know the WAL of node %d when it began", event.Segment.Number, event.NodeId)
+		}
+		s.segmentStarts[event.NodeId] = struct{}{}
+		return nil, nil
+	}
+
+	if event.Gap != nil {
+		return nil, errors.Errorf("cannot replay incomplete log: the recorder dropped events for node %d", event.NodeId)
+	}
+
 	var node *stateMachine
 
 	if _, ok := event.StateEvent.Type.(*pb.StateEvent_Initialize); ok {
@@ -173,6 +233,10 @@ func (s *stateMachines) apply(event *rpb.RecordedEvent) (receivedActions *mirbft
 			},
 			pendingActions: &mirbft.Actions{},
 		}
+		if _, ok := s.segmentStarts[event.NodeId]; ok {
+			delete(s.segmentStarts, event.NodeId)
+			node.results = eventlog.NewResultFilter()
+		}
 		s.nodes[event.NodeId] = node
 	} else {
 		var ok bool
@@ -188,9 +252,31 @@ func (s *stateMachines) apply(event *rpb.RecordedEvent) (receivedActions *mirbft
 		}
 	}()
 
+	stateEvent := event.StateEvent
+	if node.results != nil {
+		stateEvent = node.results.Filter(stateEvent)
+		if stateEvent == nil {
+			return nil, nil
+		}
+	}
+
 	start := time.Now()
-	actions := node.machine.ApplyEvent(event.StateEvent)
+	actions := node.machine.ApplyEvent(stateEvent)
 	node.executionTime += time.Since(start)
+
+	switch et := event.StateEvent.Type.(type) {
+	case *pb.StateEvent_LoadRequest:
+		// The serializer discards the actions resulting from loading
+		// requests, so we do as well.
+		return nil, nil
+	case *pb.StateEvent_LoadEntry:
+		node.loadedEntries = append(node.loadedEntries, et.LoadEntry)
+	}
+
+	if node.results != nil {
+		node.results.ApplyActions(actions)
+	}
+
 	node.pendingActions, err = actionsConcat(node.pendingActions, actions)
 	if err != nil {
 		return nil, err
@@ -198,11 +284,71 @@ func (s *stateMachines) apply(event *rpb.RecordedEvent) (receivedActions *mirbft
 
 	if _, ok := event.StateEvent.Type.(*pb.StateEvent_ActionsReceived); ok {
 		receivedActions, node.pendingActions = node.pendingActions, &mirbft.Actions{}
+		node.loadedEntries = nil
 	}
 
 	return receivedActions, nil
 }
 
+// verifyActions checks that the recorded actions are the actions which
+// the state machine has regenerated since they were last received.  Once
+// replay has diverged from the recorded node, the recorded actions are no
+// longer verified.
+func (sm *stateMachine) verifyActions(nodeID uint64, recorded *rpb.Actions) error {
+	if sm.results != nil {
+		return nil
+	}
+
+	regenerated, recorded, match := sm.compareActions(recorded)
+	if match {
+		return nil
+	}
+
+	recordedText, err := textFormat(recorded, true)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal recorded actions")
+	}
+
+	regeneratedText, err := textFormat(regenerated, true)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal regenerated actions")
+	}
+
+	return errors.Errorf("node %d recorded actions which differ from the regenerated actions:\n  recorded:    %s\n  regenerated: %s", nodeID, recordedText, regeneratedText)
+}
+
+// compareActions returns the actions which the state machine has regenerated
+// since they were last received, the recorded actions, and whether the two
+// match.  Both are redacted, as replay cannot regenerate request data which
+// was not recorded.
+func (sm *stateMachine) compareActions(recorded *rpb.Actions) (*rpb.Actions, *rpb.Actions, bool) {
+	regenerated := eventlog.RedactActions(eventlog.ActionsProto(sm.pendingActions))
+	recorded = eventlog.RedactActions(recorded)
+
+	if proto.Equal(regenerated, recorded) {
+		return regenerated, recorded, true
+	}
+
+	if len(sm.loadedEntries) > 0 {
+		bootstrapped := proto.Clone(regenerated).(*rpb.Actions)
+		bootstrapped.WriteAhead = nil
+		for _, entry := range sm.loadedEntries {
+			bootstrapped.WriteAhead = append(bootstrapped.WriteAhead, &rpb.Actions_Write{
+				Type: &rpb.Actions_Write_Append{
+					Append: entry,
+				},
+			})
+		}
+		bootstrapped.WriteAhead = append(bootstrapped.WriteAhead, regenerated.WriteAhead...)
+
+		if proto.Equal(bootstrapped, recorded) {
+			return bootstrapped, recorded, true
+		}
+	}
+
+	return regenerated, recorded, false
+}
+
 // actionsConcat appends the actions of o to the actions a
 func actionsConcat(a, o *mirbft.Actions) (*mirbft.Actions, error) {
 	a.Send = append(a.Send, o.Send...)
@@ -211,12 +357,18 @@ func actionsConcat(a, o *mirbft.Actions) (*mirbft.Actions, error) {
 	a.WriteAhead = append(a.WriteAhead, o.WriteAhead...)
 	a.StoreRequests = append(a.StoreRequests, o.StoreRequests...)
 	a.ForwardRequests = append(a.ForwardRequests, o.ForwardRequests...)
+	a.Misbehaviors = append(a.Misbehaviors, o.Misbehaviors...)
 	if o.StateTransfer != nil {
 		if a.StateTransfer != nil {
 			return nil, fmt.Errorf("attempted to concatenate two concurrent state transfer requests")
 		}
 		a.StateTransfer = o.StateTransfer
 	}
+	if o.StableCheckpoint != nil {
+		if a.StableCheckpoint == nil || *a.StableCheckpoint < *o.StableCheckpoint {
+			a.StableCheckpoint = o.StableCheckpoint
+		}
+	}
 	return a, nil
 }
 
@@ -323,6 +475,24 @@ func actionsString(a *mirbft.Actions, truncateBytes bool, padding int) (string,
 
 	}
 
+	if a.StableCheckpoint != nil {
+		writeLine(fmt.Sprintf("stable_checkpoint: seq_no=%d", *a.StableCheckpoint), 0)
+	}
+
+	if len(a.Misbehaviors) > 0 {
+		writeLine("misbehaviors:", 0)
+		for _, misbehavior := range a.Misbehaviors {
+			writeLine(fmt.Sprintf("{node: %d, kind: %s}", misbehavior.Node, misbehavior.Kind), 2)
+			for _, msg := range misbehavior.Msgs {
+				msgText, err := textFormat(msg, truncateBytes)
+				if err != nil {
+					return "", err
+				}
+				writeLine(fmt.Sprintf("{msg: %s}", msgText), 4)
+			}
+		}
+	}
+
 	return buffer.String(), nil
 }
 
@@ -331,92 +501,112 @@ func (s *stateMachines) status(event *rpb.RecordedEvent) *status.StateMachine {
 	return node.machine.Status()
 }
 
-func (a *arguments) shouldPrint(event *rpb.RecordedEvent) bool {
-	var eventTypeText string
-	switch event.StateEvent.Type.(type) {
+// recordedEventTypeName returns the name of the recorded event type, as
+// used by --eventType and --notEventType.
+func recordedEventTypeName(event *rpb.RecordedEvent) string {
+	switch {
+	case event.Actions != nil:
+		return "Actions"
+	case event.Segment != nil:
+		return "Segment"
+	case event.Gap != nil:
+		return "Gap"
+	default:
+		return eventTypeName(event.StateEvent)
+	}
+}
+
+// eventTypeName returns the name of the state event type, as used by
+// --eventType and --notEventType.
+func eventTypeName(stateEvent *pb.StateEvent) string {
+	switch stateEvent.Type.(type) {
 	case *pb.StateEvent_Initialize:
-		eventTypeText = "Initialize"
+		return "Initialize"
 	case *pb.StateEvent_LoadEntry:
-		eventTypeText = "LoadEntry"
+		return "LoadEntry"
+	case *pb.StateEvent_LoadRequest:
+		return "LoadRequest"
 	case *pb.StateEvent_CompleteInitialization:
-		eventTypeText = "CompleteInitialization"
+		return "CompleteInitialization"
 	case *pb.StateEvent_Tick:
-		eventTypeText = "Tick"
+		return "Tick"
 	case *pb.StateEvent_Propose:
-		eventTypeText = "Propose"
+		return "Propose"
 	case *pb.StateEvent_AddResults:
-		eventTypeText = "AddResults"
+		return "AddResults"
 	case *pb.StateEvent_ActionsReceived:
-		eventTypeText = "ActionsReceived"
+		return "ActionsReceived"
 	case *pb.StateEvent_Step:
-		eventTypeText = "Step"
+		return "Step"
 	case *pb.StateEvent_Transfer:
-		eventTypeText = "StateTransfer"
+		return "StateTransfer"
+	default:
+		panic(fmt.Sprintf("Unknown event type '%T'", stateEvent.Type))
+	}
+}
+
+// msgTypeName returns the name of the message type, as used by
+// --stepType and --notStepType.
+func msgTypeName(msg *pb.Msg) string {
+	switch msg.Type.(type) {
+	case *pb.Msg_Preprepare:
+		return "Preprepare"
+	case *pb.Msg_Prepare:
+		return "Prepare"
+	case *pb.Msg_Commit:
+		return "Commit"
+	case *pb.Msg_Checkpoint:
+		return "Checkpoint"
+	case *pb.Msg_Suspect:
+		return "Suspect"
+	case *pb.Msg_EpochChange:
+		return "EpochChange"
+	case *pb.Msg_EpochChangeAck:
+		return "EpochChangeAck"
+	case *pb.Msg_NewEpoch:
+		return "NewEpoch"
+	case *pb.Msg_NewEpochEcho:
+		return "NewEpochEcho"
+	case *pb.Msg_NewEpochReady:
+		return "NewEpochReady"
+	case *pb.Msg_FetchBatch:
+		return "FetchBatch"
+	case *pb.Msg_ForwardBatch:
+		return "ForwardBatch"
+	case *pb.Msg_FetchRequest:
+		return "FetchRequest"
+	case *pb.Msg_ForwardRequest:
+		return "ForwardRequest"
+	case *pb.Msg_RequestAck:
+		return "RequestAck"
 	default:
-		panic(fmt.Sprintf("Unknown event type '%T'", event.StateEvent.Type))
+		panic("unknown message type")
 	}
+}
 
-	if excludeByType(eventTypeText, a.eventTypes, a.notEventTypes) {
+func (a *arguments) shouldPrint(event *rpb.RecordedEvent) bool {
+	if excludeByType(recordedEventTypeName(event), a.eventTypes, a.notEventTypes) {
 		return false
 	}
 
-	switch et := event.StateEvent.Type.(type) {
-	case *pb.StateEvent_Initialize:
-	case *pb.StateEvent_LoadEntry:
-	case *pb.StateEvent_CompleteInitialization:
-	case *pb.StateEvent_Tick:
-	case *pb.StateEvent_Propose:
-	case *pb.StateEvent_AddResults:
-	case *pb.StateEvent_ActionsReceived:
-	case *pb.StateEvent_Step:
-		var stepTypeText string
-		switch et.Step.Msg.Type.(type) {
-		case *pb.Msg_Preprepare:
-			stepTypeText = "Preprepare"
-		case *pb.Msg_Prepare:
-			stepTypeText = "Prepare"
-		case *pb.Msg_Commit:
-			stepTypeText = "Commit"
-		case *pb.Msg_Checkpoint:
-			stepTypeText = "Checkpoint"
-		case *pb.Msg_Suspect:
-			stepTypeText = "Suspect"
-		case *pb.Msg_EpochChange:
-			stepTypeText = "EpochChange"
-		case *pb.Msg_EpochChangeAck:
-			stepTypeText = "EpochChangeAck"
-		case *pb.Msg_NewEpoch:
-			stepTypeText = "NewEpoch"
-		case *pb.Msg_NewEpochEcho:
-			stepTypeText = "NewEpochEcho"
-		case *pb.Msg_NewEpochReady:
-			stepTypeText = "NewEpochReady"
-		case *pb.Msg_FetchBatch:
-			stepTypeText = "FetchBatch"
-		case *pb.Msg_ForwardBatch:
-			stepTypeText = "ForwardBatch"
-		case *pb.Msg_FetchRequest:
-			stepTypeText = "FetchRequest"
-		case *pb.Msg_ForwardRequest:
-			stepTypeText = "ForwardRequest"
-		case *pb.Msg_RequestAck:
-			stepTypeText = "RequestAck"
-		default:
-			panic("unknown message type")
-		}
-		if excludeByType(stepTypeText, a.stepTypes, a.notStepTypes) {
+	if step, ok := event.StateEvent.GetType().(*pb.StateEvent_Step); ok {
+		if excludeByType(msgTypeName(step.Step.Msg), a.stepTypes, a.notStepTypes) {
 			return false
 		}
-	case *pb.StateEvent_Transfer:
-		eventTypeText = "StateTransfer"
-	default:
-		panic(fmt.Sprintf("Unknown event type '%T'", event.StateEvent.Type))
 	}
 
 	return true
 }
 
 func (a *arguments) execute(output io.Writer) error {
+	if a.stats {
+		return a.executeStats(output)
+	}
+
+	if a.diff {
+		return a.executeDiff(output)
+	}
+
 	defer a.input.Close()
 
 	s := newStateMachines(output, a.logLevel)
@@ -504,6 +694,11 @@ func (a *arguments) execute(output io.Writer) error {
 
 func parseArgs(args []string) (*arguments, error) {
 	app := kingpin.New("mircat", "Utility for processing Mir state event logs.")
+	app.Command("print", "Print the events of the log, optionally applying them to a Mir state machine.").Default()
+	statsCmd := app.Command("stats", "Replay the log and summarize it per node, to help triage large logs.")
+	statsFormat := statsCmd.Flag("format", "The format in which to output the summary.").Default("table").Enum("table", "json")
+	diffCmd := app.Command("diff", "Report the first point at which the input diverges, either from the actions this build regenerates from it, or from another log replayed in lockstep.")
+	other := diffCmd.Flag("other", "The event log to compare against the input (defaults to comparing the input's recorded actions against this build).").File()
 	input := app.Flag("input", "The input file to read (defaults to stdin).").Default(os.Stdin.Name()).File()
 	interactive := app.Flag("interactive", "Whether to apply this log to a Mir state machine.").Default("false").Bool()
 	printActions := app.Flag("printActions", "Whether to display the aggregated actions on actions received (requires interactive).").Default("false").Bool()
@@ -516,11 +711,14 @@ func parseArgs(args []string) (*arguments, error) {
 	statusIndices := app.Flag("statusIndex", "Print node status at given index in the log (repeatable).").Uint64List()
 	logLevel := app.Flag("logLevel", "When run in interactive mode, the log level for the state machine with which to output.").Enum("debug", "info", "warn", "error")
 
-	_, err := app.Parse(args)
+	command, err := app.Parse(args)
 	if err != nil {
 		return nil, err
 	}
 
+	stats := command == statsCmd.FullCommand()
+	diff := command == diffCmd.FullCommand()
+
 	switch {
 	case *eventTypes != nil && *notEventTypes != nil:
 		return nil, errors.Errorf("cannot set both --eventType and --notEventType")
@@ -532,6 +730,8 @@ func parseArgs(args []string) (*arguments, error) {
 		return nil, errors.Errorf("cannot set printActions for non-interactive playback")
 	case *logLevel != "" && !*interactive:
 		return nil, errors.Errorf("cannot set logLevel for non-interactive playback")
+	case (stats || diff) && (*interactive || *eventTypes != nil || *notEventTypes != nil || *stepTypes != nil || *notStepTypes != nil):
+		return nil, errors.Errorf("cannot combine %s with printing options, only --input and --nodeID apply", command)
 	}
 
 	mirLogLevel := mirbft.LevelInfo
@@ -547,6 +747,11 @@ func parseArgs(args []string) (*arguments, error) {
 		mirLogLevel = mirbft.LevelError
 	}
 
+	var otherInput io.ReadCloser
+	if *other != nil {
+		otherInput = *other
+	}
+
 	return &arguments{
 		input:         *input,
 		interactive:   *interactive,
@@ -559,6 +764,10 @@ func parseArgs(args []string) (*arguments, error) {
 		notStepTypes:  *notStepTypes,
 		verboseText:   *verboseText,
 		statusIndices: *statusIndices,
+		stats:         stats,
+		statsFormat:   *statsFormat,
+		diff:          diff,
+		other:         otherInput,
 	}, nil
 }
 
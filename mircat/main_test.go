@@ -3,34 +3,43 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/eventlog"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
 	"github.com/IBM/mirbft/testengine"
 )
 
-var _ = Describe("Parsing", func() {
-	var (
-		logBytes *bytes.Buffer
-	)
+// recordLog returns the gzipped event log of a test engine run in which
+// four clients each submit twenty requests to four nodes.
+func recordLog() *bytes.Buffer {
+	logBytes := &bytes.Buffer{}
+	gzWriter := gzip.NewWriter(logBytes)
 
-	BeforeEach(func() {
-		logBytes = &bytes.Buffer{}
-		gzWriter := gzip.NewWriter(logBytes)
-		defer gzWriter.Close()
+	recorder := testengine.BasicRecorder(4, 4, 20)
+	recorder.NetworkState.Config.MaxEpochLength = 200000 // XXX this works around a bug in the library for now
 
-		recorder := testengine.BasicRecorder(4, 4, 20)
-		recorder.NetworkState.Config.MaxEpochLength = 200000 // XXX this works around a bug in the library for now
+	recording, err := recorder.Recording(gzWriter)
+	Expect(err).NotTo(HaveOccurred())
 
-		recording, err := recorder.Recording(gzWriter)
-		Expect(err).NotTo(HaveOccurred())
+	_, err = recording.DrainClients(5000)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(gzWriter.Close()).To(Succeed())
 
-		_, err = recording.DrainClients(5000)
-		Expect(err).NotTo(HaveOccurred())
-	})
+	return logBytes
+}
 
+var _ = Describe("Parsing", func() {
 	It("parses a fully populated command line", func() {
 		args, err := parseArgs([]string{
 			"--input", "main.go",
@@ -93,19 +102,8 @@ var _ = Describe("Execution", func() {
 	)
 
 	BeforeEach(func() {
-		logBytes = &bytes.Buffer{}
+		logBytes = recordLog()
 		output = &bytes.Buffer{}
-		gzWriter := gzip.NewWriter(logBytes)
-		defer gzWriter.Close()
-
-		recorder := testengine.BasicRecorder(4, 4, 20)
-		recorder.NetworkState.Config.MaxEpochLength = 200000 // XXX this works around a bug in the library for now
-
-		recording, err := recorder.Recording(gzWriter)
-		Expect(err).NotTo(HaveOccurred())
-
-		_, err = recording.DrainClients(5000)
-		Expect(err).NotTo(HaveOccurred())
 
 		args = &arguments{
 			input:       ioutil.NopCloser(logBytes),
@@ -120,9 +118,697 @@ var _ = Describe("Execution", func() {
 		err := args.execute(output)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(output.String()).To(ContainSubstring(
-			"     1 [node_id=0 time=0 state_event=[initialize=[id=0 batch_size=1 heartbeat_ticks=2 suspect_ticks=4 new_epoch_timeout_ticks=8 buffer_size=5242880]]]\n" +
-				"     3 [node_id=2 time=0 state_event=[initialize=[id=2 batch_size=1 heartbeat_ticks=2 suspect_ticks=4 new_epoch_timeout_ticks=8 buffer_size=5242880]]]\n" +
+			"     1 [node_id=0 time=0 state_event=[initialize=[id=0 batch_size=1 heartbeat_ticks=2 suspect_ticks=4 new_epoch_timeout_ticks=8 buffer_size=5242880 max_batch_bytes=0 min_batch_size=0 batch_timeout_ticks=0 adaptive_batch_size=false correct_fetch_ticks=0 fetch_timeout_ticks=0 ack_resend_ticks=0 ack_resend_backoff=LINEAR ack_resend_max_ticks=0 transfer_on_checkpoint_divergence=false transfer_retry_ticks=0]]]\n" +
+				"     3 [node_id=2 time=0 state_event=[initialize=[id=2 batch_size=1 heartbeat_ticks=2 suspect_ticks=4 new_epoch_timeout_ticks=8 buffer_size=5242880 max_batch_bytes=0 min_batch_size=0 batch_timeout_ticks=0 adaptive_batch_size=false correct_fetch_ticks=0 fetch_timeout_ticks=0 ack_resend_ticks=0 ack_resend_backoff=LINEAR ack_resend_max_ticks=0 transfer_on_checkpoint_divergence=false transfer_retry_ticks=0]]]\n" +
 				"     7 [node_id=0 time=0 state_event=[complete_initialization=[]]]\n",
 		))
 	})
 })
+
+var _ = Describe("Stats", func() {
+	var output *bytes.Buffer
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+	})
+
+	It("parses the stats command", func() {
+		args, err := parseArgs([]string{
+			"stats",
+			"--input", "main.go",
+			"--nodeID", "1",
+			"--format", "json",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args.input.Close()).NotTo(HaveOccurred())
+		Expect(args.stats).To(BeTrue())
+		Expect(args.statsFormat).To(Equal("json"))
+		Expect(args.nodeIDs).To(Equal([]uint64{1}))
+	})
+
+	It("rejects printing options", func() {
+		_, err := parseArgs([]string{
+			"stats",
+			"--eventType", "Step",
+		})
+		Expect(err).To(MatchError("cannot combine stats with printing options, only --input and --nodeID apply"))
+	})
+
+	It("summarizes each node as json", func() {
+		args := &arguments{
+			input:       ioutil.NopCloser(recordLog()),
+			nodeIDs:     []uint64{0, 2},
+			stats:       true,
+			statsFormat: "json",
+		}
+
+		err := args.execute(output)
+		Expect(err).NotTo(HaveOccurred())
+
+		var stats []*nodeStats
+		Expect(json.Unmarshal(output.Bytes(), &stats)).To(Succeed())
+		Expect(stats).To(HaveLen(2))
+		for i, nodeID := range []uint64{0, 2} {
+			ns := stats[i]
+			Expect(ns.NodeID).To(Equal(nodeID))
+			Expect(ns.EventTypes["Initialize"]).To(Equal(uint64(1)))
+			Expect(ns.EventTypes["Step"]).To(Equal(ns.Events - sumExcept(ns.EventTypes, "Step")))
+			Expect(ns.MsgTypes["Checkpoint"]).NotTo(BeZero())
+			Expect(ns.CommittedRequests).To(Equal(uint64(80)))
+			Expect(ns.CommitLatency.Count).To(Equal(uint64(80)))
+			Expect(ns.CommitLatency.Max).To(BeNumerically(">=", ns.CommitLatency.P99))
+			Expect(ns.CommitLatency.P99).To(BeNumerically(">=", ns.CommitLatency.P50))
+			Expect(ns.Checkpoints).NotTo(BeEmpty())
+			Expect(ns.EpochTimeline).To(HaveLen(2))
+			Expect(ns.EpochTimeline[0].Event).To(Equal("epoch_change"))
+			Expect(ns.EpochTimeline[1].Event).To(Equal("new_epoch"))
+			Expect(ns.EpochTimeline[1].Epoch).To(Equal(uint64(1)))
+		}
+	})
+
+	It("summarizes each node as tables", func() {
+		args := &arguments{
+			input: ioutil.NopCloser(recordLog()),
+			stats: true,
+		}
+
+		err := args.execute(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring("node  events  batches  requests  commits/s"))
+		Expect(output.String()).To(MatchRegexp(`\n\s+Preprepare\s+80\s+80\s+80\s+80\s*\n`))
+		Expect(output.String()).To(ContainSubstring("epoch timeline for node 3"))
+	})
+})
+
+func sumExcept(counts map[string]uint64, except string) uint64 {
+	total := uint64(0)
+	for name, count := range counts {
+		if name != except {
+			total += count
+		}
+	}
+	return total
+}
+
+var _ = Describe("Diff", func() {
+	var (
+		logBytes   *bytes.Buffer
+		otherBytes *bytes.Buffer
+		output     *bytes.Buffer
+		args       *arguments
+	)
+
+	BeforeEach(func() {
+		logBytes = recordLog()
+		otherBytes = recordLog()
+		output = &bytes.Buffer{}
+	})
+
+	JustBeforeEach(func() {
+		args = &arguments{
+			input: ioutil.NopCloser(logBytes),
+			other: ioutil.NopCloser(otherBytes),
+			diff:  true,
+		}
+	})
+
+	It("parses the diff command", func() {
+		args, err := parseArgs([]string{
+			"diff",
+			"--input", "main.go",
+			"--other", "main_test.go",
+			"--nodeID", "1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args.input.Close()).NotTo(HaveOccurred())
+		Expect(args.other.Close()).NotTo(HaveOccurred())
+		Expect(args.diff).To(BeTrue())
+		Expect(args.nodeIDs).To(Equal([]uint64{1}))
+	})
+
+	It("finds no divergence between identical recordings", func() {
+		err := args.execute(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(HavePrefix("no divergence found after"))
+	})
+
+	When("the recordings diverge", func() {
+		BeforeEach(func() {
+			reader, err := eventlog.NewReader(otherBytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBytes = &bytes.Buffer{}
+			gzWriter := gzip.NewWriter(otherBytes)
+			defer gzWriter.Close()
+
+			for i := 1; ; i++ {
+				event, err := reader.ReadEvent()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+
+				if i == 100 {
+					event.Time++
+				}
+
+				Expect(eventlog.WriteRecordedEvent(gzWriter, event)).To(Succeed())
+			}
+		})
+
+		It("reports the first diverging event", func() {
+			err := args.execute(output)
+			Expect(err).To(MatchError("events diverge at input index 100, other index 100"))
+			Expect(output.String()).To(MatchRegexp(`^input    100 \[node_id=\d+ time=\d+ .*\nother    100 \[node_id=\d+ time=\d+ `))
+			Expect(output.String()).To(MatchRegexp(`\ninput node \d+ status:\n`))
+			Expect(output.String()).To(MatchRegexp(`\nother node \d+ status:\n`))
+		})
+	})
+
+	When("the recordings include actions", func() {
+		var mangle func(*rpb.Actions)
+
+		BeforeEach(func() {
+			mangle = nil
+		})
+
+		JustBeforeEach(func() {
+			logBytes = recordWithActions(0, nil)
+			otherBytes = recordWithActions(20, mangle)
+			args.input = ioutil.NopCloser(logBytes)
+			args.other = ioutil.NopCloser(otherBytes)
+		})
+
+		It("finds no divergence between identical recordings", func() {
+			err := args.execute(output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(HavePrefix("no divergence found after"))
+		})
+
+		When("the recorded actions differ", func() {
+			BeforeEach(func() {
+				mangle = func(actions *rpb.Actions) {
+					actions.StableCheckpoint = &rpb.Actions_StableCheckpoint{
+						SeqNo: 1000,
+					}
+				}
+			})
+
+			It("reports the first diverging actions", func() {
+				err := args.execute(output)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(MatchRegexp(`^actions diverge at input index \d+, other index \d+$`))
+				Expect(output.String()).To(ContainSubstring("actions differ in: [stable_checkpoint]\n"))
+				Expect(output.String()).To(ContainSubstring("stable_checkpoint=[seq_no=1000]"))
+				Expect(output.String()).To(MatchRegexp(`\ninput node \d+ status:\n`))
+				Expect(output.String()).To(MatchRegexp(`\nother node \d+ status:\n`))
+			})
+		})
+	})
+
+	When("no other log is given", func() {
+		var mangle func(*rpb.Actions)
+
+		BeforeEach(func() {
+			mangle = nil
+		})
+
+		JustBeforeEach(func() {
+			logBytes = recordWithActions(20, mangle)
+			args.input = ioutil.NopCloser(logBytes)
+			args.other = nil
+		})
+
+		It("parses the diff command", func() {
+			args, err := parseArgs([]string{
+				"diff",
+				"--input", "main.go",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.input.Close()).NotTo(HaveOccurred())
+			Expect(args.other).To(BeNil())
+			Expect(args.diff).To(BeTrue())
+		})
+
+		It("finds no divergence from the regenerated actions", func() {
+			err := args.execute(output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(HavePrefix("no divergence from the regenerated actions found after"))
+		})
+
+		It("requires the input to have recorded actions", func() {
+			args.input = ioutil.NopCloser(recordLog())
+			err := args.execute(output)
+			Expect(err).To(MatchError(MatchRegexp(`^input recorded no actions to compare against after \d+ events$`)))
+		})
+
+		When("the recorded actions differ from the regenerated actions", func() {
+			BeforeEach(func() {
+				mangle = func(actions *rpb.Actions) {
+					actions.Send = actions.Send[1:]
+				}
+			})
+
+			It("reports the first diverging actions", func() {
+				err := args.execute(output)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(MatchRegexp(`^actions diverge from the regenerated actions at input index \d+$`))
+				Expect(output.String()).To(MatchRegexp(`^input +\d+ \[node_id=\d+ time=\d+ actions=\[`))
+				Expect(output.String()).To(ContainSubstring("actions differ in: [send]\n"))
+				Expect(output.String()).To(ContainSubstring("\nrecorded actions: "))
+				Expect(output.String()).To(ContainSubstring("\nregenerated actions: "))
+				Expect(output.String()).To(MatchRegexp(`\ninput node \d+ status:\n`))
+			})
+		})
+	})
+
+	Describe("actionsDiff", func() {
+		It("names the kinds of actions which differ", func() {
+			seqNo := uint64(5)
+			a := &mirbft.Actions{
+				Send: []mirbft.Send{
+					{
+						Targets: []uint64{1, 2},
+						Msg: &pb.Msg{
+							Type: &pb.Msg_Suspect{
+								Suspect: &pb.Suspect{Epoch: 3},
+							},
+						},
+					},
+				},
+				StableCheckpoint: &seqNo,
+			}
+
+			b := &mirbft.Actions{
+				Send: []mirbft.Send{
+					{
+						Targets: []uint64{1, 3},
+						Msg:     a.Send[0].Msg,
+					},
+				},
+				WriteAhead: []*mirbft.Write{
+					{
+						Truncate: &seqNo,
+					},
+				},
+				StableCheckpoint: &seqNo,
+			}
+
+			differing := actionsDiff(eventlog.ActionsProto(a), eventlog.ActionsProto(b))
+			Expect(differing).To(Equal([]string{"send", "write_ahead"}))
+
+			differing = actionsDiff(eventlog.ActionsProto(a), eventlog.ActionsProto(a))
+			Expect(differing).To(BeEmpty())
+		})
+	})
+
+	Describe("actionsConcat", func() {
+		It("keeps the highest stable checkpoint", func() {
+			low, high := uint64(5), uint64(10)
+
+			result, err := actionsConcat(&mirbft.Actions{StableCheckpoint: &high}, &mirbft.Actions{StableCheckpoint: &low})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*result.StableCheckpoint).To(Equal(high))
+
+			result, err = actionsConcat(&mirbft.Actions{StableCheckpoint: &low}, &mirbft.Actions{StableCheckpoint: &high})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*result.StableCheckpoint).To(Equal(high))
+
+			result, err = actionsConcat(&mirbft.Actions{StableCheckpoint: &low}, &mirbft.Actions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*result.StableCheckpoint).To(Equal(low))
+		})
+	})
+})
+
+// recordWithActions records a test engine run along with its actions.  If
+// mangle is set, it is applied to the recorded actions of the given index
+// among all recorded actions events.
+func recordWithActions(mangleIndex int, mangle func(*rpb.Actions)) *bytes.Buffer {
+	// The test engine does not record actions, so insert the actions
+	// regenerated by replay, as the serializer would have recorded them.
+	reader, err := eventlog.NewReader(recordLog())
+	Expect(err).NotTo(HaveOccurred())
+
+	logBytes := &bytes.Buffer{}
+	gzWriter := gzip.NewWriter(logBytes)
+	defer gzWriter.Close()
+
+	s := newStateMachines(ioutil.Discard, mirbft.LevelError)
+	actionsIndex := 0
+	for {
+		event, err := reader.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		if _, ok := event.StateEvent.Type.(*pb.StateEvent_ActionsReceived); ok {
+			actions := eventlog.ActionsProto(s.nodes[event.NodeId].pendingActions)
+			if mangle != nil && actionsIndex == mangleIndex {
+				mangle(actions)
+			}
+			actionsIndex++
+
+			Expect(eventlog.WriteRecordedEvent(gzWriter, &rpb.RecordedEvent{
+				NodeId:  event.NodeId,
+				Time:    event.Time,
+				Actions: actions,
+			})).To(Succeed())
+		}
+
+		_, err = s.apply(event)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(eventlog.WriteRecordedEvent(gzWriter, event)).To(Succeed())
+	}
+
+	return logBytes
+}
+
+var _ = Describe("Recorded actions", func() {
+	var (
+		logBytes *bytes.Buffer
+		output   *bytes.Buffer
+		args     *arguments
+
+		// mangle, if set, is applied to the recorded actions of the
+		// given index among all recorded actions events.
+		mangleIndex int
+		mangle      func(*rpb.Actions)
+	)
+
+	BeforeEach(func() {
+		mangle = nil
+		output = &bytes.Buffer{}
+	})
+
+	JustBeforeEach(func() {
+		logBytes = recordWithActions(mangleIndex, mangle)
+	})
+
+	JustBeforeEach(func() {
+		args = &arguments{
+			input:       ioutil.NopCloser(logBytes),
+			eventTypes:  []string{"Actions"},
+			interactive: true,
+		}
+	})
+
+	It("verifies the recorded actions match the regenerated ones", func() {
+		err := args.execute(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(MatchRegexp(`\n +\d+ \[node_id=\d+ time=\d+ actions=\[`))
+		Expect(output.String()).To(ContainSubstring("Node 3 successfully completed execution"))
+	})
+
+	When("the recorded actions differ", func() {
+		BeforeEach(func() {
+			mangleIndex = 20
+			mangle = func(actions *rpb.Actions) {
+				actions.StableCheckpoint = &rpb.Actions_StableCheckpoint{
+					SeqNo: 1000,
+				}
+			}
+		})
+
+		It("returns an error", func() {
+			err := args.execute(output)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp(`^node \d+ recorded actions which differ from the regenerated actions:`))
+			Expect(err.Error()).To(ContainSubstring("stable_checkpoint=[seq_no=1000]"))
+		})
+	})
+})
+
+var _ = Describe("Rotated segments", func() {
+	var (
+		dir      string
+		segments []string
+
+		// skip is the number of node 1's events which occur before
+		// the rotating recorder begins recording.
+		skip int
+	)
+
+	BeforeEach(func() {
+		skip = 0
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "mircat-segments")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Feed node 1's events through a rotating recorder, as the
+		// serializer would, along with the actions it handed out.
+		reader, err := eventlog.NewReader(recordLog())
+		Expect(err).NotTo(HaveOccurred())
+
+		rotatingRecorder, err := eventlog.NewRotatingRecorder(
+			1,
+			dir,
+			eventlog.RecordActionsOpt(),
+			eventlog.MaxSegmentEventsOpt(200),
+			eventlog.MaxSegmentsOpt(3),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := newStateMachines(ioutil.Discard, mirbft.LevelError)
+		nodeEvents := 0
+		for {
+			event, err := reader.ReadEvent()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			if event.NodeId != 1 {
+				continue
+			}
+
+			nodeEvents++
+			recording := nodeEvents > skip
+
+			if _, ok := event.StateEvent.Type.(*pb.StateEvent_ActionsReceived); ok && recording {
+				Expect(rotatingRecorder.InterceptActions(s.nodes[1].pendingActions)).To(Succeed())
+			}
+
+			_, err = s.apply(event)
+			Expect(err).NotTo(HaveOccurred())
+
+			if recording {
+				Expect(rotatingRecorder.Intercept(event.StateEvent)).To(Succeed())
+			}
+		}
+
+		Expect(rotatingRecorder.Stop()).To(Succeed())
+
+		segments, err = eventlog.Segments(dir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("retains the most recent segments", func() {
+		Expect(segments).To(HaveLen(3))
+		number, ok := segmentNumberOf(segments[0])
+		Expect(ok).To(BeTrue())
+		Expect(number).To(BeNumerically(">", 0))
+	})
+
+	It("replays each segment on its own", func() {
+		for _, segment := range segments {
+			By("replaying " + segment + " interactively")
+			input, err := os.Open(segment)
+			Expect(err).NotTo(HaveOccurred())
+
+			output := &bytes.Buffer{}
+			args := &arguments{
+				input:       input,
+				eventTypes:  []string{"Segment"},
+				interactive: true,
+			}
+			Expect(args.execute(output)).To(Succeed())
+			Expect(output.String()).To(MatchRegexp(`^ +1 \[node_id=1 time=\d+ segment=\[number=\d+ wal_unknown=false\]\]\n`))
+			Expect(output.String()).To(ContainSubstring("Node 1 successfully completed execution"))
+
+			By("playing back " + segment)
+			input, err = os.Open(segment)
+			Expect(err).NotTo(HaveOccurred())
+			eventLog, err := testengine.ReadEventLog(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(input.Close()).To(Succeed())
+
+			player, err := testengine.NewPlayer(eventLog, ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+			for eventLog.List.Len() > 0 {
+				Expect(player.Step()).To(Succeed())
+			}
+		}
+	})
+
+	When("the recording begins after the node initialized", func() {
+		BeforeEach(func() {
+			skip = 50
+		})
+
+		It("refuses to replay a segment, as its WAL is unknown", func() {
+			number, ok := segmentNumberOf(segments[0])
+			Expect(ok).To(BeTrue())
+
+			input, err := os.Open(segments[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			args := &arguments{
+				input:       input,
+				eventTypes:  []string{"Segment"},
+				interactive: true,
+			}
+			err = args.execute(&bytes.Buffer{})
+			Expect(err).To(MatchError(fmt.Sprintf("cannot replay segment %d: the recorder did not know the WAL of node 1 when it began", number)))
+
+			input, err = os.Open(segments[0])
+			Expect(err).NotTo(HaveOccurred())
+			eventLog, err := testengine.ReadEventLog(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(input.Close()).To(Succeed())
+
+			player, err := testengine.NewPlayer(eventLog, ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(player.Step()).To(MatchError(fmt.Sprintf("cannot play segment %d: the recorder did not know the WAL of node 1 when it began", number)))
+		})
+
+		It("counts no dropped events", func() {
+			input, err := os.Open(segments[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			output := &bytes.Buffer{}
+			args := &arguments{
+				input:       input,
+				stats:       true,
+				statsFormat: "json",
+			}
+			Expect(args.execute(output)).To(Succeed())
+
+			var stats []*nodeStats
+			Expect(json.Unmarshal(output.Bytes(), &stats)).To(Succeed())
+			Expect(stats).To(HaveLen(1))
+			Expect(stats[0].DroppedEvents).To(BeZero())
+			Expect(stats[0].EventTypes).NotTo(HaveKey("Gap"))
+
+			// The node is not replayed without its WAL
+			Expect(stats[0].CommittedRequests).To(BeZero())
+		})
+	})
+})
+
+func segmentNumberOf(path string) (uint64, bool) {
+	var number uint64
+	_, err := fmt.Sscanf(filepath.Base(path), "eventlog-%d.gz", &number)
+	return number, err == nil
+}
+
+var _ = Describe("Incomplete logs", func() {
+	var (
+		logBytes []byte
+		output   *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		// Drop five of node 1's events, as a recorder which
+		// overflowed would have, recording the gap in their place.
+		reader, err := eventlog.NewReader(recordLog())
+		Expect(err).NotTo(HaveOccurred())
+
+		buffer := &bytes.Buffer{}
+		gzWriter := gzip.NewWriter(buffer)
+
+		nodeEvents := 0
+		for {
+			event, err := reader.ReadEvent()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			if event.NodeId == 1 {
+				nodeEvents++
+				switch {
+				case nodeEvents == 100:
+					Expect(eventlog.WriteRecordedEvent(gzWriter, &rpb.RecordedEvent{
+						NodeId: 1,
+						Time:   event.Time,
+						Gap: &rpb.Gap{
+							Dropped: 5,
+						},
+					})).To(Succeed())
+					continue
+				case nodeEvents > 100 && nodeEvents < 105:
+					continue
+				}
+			}
+
+			Expect(eventlog.WriteRecordedEvent(gzWriter, event)).To(Succeed())
+		}
+		Expect(gzWriter.Close()).To(Succeed())
+
+		logBytes = buffer.Bytes()
+		output = &bytes.Buffer{}
+	})
+
+	It("prints the gap", func() {
+		args := &arguments{
+			input:      ioutil.NopCloser(bytes.NewReader(logBytes)),
+			eventTypes: []string{"Gap"},
+		}
+		Expect(args.execute(output)).To(Succeed())
+		Expect(output.String()).To(MatchRegexp(`^ +\d+ \[node_id=1 time=\d+ gap=\[dropped=5\]\]\n$`))
+	})
+
+	It("refuses to replay the log interactively", func() {
+		args := &arguments{
+			input:       ioutil.NopCloser(bytes.NewReader(logBytes)),
+			eventTypes:  []string{"Gap"},
+			interactive: true,
+		}
+		err := args.execute(output)
+		Expect(err).To(MatchError("cannot replay incomplete log: the recorder dropped events for node 1"))
+	})
+
+	It("counts the dropped events", func() {
+		args := &arguments{
+			input:       ioutil.NopCloser(bytes.NewReader(logBytes)),
+			nodeIDs:     []uint64{1},
+			stats:       true,
+			statsFormat: "json",
+		}
+		Expect(args.execute(output)).To(Succeed())
+
+		var stats []*nodeStats
+		Expect(json.Unmarshal(output.Bytes(), &stats)).To(Succeed())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].DroppedEvents).To(Equal(uint64(5)))
+		Expect(stats[0].EventTypes["Gap"]).To(Equal(uint64(1)))
+
+		// The node is not replayed past the gap
+		Expect(stats[0].CommittedRequests).To(BeNumerically("<", 80))
+	})
+
+	It("refuses to play back the log", func() {
+		eventLog, err := testengine.ReadEventLog(bytes.NewReader(logBytes))
+		Expect(err).NotTo(HaveOccurred())
+
+		player, err := testengine.NewPlayer(eventLog, ioutil.Discard)
+		Expect(err).NotTo(HaveOccurred())
+		for {
+			err = player.Step()
+			if err != nil {
+				break
+			}
+		}
+		Expect(err).To(MatchError("cannot play incomplete log: the recorder dropped events for node 1"))
+	})
+})
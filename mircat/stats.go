@@ -0,0 +1,422 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/eventlog"
+	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+// nodeStats summarizes the events of a single node in a log.  All times
+// are the recorded event times, in (possibly simulated) milliseconds.
+type nodeStats struct {
+	NodeID            uint64            `json:"node_id"`
+	Events            uint64            `json:"events"`
+	FirstEventTime    int64             `json:"first_event_time"`
+	LastEventTime     int64             `json:"last_event_time"`
+	EventTypes        map[string]uint64 `json:"event_types"`
+	MsgTypes          map[string]uint64 `json:"msg_types"`
+	CommittedBatches  uint64            `json:"committed_batches"`
+	CommittedRequests uint64            `json:"committed_requests"`
+
+	// DroppedEvents is the number of events the recorder dropped rather
+	// than block the node, as recorded by gap markers.  The node cannot
+	// be replayed from a gap, nor from a segment which began without its
+	// WAL, until it is next initialized, so commits in the meantime are
+	// not counted.
+	DroppedEvents uint64 `json:"dropped_events"`
+
+	// CommitsPerSecond is the number of batches committed in each
+	// second of the log, beginning from time zero.
+	CommitsPerSecond []uint64        `json:"commits_per_second"`
+	CommitLatency    *latencyStats   `json:"commit_latency"`
+	Checkpoints      []*checkpointAt `json:"checkpoints"`
+	EpochTimeline    []*epochEvent   `json:"epoch_timeline"`
+
+	proposals   map[clientReqNo]int64
+	latencies   []int64
+	activeEpoch *uint64
+	gapped      bool
+}
+
+type clientReqNo struct {
+	clientID uint64
+	reqNo    uint64
+}
+
+// latencyStats describes the time from a request being proposed to
+// a node until that node committing it.
+type latencyStats struct {
+	Count uint64 `json:"count"`
+	Mean  int64  `json:"mean"`
+	P50   int64  `json:"p50"`
+	P99   int64  `json:"p99"`
+	Max   int64  `json:"max"`
+}
+
+type checkpointAt struct {
+	Time  int64  `json:"time"`
+	SeqNo uint64 `json:"seq_no"`
+}
+
+// epochEvent is a point in the epoch-change timeline of a node, one of
+// "suspect", "epoch_change", "new_epoch", or "end_epoch".
+type epochEvent struct {
+	Time  int64  `json:"time"`
+	Event string `json:"event"`
+	Epoch uint64 `json:"epoch"`
+}
+
+func newNodeStats(nodeID uint64) *nodeStats {
+	return &nodeStats{
+		NodeID:     nodeID,
+		EventTypes: map[string]uint64{},
+		MsgTypes:   map[string]uint64{},
+		proposals:  map[clientReqNo]int64{},
+	}
+}
+
+func (ns *nodeStats) applyEvent(event *rpb.RecordedEvent) {
+	if ns.Events == 0 {
+		ns.FirstEventTime = event.Time
+	}
+	ns.Events++
+	ns.LastEventTime = event.Time
+
+	ns.EventTypes[recordedEventTypeName(event)]++
+
+	if event.Gap != nil {
+		ns.DroppedEvents += event.Gap.Dropped
+	}
+
+	switch et := event.StateEvent.GetType().(type) {
+	case *pb.StateEvent_Step:
+		ns.MsgTypes[msgTypeName(et.Step.Msg)]++
+	case *pb.StateEvent_Propose:
+		key := clientReqNo{
+			clientID: et.Propose.Request.ClientId,
+			reqNo:    et.Propose.Request.ReqNo,
+		}
+		if _, ok := ns.proposals[key]; !ok {
+			ns.proposals[key] = event.Time
+		}
+	}
+}
+
+func (ns *nodeStats) applyActions(time int64, actions *mirbft.Actions) {
+	for _, commit := range actions.Commits {
+		if commit.Batch == nil {
+			continue
+		}
+
+		ns.CommittedBatches++
+		second := int(time / 1000)
+		for len(ns.CommitsPerSecond) <= second {
+			ns.CommitsPerSecond = append(ns.CommitsPerSecond, 0)
+		}
+		ns.CommitsPerSecond[second]++
+
+		for _, request := range commit.Batch.Requests {
+			ns.CommittedRequests++
+			key := clientReqNo{
+				clientID: request.ClientId,
+				reqNo:    request.ReqNo,
+			}
+			proposed, ok := ns.proposals[key]
+			if !ok {
+				// Proposed to some other node, or before the log began
+				continue
+			}
+			delete(ns.proposals, key)
+			ns.latencies = append(ns.latencies, time-proposed)
+		}
+	}
+
+	for _, write := range actions.WriteAhead {
+		if write.Append == nil {
+			continue
+		}
+
+		switch d := write.Append.Data.Type.(type) {
+		case *pb.Persistent_CEntry:
+			ns.Checkpoints = append(ns.Checkpoints, &checkpointAt{
+				Time:  time,
+				SeqNo: d.CEntry.SeqNo,
+			})
+		case *pb.Persistent_Suspect:
+			ns.EpochTimeline = append(ns.EpochTimeline, &epochEvent{
+				Time:  time,
+				Event: "suspect",
+				Epoch: d.Suspect.Epoch,
+			})
+		case *pb.Persistent_ECEntry:
+			ns.EpochTimeline = append(ns.EpochTimeline, &epochEvent{
+				Time:  time,
+				Event: "epoch_change",
+				Epoch: d.ECEntry.EpochNumber,
+			})
+		case *pb.Persistent_NEntry:
+			if ns.activeEpoch != nil && *ns.activeEpoch == d.NEntry.EpochConfig.Number {
+				// An NEntry is persisted each time the epoch's watermarks
+				// move, only the first marks the start of the epoch.
+				continue
+			}
+			ns.activeEpoch = &d.NEntry.EpochConfig.Number
+			ns.EpochTimeline = append(ns.EpochTimeline, &epochEvent{
+				Time:  time,
+				Event: "new_epoch",
+				Epoch: d.NEntry.EpochConfig.Number,
+			})
+		case *pb.Persistent_FEntry:
+			ns.EpochTimeline = append(ns.EpochTimeline, &epochEvent{
+				Time:  time,
+				Event: "end_epoch",
+				Epoch: d.FEntry.EndsEpochConfig.Number,
+			})
+		}
+	}
+}
+
+// finish computes the summary statistics once all events are applied.
+func (ns *nodeStats) finish() {
+	ns.CommitLatency = &latencyStats{}
+	if len(ns.latencies) == 0 {
+		return
+	}
+
+	sort.Slice(ns.latencies, func(i, j int) bool {
+		return ns.latencies[i] < ns.latencies[j]
+	})
+
+	var total int64
+	for _, latency := range ns.latencies {
+		total += latency
+	}
+
+	count := len(ns.latencies)
+	ns.CommitLatency = &latencyStats{
+		Count: uint64(count),
+		Mean:  total / int64(count),
+		P50:   ns.latencies[(count-1)*50/100],
+		P99:   ns.latencies[(count-1)*99/100],
+		Max:   ns.latencies[count-1],
+	}
+}
+
+// meanCommitsPerSecond is the mean commit rate over the seconds
+// in which this node was recording events.
+func (ns *nodeStats) meanCommitsPerSecond() float64 {
+	duration := ns.LastEventTime - ns.FirstEventTime
+	if duration <= 0 {
+		return 0
+	}
+
+	return float64(ns.CommittedBatches) * 1000 / float64(duration)
+}
+
+func (ns *nodeStats) peakCommitsPerSecond() uint64 {
+	var peak uint64
+	for _, commits := range ns.CommitsPerSecond {
+		if commits > peak {
+			peak = commits
+		}
+	}
+
+	return peak
+}
+
+// meanCheckpointInterval is the mean time between checkpoints.
+func (ns *nodeStats) meanCheckpointInterval() int64 {
+	if len(ns.Checkpoints) < 2 {
+		return 0
+	}
+
+	first, last := ns.Checkpoints[0], ns.Checkpoints[len(ns.Checkpoints)-1]
+	return (last.Time - first.Time) / int64(len(ns.Checkpoints)-1)
+}
+
+// collectStats replays the log, and returns the stats of each node in
+// node order.
+func (a *arguments) collectStats() ([]*nodeStats, error) {
+	defer a.input.Close()
+
+	// The state machine logs are not of interest when summarizing.
+	s := newStateMachines(ioutil.Discard, mirbft.LevelError)
+
+	reader, err := eventlog.NewReader(a.input)
+	if err != nil {
+		return nil, errors.WithMessage(err, "bad input file")
+	}
+
+	stats := map[uint64]*nodeStats{}
+	for {
+		event, err := reader.ReadEvent()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, errors.WithMessage(err, "failed reading input")
+		}
+
+		if excludedByNodeID(event, a.nodeIDs) {
+			continue
+		}
+
+		ns, ok := stats[event.NodeId]
+		if !ok {
+			ns = newNodeStats(event.NodeId)
+			stats[event.NodeId] = ns
+		}
+
+		ns.applyEvent(event)
+
+		switch {
+		case event.Gap != nil, event.Segment.GetWalUnknown():
+			ns.gapped = true
+		case event.StateEvent.GetInitialize() != nil:
+			ns.gapped = false
+		}
+
+		if ns.gapped {
+			continue
+		}
+
+		actions, err := s.apply(event)
+		if err != nil {
+			return nil, err
+		}
+
+		if actions != nil {
+			ns.applyActions(event.Time, actions)
+		}
+	}
+
+	result := make([]*nodeStats, 0, len(stats))
+	for _, ns := range stats {
+		ns.finish()
+		result = append(result, ns)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeID < result[j].NodeID
+	})
+
+	return result, nil
+}
+
+func (a *arguments) executeStats(output io.Writer) error {
+	stats, err := a.collectStats()
+	if err != nil {
+		return err
+	}
+
+	if a.statsFormat == "json" {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		return errors.WithMessage(encoder.Encode(stats), "could not encode stats")
+	}
+
+	return errors.WithMessage(writeStatsTables(output, stats), "could not write stats")
+}
+
+func writeStatsTables(output io.Writer, stats []*nodeStats) error {
+	tw := tabwriter.NewWriter(output, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "node\tevents\tbatches\trequests\tcommits/s\tpeak/s\tlatency ms mean\tp50\tp99\tmax\tcheckpoints\tinterval ms\tepoch changes\tdropped\t")
+	for _, ns := range stats {
+		epochChanges := 0
+		for _, event := range ns.EpochTimeline {
+			if event.Event == "epoch_change" {
+				epochChanges++
+			}
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%.2f\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			ns.NodeID,
+			ns.Events,
+			ns.CommittedBatches,
+			ns.CommittedRequests,
+			ns.meanCommitsPerSecond(),
+			ns.peakCommitsPerSecond(),
+			ns.CommitLatency.Mean,
+			ns.CommitLatency.P50,
+			ns.CommitLatency.P99,
+			ns.CommitLatency.Max,
+			len(ns.Checkpoints),
+			ns.meanCheckpointInterval(),
+			epochChanges,
+			ns.DroppedEvents,
+		)
+	}
+	fmt.Fprintln(tw)
+
+	writeCounts := func(title string, names []string, counts func(*nodeStats) map[string]uint64) {
+		header := []string{title}
+		for _, ns := range stats {
+			header = append(header, fmt.Sprintf("node %d", ns.NodeID))
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+
+		for _, name := range names {
+			row := []string{name}
+			total := uint64(0)
+			for _, ns := range stats {
+				count := counts(ns)[name]
+				total += count
+				row = append(row, fmt.Sprintf("%d", count))
+			}
+			if total == 0 {
+				continue
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t")+"\t")
+		}
+		fmt.Fprintln(tw)
+	}
+
+	writeCounts("event type", allEventTypes, func(ns *nodeStats) map[string]uint64 {
+		return ns.EventTypes
+	})
+
+	writeCounts("msg type", allMsgTypes, func(ns *nodeStats) map[string]uint64 {
+		return ns.MsgTypes
+	})
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, ns := range stats {
+		if len(ns.EpochTimeline) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(output, "epoch timeline for node %d\n", ns.NodeID)
+		tw := tabwriter.NewWriter(output, 0, 4, 2, ' ', tabwriter.AlignRight)
+		for _, event := range ns.EpochTimeline {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t\n", event.Time, event.Event, event.Epoch)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(output)
+	}
+
+	return nil
+}
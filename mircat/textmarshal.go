@@ -56,10 +56,19 @@ func (te *textEncoder) marshalMessage(m pref.Message) error {
 		if od := fd.ContainingOneof(); od != nil {
 			fd = m.WhichOneof(od)
 			i += od.Fields().Len()
+			if fd == nil {
+				continue
+			}
 		} else {
 			i++
 		}
 
+		if fd.Kind() == pref.MessageKind && !fd.IsList() && !m.Has(fd) {
+			// Unset messages, like unset oneofs, are omitted
+			// rather than printed as if empty.
+			continue
+		}
+
 		name := fd.Name()
 		// Use type name for group field name.
 		if fd.Kind() == pref.GroupKind {
@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -28,6 +29,9 @@ func newNodeBuffers(myConfig *pb.StateEvent_InitialParameters, logger Logger) *n
 	}
 }
 
+// nodeBuffer returns the buffer accounting for the messages held from
+// source.  It is shared by every component buffering for that node, so
+// that BufferSize bounds the total held per node, not per component.
 func (nbs *nodeBuffers) nodeBuffer(source nodeID) *nodeBuffer {
 	nb, ok := nbs.nodeMap[source]
 	if !ok {
@@ -36,16 +40,34 @@ func (nbs *nodeBuffers) nodeBuffer(source nodeID) *nodeBuffer {
 			logger:   nbs.logger,
 			myConfig: nbs.myConfig,
 		}
+		nbs.nodeMap[source] = nb
 	}
 
 	return nb
 }
 
+func (nbs *nodeBuffers) status(nodes []uint64) []*status.NodeBuffer {
+	result := make([]*status.NodeBuffer, len(nodes))
+	for i, id := range nodes {
+		result[i] = &status.NodeBuffer{
+			ID: id,
+		} // TODO, populate the buckets and last checkpoint again
+
+		if nb, ok := nbs.nodeMap[nodeID(id)]; ok {
+			result[i].Msgs = nb.totalMsgs
+			result[i].Size = nb.totalSize
+		}
+	}
+
+	return result
+}
+
 type nodeBuffer struct {
 	id        nodeID
 	logger    Logger
 	myConfig  *pb.StateEvent_InitialParameters
 	totalSize int
+	totalMsgs int
 }
 
 func (nb *nodeBuffer) logDrop(component string, msg *pb.Msg) {
@@ -54,10 +76,12 @@ func (nb *nodeBuffer) logDrop(component string, msg *pb.Msg) {
 
 func (nb *nodeBuffer) msgRemoved(msg *pb.Msg) {
 	nb.totalSize -= proto.Size(msg)
+	nb.totalMsgs--
 }
 
 func (nb *nodeBuffer) msgStored(msg *pb.Msg) {
 	nb.totalSize += proto.Size(msg)
+	nb.totalMsgs++
 }
 
 func (nb *nodeBuffer) overCapacity() bool {
@@ -104,6 +128,14 @@ func (mb *msgBuffer) store(msg *pb.Msg) {
 	mb.nodeBuffer.msgStored(msg)
 }
 
+// clear removes all messages from the buffer, it must be invoked before
+// the buffer is discarded so that the node buffer's accounting remains correct.
+func (mb *msgBuffer) clear() {
+	for e := mb.buffer.Front(); e != nil; e = mb.buffer.Front() {
+		mb.nodeBuffer.msgRemoved(mb.buffer.Remove(e).(*pb.Msg))
+	}
+}
+
 func (mb *msgBuffer) next(filter func(source nodeID, msg *pb.Msg) applyable) *pb.Msg {
 	e := mb.buffer.Front()
 	if e == nil {
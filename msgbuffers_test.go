@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("nodeBuffers", func() {
+	var (
+		nbs     *nodeBuffers
+		msgSize int
+	)
+
+	prepare := func(seqNo uint64) *pb.Msg {
+		return &pb.Msg{
+			Type: &pb.Msg_Prepare{
+				Prepare: &pb.Prepare{
+					SeqNo:  seqNo,
+					Epoch:  5,
+					Digest: []byte("digest"),
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		msgSize = proto.Size(prepare(10))
+		nbs = newNodeBuffers(&pb.StateEvent_InitialParameters{
+			// Room for two messages, the third puts the node over capacity
+			BufferSize: uint32(2 * msgSize),
+		}, ConsoleWarnLogger)
+	})
+
+	It("returns the same buffer for a node each time", func() {
+		Expect(nbs.nodeBuffer(1)).To(BeIdenticalTo(nbs.nodeBuffer(1)))
+		Expect(nbs.nodeBuffer(1)).NotTo(BeIdenticalTo(nbs.nodeBuffer(2)))
+	})
+
+	It("bounds the messages held from a node across all components", func() {
+		first := newMsgBuffer("first", nbs.nodeBuffer(1))
+		second := newMsgBuffer("second", nbs.nodeBuffer(1))
+		other := newMsgBuffer("other", nbs.nodeBuffer(2))
+
+		first.store(prepare(10))
+		first.store(prepare(11))
+		first.store(prepare(12))
+		Expect(first.buffer.Len()).To(Equal(3))
+
+		// The node is now over capacity, so the second component
+		// may hold at most the latest message it was given.
+		second.store(prepare(13))
+		second.store(prepare(14))
+		Expect(second.buffer.Len()).To(Equal(1))
+		Expect(second.buffer.Front().Value.(*pb.Msg).GetPrepare().SeqNo).To(Equal(uint64(14)))
+
+		// Messages from other nodes are unaffected.
+		other.store(prepare(15))
+		other.store(prepare(16))
+		Expect(other.buffer.Len()).To(Equal(2))
+
+		Expect(nbs.status([]uint64{1, 2})).To(Equal([]*status.NodeBuffer{
+			{ID: 1, Msgs: 4, Size: 4 * msgSize},
+			{ID: 2, Msgs: 2, Size: 2 * msgSize},
+		}))
+
+		first.clear()
+		second.clear()
+		Expect(nbs.status([]uint64{1})[0].Msgs).To(Equal(0))
+		Expect(nbs.status([]uint64{1})[0].Size).To(Equal(0))
+	})
+})
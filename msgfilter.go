@@ -97,6 +97,8 @@ func preProcess(outerMsg *pb.Msg) error {
 		case innerMsg.NewEpochReady.StartingCheckpoint == nil:
 			return errors.Errorf("NewEpochReady has nil StartingCheckpoint")
 		}
+	case *pb.Msg_SnapshotRequest, *pb.Msg_SnapshotChunk:
+		return errors.Errorf("message of type '%T' is for state transfer, and should not be stepped into the node", outerMsg.Type)
 	default:
 		return errors.Errorf("unknown type '%T' for message", outerMsg.Type)
 	}
@@ -119,6 +119,16 @@ func (p *persisted) addCEntry(cEntry *pb.CEntry) *Actions {
 	return p.appendLogEntry(d)
 }
 
+func (p *persisted) addFEntry(fEntry *pb.FEntry) *Actions {
+	d := &pb.Persistent{
+		Type: &pb.Persistent_FEntry{
+			FEntry: fEntry,
+		},
+	}
+
+	return p.appendLogEntry(d)
+}
+
 func (p *persisted) addSuspect(suspect *pb.Suspect) *Actions {
 	d := &pb.Persistent{
 		Type: &pb.Persistent_Suspect{
@@ -298,6 +308,17 @@ func (p *persisted) constructEpochChange(newEpoch uint64) *pb.EpochChange {
 			logEpoch = &fEntry.EndsEpochConfig.Number
 		},
 		onCEntry: func(cEntry *pb.CEntry) {
+			for len(newEpochChange.Checkpoints) > 0 {
+				last := newEpochChange.Checkpoints[len(newEpochChange.Checkpoints)-1]
+				if last.SeqNo < cEntry.SeqNo {
+					break
+				}
+
+				// A checkpoint we transferred to supersedes any divergent
+				// checkpoints we computed at or above its sequence number.
+				newEpochChange.Checkpoints = newEpochChange.Checkpoints[:len(newEpochChange.Checkpoints)-1]
+			}
+
 			newEpochChange.Checkpoints = append(newEpochChange.Checkpoints, &pb.Checkpoint{
 				SeqNo: cEntry.SeqNo,
 				Value: cEntry.CheckpointValue,
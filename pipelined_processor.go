@@ -0,0 +1,409 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+
+	"github.com/pkg/errors"
+)
+
+// PipelinedProcessor is a version of the ProcessorWorkPool which does not wait
+// for one set of Actions to complete before beginning to process the next.
+// Instead, actions are submitted as they are read from the Ready() channel, and
+// results are streamed back to the node via AddResults as they become available.
+// Ordering is enforced only where safety requires it.  Requests are stored and the
+// WAL is persisted in order, and the sends for a batch of actions are not performed
+// until the requests and WAL entries of that batch (and therefore of all previous
+// batches) are durable.  Commits are applied in order, and checkpoint results are
+// returned in order.  Hashing, and the sends of already persisted batches proceed
+// concurrently with the persistence of later batches.  This allows throughput to
+// exceed the rate at which the WAL may be synced.
+//
+// If any action fails, the node is stopped via StopWithError, and all further
+// submissions fail.
+//
+// The pipeline may be driven by reading from Ready() and invoking Submit directly,
+// or it may be passed to Run as an ActionsProcessor, in which case Process submits
+// the actions and the results are added by the pipeline as they become available.
+// In either case, the caller must Stop the pipeline once the node has exited.
+type PipelinedProcessor struct {
+	processor *Processor
+
+	// slotsC is a semaphore bounding the number of batches in flight
+	slotsC    chan struct{}
+	persistC  chan *pipelineBatch
+	commitC   chan *pipelineBatch
+	transmitC chan *pipelineTransmit
+	hashC     chan *pipelineHash
+
+	waitGroup sync.WaitGroup
+
+	exitMutex sync.Mutex
+	exitErr   error
+	doneC     chan struct{}
+}
+
+type PipelinedProcessorOpts struct {
+	// MaxInFlight is the number of sets of Actions which may be processing
+	// concurrently before Submit blocks.  Defaults to 4.
+	MaxInFlight     int
+	TransmitWorkers int
+	HashWorkers     int
+}
+
+// pipelineBatch tracks a set of actions through the pipeline.  The batch
+// is complete once the persist (and transmit), hash, and commit stages
+// have each finished with it.
+type pipelineBatch struct {
+	actions *Actions
+
+	stagesRemaining    int32
+	transmitsRemaining int32
+}
+
+type pipelineTransmit struct {
+	batch   *pipelineBatch
+	send    *Send
+	forward *Forward
+}
+
+type pipelineHash struct {
+	request *HashRequest
+	resultC chan<- *HashResult
+}
+
+func NewPipelinedProcessor(p *Processor, opts PipelinedProcessorOpts) *PipelinedProcessor {
+	if opts.MaxInFlight == 0 {
+		opts.MaxInFlight = 4
+	}
+
+	if opts.TransmitWorkers == 0 {
+		opts.TransmitWorkers = runtime.NumCPU()
+	}
+
+	if opts.HashWorkers == 0 {
+		opts.HashWorkers = runtime.NumCPU()
+	}
+
+	pp := &PipelinedProcessor{
+		processor: p,
+		slotsC:    make(chan struct{}, opts.MaxInFlight),
+		persistC:  make(chan *pipelineBatch, opts.MaxInFlight),
+		commitC:   make(chan *pipelineBatch, opts.MaxInFlight),
+		transmitC: make(chan *pipelineTransmit, opts.TransmitWorkers),
+		hashC:     make(chan *pipelineHash, opts.HashWorkers),
+		doneC:     make(chan struct{}),
+	}
+
+	pp.waitGroup.Add(2 + opts.TransmitWorkers + opts.HashWorkers)
+
+	go func() {
+		defer pp.waitGroup.Done()
+		pp.servicePersist()
+	}()
+
+	go func() {
+		defer pp.waitGroup.Done()
+		pp.serviceCommit()
+	}()
+
+	for i := 0; i < opts.TransmitWorkers; i++ {
+		go func() {
+			defer pp.waitGroup.Done()
+			pp.serviceTransmit()
+		}()
+	}
+
+	for i := 0; i < opts.HashWorkers; i++ {
+		go func() {
+			defer pp.waitGroup.Done()
+			pp.serviceHash()
+		}()
+	}
+
+	return pp
+}
+
+// Submit enqueues the actions for processing.  It blocks only if the maximum
+// number of sets of actions are already in flight.  An error is returned if the
+// processor has been stopped, or if processing of previous actions failed.
+func (pp *PipelinedProcessor) Submit(actions *Actions) error {
+	select {
+	case <-pp.doneC:
+		return pp.err()
+	default:
+	}
+
+	select {
+	case pp.slotsC <- struct{}{}:
+	case <-pp.doneC:
+		return pp.err()
+	}
+
+	pp.processor.reportMisbehaviors(actions.Misbehaviors)
+
+	batch := &pipelineBatch{
+		actions:         actions,
+		stagesRemaining: 3,
+	}
+
+	// These channels have capacity for every slot, so never block
+	pp.persistC <- batch
+	pp.commitC <- batch
+
+	pp.waitGroup.Add(1)
+	go func() {
+		defer pp.waitGroup.Done()
+		pp.hashBatch(batch)
+	}()
+
+	return nil
+}
+
+// Process submits the actions, satisfying the ActionsProcessor interface so that
+// the pipeline may be used with Run.  Because the pipeline adds the results to
+// the node itself, the returned results are always nil.
+func (pp *PipelinedProcessor) Process(actions *Actions) (*ActionResults, error) {
+	return nil, pp.Submit(actions)
+}
+
+// Stop halts processing and waits for all go routines to exit.  Actions which
+// were submitted but have not completed processing are abandoned.
+func (pp *PipelinedProcessor) Stop() {
+	pp.fail(ErrStopped)
+	pp.waitGroup.Wait()
+}
+
+func (pp *PipelinedProcessor) err() error {
+	pp.exitMutex.Lock()
+	defer pp.exitMutex.Unlock()
+	return pp.exitErr
+}
+
+// fail records the first error encountered, stops all processing,
+// and stops the node with that error.
+func (pp *PipelinedProcessor) fail(err error) {
+	pp.exitMutex.Lock()
+	defer pp.exitMutex.Unlock()
+
+	select {
+	case <-pp.doneC:
+		return
+	default:
+	}
+
+	pp.exitErr = err
+	close(pp.doneC)
+
+	if err != ErrStopped {
+		// Stopping the node waits for the serializer to exit, which
+		// never depends on the processor, so this cannot deadlock.
+		pp.processor.Node.StopWithError(err)
+	}
+}
+
+func (pp *PipelinedProcessor) stageDone(batch *pipelineBatch) {
+	if atomic.AddInt32(&batch.stagesRemaining, -1) != 0 {
+		return
+	}
+
+	<-pp.slotsC
+}
+
+func (pp *PipelinedProcessor) addResults(results ActionResults) bool {
+	if err := pp.processor.Node.AddResults(results); err != nil {
+		pp.fail(errors.WithMessage(err, "could not add results"))
+		return false
+	}
+
+	return true
+}
+
+// servicePersist stores requests and writes the WAL in order, then hands the
+// sends and forwards of each batch to the transmit workers.
+func (pp *PipelinedProcessor) servicePersist() {
+	p := pp.processor
+	for {
+		var batch *pipelineBatch
+		select {
+		case batch = <-pp.persistC:
+		case <-pp.doneC:
+			return
+		}
+
+		if err := p.persist(batch.actions); err != nil {
+			pp.fail(err)
+			return
+		}
+
+		actions := batch.actions
+		batch.transmitsRemaining = int32(len(actions.Send) + len(actions.ForwardRequests))
+		if batch.transmitsRemaining == 0 {
+			pp.stageDone(batch)
+			continue
+		}
+
+		for i := range actions.Send {
+			select {
+			case pp.transmitC <- &pipelineTransmit{batch: batch, send: &actions.Send[i]}:
+			case <-pp.doneC:
+				return
+			}
+		}
+
+		for i := range actions.ForwardRequests {
+			select {
+			case pp.transmitC <- &pipelineTransmit{batch: batch, forward: &actions.ForwardRequests[i]}:
+			case <-pp.doneC:
+				return
+			}
+		}
+	}
+}
+
+func (pp *PipelinedProcessor) serviceTransmit() {
+	p := pp.processor
+	for {
+		var transmit *pipelineTransmit
+		select {
+		case transmit = <-pp.transmitC:
+		case <-pp.doneC:
+			return
+		}
+
+		var err error
+		if transmit.send != nil {
+			err = p.transmit(transmit.send.Targets, transmit.send.Msg)
+		} else {
+			err = p.forward(transmit.forward)
+		}
+
+		if err != nil {
+			pp.fail(err)
+			return
+		}
+
+		if atomic.AddInt32(&transmit.batch.transmitsRemaining, -1) == 0 {
+			pp.stageDone(transmit.batch)
+		}
+	}
+}
+
+func (pp *PipelinedProcessor) serviceHash() {
+	h := pp.processor.Hasher()
+	for {
+		var hashReq *pipelineHash
+		select {
+		case hashReq = <-pp.hashC:
+		case <-pp.doneC:
+			return
+		}
+
+		// A nil result indicates the request was rejected
+		var result *HashResult
+		if pp.processor.verifyRequest(hashReq.request) {
+			for _, data := range hashReq.request.Data {
+				h.Write(data)
+			}
+
+			result = &HashResult{
+				Request: hashReq.request,
+				Digest:  h.Sum(nil),
+			}
+			h.Reset()
+		}
+
+		// The result channel has capacity for every hash of the batch
+		hashReq.resultC <- result
+	}
+}
+
+// hashBatch dispatches the hashes of a batch to the hash workers, and returns
+// the results to the node once all have completed.
+func (pp *PipelinedProcessor) hashBatch(batch *pipelineBatch) {
+	hashReqs := batch.actions.Hash
+	resultC := make(chan *HashResult, len(hashReqs))
+
+	for _, hashReq := range hashReqs {
+		select {
+		case pp.hashC <- &pipelineHash{request: hashReq, resultC: resultC}:
+		case <-pp.doneC:
+			return
+		}
+	}
+
+	digests := make([]*HashResult, 0, len(hashReqs))
+	for range hashReqs {
+		select {
+		case result := <-resultC:
+			if result != nil {
+				digests = append(digests, result)
+			}
+		case <-pp.doneC:
+			return
+		}
+	}
+
+	if len(digests) > 0 && !pp.addResults(ActionResults{Digests: digests}) {
+		return
+	}
+
+	pp.stageDone(batch)
+}
+
+// serviceCommit applies the commits of each batch in order, returning any
+// checkpoint results to the node in order.  Requests are garbage collected
+// only once the commits which precede the stable checkpoint are applied.
+func (pp *PipelinedProcessor) serviceCommit() {
+	for {
+		var batch *pipelineBatch
+		select {
+		case batch = <-pp.commitC:
+		case <-pp.doneC:
+			return
+		}
+
+		checkpoints, err := pp.processor.commit(batch.actions.Commits)
+		if err != nil {
+			pp.fail(err)
+			return
+		}
+
+		if err := pp.processor.garbageCollect(batch.actions.StableCheckpoint); err != nil {
+			pp.fail(err)
+			return
+		}
+
+		if len(checkpoints) > 0 && !pp.addResults(ActionResults{Checkpoints: checkpoints}) {
+			return
+		}
+
+		pp.stageDone(batch)
+	}
+}
+
+// forward reads the request from the request store, and sends it to the targets.
+func (p *Processor) forward(r *Forward) error {
+	requestData, err := p.RequestStore.Get(r.RequestAck)
+	if err != nil {
+		return errors.WithMessage(err, "could not read request to forward")
+	}
+
+	return p.transmit(r.Targets, &pb.Msg{
+		Type: &pb.Msg_ForwardRequest{
+			ForwardRequest: &pb.ForwardRequest{
+				RequestAck:  r.RequestAck,
+				RequestData: requestData,
+			},
+		},
+	})
+}
@@ -8,26 +8,34 @@ package mirbft
 
 import (
 	"context"
-	"fmt"
 	"hash"
 	"runtime"
 	"sync"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
-)
 
-// TODO, convert panics into errors
+	"github.com/pkg/errors"
+)
 
 type Hasher func() hash.Hash
 
+// Link sends messages to the other nodes in the network.  Because the
+// state machine tolerates message loss, implementations should generally
+// not return errors for individual messages which could not be delivered,
+// but only for fatal conditions, such as the link having been closed.
 type Link interface {
-	Send(dest uint64, msg *pb.Msg)
+	Send(dest uint64, msg *pb.Msg) error
 }
 
-// TODO allow these to return errors
+// Log is the application log to which committed entries are applied, and
+// which produces the checkpoint values for state transfer.  The value returned
+// by Snap should commit to the entire network state, as a node which state
+// transfers obtains the network state along with the application state, and
+// must be able to verify both.  Any error returned is considered fatal, as the
+// state machine cannot continue without the entries being applied.
 type Log interface {
-	Apply(*pb.QEntry)
-	Snap(networkConfig *pb.NetworkState_Config, clientsState []*pb.NetworkState_Client) (id []byte)
+	Apply(*pb.QEntry) error
+	Snap(networkState *pb.NetworkState) (id []byte, err error)
 }
 
 type WAL interface {
@@ -39,7 +47,17 @@ type WAL interface {
 type RequestStore interface {
 	Store(requestAck *pb.RequestAck, data []byte) error
 	Get(requestAck *pb.RequestAck) ([]byte, error)
-	Commit(requestAck *pb.RequestAck) error
+
+	// Commit marks the request as committed in the batch with the given
+	// sequence number.  A committed request must no longer be reported as
+	// uncommitted, but must remain available to Get, as peers may still
+	// fetch it, until it is garbage collected.
+	Commit(seqNo uint64, requestAck *pb.RequestAck) error
+
+	// GarbageCollect deletes all requests committed at or below the given
+	// sequence number, which is that of a stable checkpoint.
+	GarbageCollect(seqNo uint64) error
+
 	Sync() error
 }
 
@@ -60,98 +78,169 @@ type Processor struct {
 	WAL          WAL
 	RequestStore RequestStore
 	Node         *Node
+
+	// RequestVerifier is optional, and if set, requests which it rejects
+	// are not hashed, and therefore never acknowledged by this node.
+	RequestVerifier RequestVerifier
+
+	// MisbehaviorReporter is optional, and if set, is given the evidence of
+	// any misbehavior by other nodes which the state machine observes.
+	MisbehaviorReporter MisbehaviorReporter
+}
+
+// MisbehaviorReporter receives evidence of misbehavior by other nodes,
+// for instance, to alert an operator.  Report must not block.
+type MisbehaviorReporter interface {
+	Report(*Misbehavior)
 }
 
-func (p *Processor) Process(actions *Actions) *ActionResults {
+// Process performs the actions and returns the results which must be
+// returned to the node via AddResults.  If any action fails, for instance
+// because the disk is full, an error is returned and it is no longer safe to
+// continue.  The caller should generally stop the node via StopWithError.
+func (p *Processor) Process(actions *Actions) (*ActionResults, error) {
 	// Persist
+	if err := p.persist(actions); err != nil {
+		return nil, err
+	}
+
+	// Transmit
+	for _, send := range actions.Send {
+		if err := p.transmit(send.Targets, send.Msg); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range actions.ForwardRequests {
+		if err := p.forward(&actions.ForwardRequests[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply
+	actionResults := &ActionResults{
+		Digests: make([]*HashResult, 0, len(actions.Hash)),
+	}
+
+	for _, req := range actions.Hash {
+		if !p.verifyRequest(req) {
+			continue
+		}
+
+		h := p.Hasher()
+		for _, data := range req.Data {
+			h.Write(data)
+		}
+
+		actionResults.Digests = append(actionResults.Digests, &HashResult{
+			Request: req,
+			Digest:  h.Sum(nil),
+		})
+	}
+
+	checkpoints, err := p.commit(actions.Commits)
+	if err != nil {
+		return nil, err
+	}
+
+	actionResults.Checkpoints = checkpoints
+
+	if err := p.garbageCollect(actions.StableCheckpoint); err != nil {
+		return nil, err
+	}
+
+	p.reportMisbehaviors(actions.Misbehaviors)
+
+	return actionResults, nil
+}
+
+// persist stores the requests, then writes the WAL entries, syncing both.
+func (p *Processor) persist(actions *Actions) error {
 	for _, r := range actions.StoreRequests {
-		p.RequestStore.Store(
-			r.RequestAck,
-			r.RequestData,
-		)
+		if err := p.RequestStore.Store(r.RequestAck, r.RequestData); err != nil {
+			return errors.WithMessage(err, "could not store request")
+		}
 	}
 
 	if err := p.RequestStore.Sync(); err != nil {
-		panic(fmt.Sprintf("could not sync request store, unsafe to continue: %s\n", err))
+		return errors.WithMessage(err, "could not sync request store")
 	}
 
 	for _, write := range actions.WriteAhead {
 		if write.Truncate != nil {
 			if err := p.WAL.Truncate(*write.Truncate); err != nil {
-				panic(fmt.Sprintf("could truncate WAL, not safe to continue: %s", err))
+				return errors.WithMessage(err, "could not truncate WAL")
 			}
 		} else {
 			if err := p.WAL.Write(write.Append.Index, write.Append.Data); err != nil {
-				panic(fmt.Sprintf("could not persist entry, not safe to continue: %s", err))
+				return errors.WithMessage(err, "could not persist entry")
 			}
 		}
 	}
 
 	if err := p.WAL.Sync(); err != nil {
-		panic(fmt.Sprintf("could not sync WAL, not safe to continue: %s", err))
+		return errors.WithMessage(err, "could not sync WAL")
 	}
 
-	// Transmit
-	for _, send := range actions.Send {
-		for _, replica := range send.Targets {
-			if replica == p.Node.Config.ID {
-				p.Node.Step(context.Background(), replica, send.Msg)
-			} else {
-				p.Link.Send(replica, send.Msg)
+	return nil
+}
+
+// transmit sends the message to each of the targets, stepping the message
+// directly into the node if this node is a target.
+func (p *Processor) transmit(targets []uint64, msg *pb.Msg) error {
+	for _, replica := range targets {
+		if replica == p.Node.Config.ID {
+			if err := p.Node.Step(context.Background(), replica, msg); err != nil {
+				return errors.WithMessage(err, "could not step message to self")
+			}
+		} else {
+			if err := p.Link.Send(replica, msg); err != nil {
+				return errors.WithMessagef(err, "could not send message to %d", replica)
 			}
 		}
 	}
 
-	for _, r := range actions.ForwardRequests {
-		requestData, err := p.RequestStore.Get(r.RequestAck)
-		if err != nil {
-			panic(fmt.Sprintf("could not store request, unsafe to continue: %s\n", err))
-		}
+	return nil
+}
 
-		fr := &pb.Msg{
-			Type: &pb.Msg_ForwardRequest{
-				&pb.ForwardRequest{
-					RequestAck:  r.RequestAck,
-					RequestData: requestData,
-				},
-			},
-		}
-		for _, replica := range r.Targets {
-			if replica == p.Node.Config.ID {
-				p.Node.Step(context.Background(), replica, fr)
-			} else {
-				p.Link.Send(replica, fr)
-			}
-		}
+// garbageCollect removes the requests which are no longer needed once
+// the checkpoint has become stable.
+func (p *Processor) garbageCollect(stableCheckpoint *uint64) error {
+	if stableCheckpoint == nil {
+		return nil
 	}
 
-	// Apply
-	actionResults := &ActionResults{
-		Digests: make([]*HashResult, len(actions.Hash)),
+	if err := p.RequestStore.GarbageCollect(*stableCheckpoint); err != nil {
+		return errors.WithMessagef(err, "could not garbage collect requests through seq_no=%d", *stableCheckpoint)
 	}
 
-	for i, req := range actions.Hash {
-		h := p.Hasher()
-		for _, data := range req.Data {
-			h.Write(data)
-		}
+	return nil
+}
 
-		actionResults.Digests[i] = &HashResult{
-			Request: req,
-			Digest:  h.Sum(nil),
-		}
+func (p *Processor) reportMisbehaviors(misbehaviors []*Misbehavior) {
+	if p.MisbehaviorReporter == nil {
+		return
+	}
+
+	for _, misbehavior := range misbehaviors {
+		p.MisbehaviorReporter.Report(misbehavior)
 	}
+}
 
-	for _, commit := range actions.Commits {
+// commit applies the batches to the log, and computes the checkpoint values
+// for any checkpoints.
+func (p *Processor) commit(commits []*Commit) ([]*CheckpointResult, error) {
+	var checkpoints []*CheckpointResult
+
+	for _, commit := range commits {
 		if commit.Batch != nil {
-			p.Log.Apply(commit.Batch) // Apply the entry
+			if err := p.Log.Apply(commit.Batch); err != nil {
+				return nil, errors.WithMessagef(err, "could not apply entry for seq_no=%d", commit.Batch.SeqNo)
+			}
 
-			// TODO, we need to make it clear that committing should not actually
-			// delete the data until the checkpoint.
 			for _, reqAck := range commit.Batch.Requests {
-				err := p.RequestStore.Commit(reqAck)
-				if err != nil {
-					panic("could not mark ack as committed, unsafe to continue")
+				if err := p.RequestStore.Commit(commit.Batch.SeqNo, reqAck); err != nil {
+					return nil, errors.WithMessage(err, "could not mark ack as committed")
 				}
 			}
 
@@ -160,14 +249,34 @@ func (p *Processor) Process(actions *Actions) *ActionResults {
 
 		// Not a batch, so, must be a checkpoint
 
-		value := p.Log.Snap(commit.Checkpoint.NetworkConfig, commit.Checkpoint.ClientsState)
-		actionResults.Checkpoints = append(actionResults.Checkpoints, &CheckpointResult{
+		// The processor reports no reconfigurations, so there are none pending
+		value, err := p.Log.Snap(&pb.NetworkState{
+			Config:  commit.Checkpoint.NetworkConfig,
+			Clients: commit.Checkpoint.ClientsState,
+		})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not snapshot log for checkpoint seq_no=%d", commit.Checkpoint.SeqNo)
+		}
+
+		checkpoints = append(checkpoints, &CheckpointResult{
 			Checkpoint: commit.Checkpoint,
 			Value:      value,
 		})
 	}
 
-	return actionResults
+	return checkpoints, nil
+}
+
+// verifyRequest returns false if the hash request is for a client request
+// which is rejected by the RequestVerifier.
+func (p *Processor) verifyRequest(req *HashRequest) bool {
+	err := VerifyHashRequest(p.RequestVerifier, req)
+	if err == nil {
+		return true
+	}
+
+	p.Node.Config.Logger.Log(LevelWarn, "rejecting request which failed verification", "error", err)
+	return false
 }
 
 // ProcessorWorkPool is a work pool based version of the standard Processor.
@@ -189,10 +298,14 @@ type ProcessorWorkPool struct {
 	// these four channels are buffered and serviced
 	// by the worker pool routines
 	transmitC     chan Send
-	transmitDoneC chan struct{}
+	transmitDoneC chan error
 	hashC         chan *HashRequest
 	hashDoneC     chan *HashResult
 
+	// err is set when a call to Process fails, after which
+	// the pool may no longer be used.
+	err error
+
 	doneC chan struct{}
 }
 
@@ -200,15 +313,9 @@ func (wp *ProcessorWorkPool) serviceSendPool() {
 	for {
 		select {
 		case send := <-wp.transmitC:
-			for _, replica := range send.Targets {
-				if replica == wp.processor.Node.Config.ID {
-					wp.processor.Node.Step(context.Background(), replica, send.Msg)
-				} else {
-					wp.processor.Link.Send(replica, send.Msg)
-				}
-			}
+			err := wp.processor.transmit(send.Targets, send.Msg)
 			select {
-			case wp.transmitDoneC <- struct{}{}:
+			case wp.transmitDoneC <- err:
 			case <-wp.doneC:
 				return
 			}
@@ -223,19 +330,24 @@ func (wp *ProcessorWorkPool) persistThenSendInParallel(
 	store []*pb.ForwardRequest,
 	sends []Send,
 	forwards []Forward,
-	sendDoneC chan<- struct{},
+	sendDoneC chan<- error,
 ) {
+	// Each of these is written to at most once, so never blocks.
+	forwardFailedC := make(chan error, 1)
+	persistDoneC := make(chan error, 1)
+
 	// First begin forwarding requests over the network, this may be done concurrently
 	// with persistence
 	go func() {
 		for _, r := range forwards {
 			requestData, err := wp.processor.RequestStore.Get(r.RequestAck)
 			if err != nil {
-				panic("io error? this should always return successfully")
+				forwardFailedC <- errors.WithMessage(err, "could not read request to forward")
+				return
 			}
 			fr := &pb.Msg{
 				Type: &pb.Msg_ForwardRequest{
-					&pb.ForwardRequest{
+					ForwardRequest: &pb.ForwardRequest{
 						RequestAck: &pb.RequestAck{
 							ReqNo:    r.RequestAck.ReqNo,
 							ClientId: r.RequestAck.ClientId,
@@ -263,27 +375,35 @@ func (wp *ProcessorWorkPool) persistThenSendInParallel(
 		for _, write := range writeAhead {
 			if write.Truncate != nil {
 				if err := wp.processor.WAL.Truncate(*write.Truncate); err != nil {
-					panic(fmt.Sprintf("could truncate WAL, not safe to continue: %s", err))
+					persistDoneC <- errors.WithMessage(err, "could not truncate WAL")
+					return
 				}
 			} else {
 				if err := wp.processor.WAL.Write(write.Append.Index, write.Append.Data); err != nil {
-					panic(fmt.Sprintf("could not persist entry, not safe to continue: %s", err))
+					persistDoneC <- errors.WithMessage(err, "could not persist entry")
+					return
 				}
 			}
 		}
 		if err := wp.processor.WAL.Sync(); err != nil {
-			panic(fmt.Sprintf("could not sync WAL: %s", err))
+			persistDoneC <- errors.WithMessage(err, "could not sync WAL")
+			return
 		}
 
 		// TODO, this could probably be parallelized with the WAL write
 		for _, r := range store {
-			wp.processor.RequestStore.Store(
-				r.RequestAck,
-				r.RequestData,
-			)
+			if err := wp.processor.RequestStore.Store(r.RequestAck, r.RequestData); err != nil {
+				persistDoneC <- errors.WithMessage(err, "could not store request")
+				return
+			}
 		}
 
-		wp.processor.RequestStore.Sync()
+		if err := wp.processor.RequestStore.Sync(); err != nil {
+			persistDoneC <- errors.WithMessage(err, "could not sync request store")
+			return
+		}
+
+		persistDoneC <- nil
 
 		for _, send := range sends {
 			select {
@@ -295,17 +415,31 @@ func (wp *ProcessorWorkPool) persistThenSendInParallel(
 	}()
 
 	go func() {
+		persisted := false
 		sent := 0
-		for sent < len(sends)+len(forwards) {
+		for !persisted || sent < len(sends)+len(forwards) {
 			select {
-			case <-wp.transmitDoneC:
+			case err := <-persistDoneC:
+				if err != nil {
+					sendDoneC <- err
+					return
+				}
+				persisted = true
+			case err := <-forwardFailedC:
+				sendDoneC <- err
+				return
+			case err := <-wp.transmitDoneC:
+				if err != nil {
+					sendDoneC <- err
+					return
+				}
 				sent++
 			case <-wp.doneC:
 				return
 			}
 		}
 
-		sendDoneC <- struct{}{}
+		sendDoneC <- nil
 	}()
 }
 
@@ -314,15 +448,19 @@ func (wp *ProcessorWorkPool) serviceHashPool() {
 	for {
 		select {
 		case hashReq := <-wp.hashC:
-			for _, data := range hashReq.Data {
-				h.Write(data)
-			}
+			// A nil result indicates the request was rejected
+			var result *HashResult
+			if wp.processor.verifyRequest(hashReq) {
+				for _, data := range hashReq.Data {
+					h.Write(data)
+				}
 
-			result := &HashResult{
-				Request: hashReq,
-				Digest:  h.Sum(nil),
+				result = &HashResult{
+					Request: hashReq,
+					Digest:  h.Sum(nil),
+				}
+				h.Reset()
 			}
-			h.Reset()
 			select {
 			case wp.hashDoneC <- result:
 			case <-wp.doneC:
@@ -347,10 +485,12 @@ func (wp *ProcessorWorkPool) hashInParallel(hashReqs []*HashRequest, hashBatchDo
 
 	go func() {
 		hashResults := make([]*HashResult, 0, len(hashReqs))
-		for len(hashResults) < len(hashReqs) {
+		for received := 0; received < len(hashReqs); received++ {
 			select {
 			case hashResult := <-wp.hashDoneC:
-				hashResults = append(hashResults, hashResult)
+				if hashResult != nil {
+					hashResults = append(hashResults, hashResult)
+				}
 			case <-wp.doneC:
 				return
 			}
@@ -360,36 +500,18 @@ func (wp *ProcessorWorkPool) hashInParallel(hashReqs []*HashRequest, hashBatchDo
 	}()
 }
 
-func (wp *ProcessorWorkPool) commitInParallel(commits []*Commit, commitBatchDoneC chan<- []*CheckpointResult) {
-	go func() {
-		var checkpoints []*CheckpointResult
-
-		for _, commit := range commits {
-			if commit.Batch != nil {
-				wp.processor.Log.Apply(commit.Batch) // Apply the entry
-
-				// TODO, we need to make it clear that committing should not actually
-				// delete the data until the checkpoint.
-				for _, reqAck := range commit.Batch.Requests {
-					err := wp.processor.RequestStore.Commit(reqAck)
-					if err != nil {
-						panic("could not mark ack as committed, unsafe to continue")
-					}
-				}
-
-				continue
-			}
-
-			// Not a batch, so, must be a checkpoint
+type commitBatchResult struct {
+	checkpoints []*CheckpointResult
+	err         error
+}
 
-			value := wp.processor.Log.Snap(commit.Checkpoint.NetworkConfig, commit.Checkpoint.ClientsState)
-			checkpoints = append(checkpoints, &CheckpointResult{
-				Checkpoint: commit.Checkpoint,
-				Value:      value,
-			})
+func (wp *ProcessorWorkPool) commitInParallel(commits []*Commit, commitBatchDoneC chan<- commitBatchResult) {
+	go func() {
+		checkpoints, err := wp.processor.commit(commits)
+		commitBatchDoneC <- commitBatchResult{
+			checkpoints: checkpoints,
+			err:         err,
 		}
-
-		commitBatchDoneC <- checkpoints
 	}()
 }
 
@@ -413,7 +535,7 @@ func NewProcessorWorkPool(p *Processor, opts ProcessorWorkPoolOpts) *ProcessorWo
 		doneC: make(chan struct{}),
 
 		transmitC:     make(chan Send, opts.TransmitWorkers),
-		transmitDoneC: make(chan struct{}, opts.TransmitWorkers),
+		transmitDoneC: make(chan error, opts.TransmitWorkers),
 		hashC:         make(chan *HashRequest, opts.HashWorkers),
 		hashDoneC:     make(chan *HashResult, opts.HashWorkers),
 	}
@@ -444,12 +566,21 @@ func (wp *ProcessorWorkPool) Stop() {
 	wp.waitGroup.Wait()
 }
 
-func (wp *ProcessorWorkPool) Process(actions *Actions) *ActionResults {
+// Process performs the actions in parallel and returns the results which must
+// be returned to the node via AddResults.  If any action fails, an error is
+// returned, and the work pool may not be used for any further processing.  The
+// caller should generally stop both the pool and the node.
+func (wp *ProcessorWorkPool) Process(actions *Actions) (*ActionResults, error) {
 	wp.mutex.Lock()
 	defer wp.mutex.Unlock()
-	sendBatchDoneC := make(chan struct{}, 1)
+
+	if wp.err != nil {
+		return nil, errors.WithMessage(wp.err, "work pool previously failed")
+	}
+
+	sendBatchDoneC := make(chan error, 1)
 	hashBatchDoneC := make(chan []*HashResult, 1)
-	commitBatchDoneC := make(chan []*CheckpointResult, 1)
+	commitBatchDoneC := make(chan commitBatchResult, 1)
 
 	wp.persistThenSendInParallel(
 		actions.WriteAhead,
@@ -461,10 +592,26 @@ func (wp *ProcessorWorkPool) Process(actions *Actions) *ActionResults {
 	wp.hashInParallel(actions.Hash, hashBatchDoneC)
 	wp.commitInParallel(actions.Commits, commitBatchDoneC)
 
-	<-sendBatchDoneC
+	if err := <-sendBatchDoneC; err != nil {
+		wp.err = err
+		return nil, err
+	}
 
-	return &ActionResults{
-		Digests:     <-hashBatchDoneC,
-		Checkpoints: <-commitBatchDoneC,
+	digests := <-hashBatchDoneC
+
+	commitResult := <-commitBatchDoneC
+	if commitResult.err != nil {
+		wp.err = commitResult.err
+		return nil, commitResult.err
 	}
+
+	if err := wp.processor.garbageCollect(actions.StableCheckpoint); err != nil {
+		wp.err = err
+		return nil, err
+	}
+
+	return &ActionResults{
+		Digests:     digests,
+		Checkpoints: commitResult.checkpoints,
+	}, nil
 }
@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+type FailingWAL struct {
+	Err error
+}
+
+func (fw *FailingWAL) Write(index uint64, entry *pb.Persistent) error {
+	return fw.Err
+}
+
+func (fw *FailingWAL) Truncate(index uint64) error {
+	return fw.Err
+}
+
+func (fw *FailingWAL) Sync() error {
+	return fw.Err
+}
+
+type MemRequestStore struct {
+	Requests map[string][]byte
+}
+
+func (mrs *MemRequestStore) key(ack *pb.RequestAck) string {
+	return fmt.Sprintf("%d.%d.%x", ack.ClientId, ack.ReqNo, ack.Digest)
+}
+
+func (mrs *MemRequestStore) Store(ack *pb.RequestAck, data []byte) error {
+	mrs.Requests[mrs.key(ack)] = data
+	return nil
+}
+
+func (mrs *MemRequestStore) Get(ack *pb.RequestAck) ([]byte, error) {
+	data, ok := mrs.Requests[mrs.key(ack)]
+	if !ok {
+		return nil, fmt.Errorf("no such request")
+	}
+	return data, nil
+}
+
+func (mrs *MemRequestStore) Commit(seqNo uint64, ack *pb.RequestAck) error {
+	return nil
+}
+
+func (mrs *MemRequestStore) GarbageCollect(seqNo uint64) error {
+	return nil
+}
+
+func (mrs *MemRequestStore) Sync() error {
+	return nil
+}
+
+var _ = Describe("Processor", func() {
+	var (
+		node      *mirbft.Node
+		processor *mirbft.Processor
+		walErr    = fmt.Errorf("no space left on device")
+	)
+
+	BeforeEach(func() {
+		var err error
+		node, err = mirbft.StartNewNode(
+			&mirbft.Config{
+				ID:                   0,
+				BatchSize:            1,
+				SuspectTicks:         4,
+				HeartbeatTicks:       2,
+				NewEpochTimeoutTicks: 8,
+				BufferSize:           5 * 1024 * 1024,
+				Logger:               mirbft.ConsoleWarnLogger,
+			},
+			mirbft.StandardInitialNetworkState(1, 0),
+			[]byte("fake-application-state"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The initial actions are only delivered once the serializer
+		// has processed its first event.
+		Expect(node.Tick()).To(Succeed())
+
+		processor = &mirbft.Processor{
+			Node:   node,
+			Hasher: sha256.New,
+			WAL: &FailingWAL{
+				Err: walErr,
+			},
+			RequestStore: &MemRequestStore{
+				Requests: map[string][]byte{},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		node.Stop()
+	})
+
+	It("returns an error when the WAL fails", func() {
+		actions := <-node.Ready()
+		Expect(actions.WriteAhead).NotTo(BeEmpty())
+
+		_, err := processor.Process(&actions)
+		Expect(err).To(MatchError("could not persist entry: no space left on device"))
+	})
+
+	When("the work pool is used", func() {
+		var workPool *mirbft.ProcessorWorkPool
+
+		BeforeEach(func() {
+			workPool = mirbft.NewProcessorWorkPool(processor, mirbft.ProcessorWorkPoolOpts{})
+		})
+
+		AfterEach(func() {
+			workPool.Stop()
+		})
+
+		It("returns an error when the WAL fails, and on all subsequent calls", func() {
+			actions := <-node.Ready()
+			Expect(actions.WriteAhead).NotTo(BeEmpty())
+
+			_, err := workPool.Process(&actions)
+			Expect(err).To(MatchError("could not persist entry: no space left on device"))
+
+			_, err = workPool.Process(&mirbft.Actions{})
+			Expect(err).To(MatchError("work pool previously failed: could not persist entry: no space left on device"))
+		})
+	})
+
+	When("the pipelined processor is used", func() {
+		var pipeline *mirbft.PipelinedProcessor
+
+		BeforeEach(func() {
+			pipeline = mirbft.NewPipelinedProcessor(processor, mirbft.PipelinedProcessorOpts{})
+		})
+
+		AfterEach(func() {
+			pipeline.Stop()
+		})
+
+		It("stops the node when the WAL fails, and rejects subsequent submissions", func() {
+			actions := <-node.Ready()
+			Expect(actions.WriteAhead).NotTo(BeEmpty())
+
+			Expect(pipeline.Submit(&actions)).To(Succeed())
+			Eventually(node.Err()).Should(BeClosed())
+
+			_, exitErr := node.Status(context.Background())
+			Expect(exitErr).To(MatchError("could not persist entry: no space left on device"))
+
+			err := pipeline.Submit(&mirbft.Actions{})
+			Expect(err).To(MatchError("could not persist entry: no space left on device"))
+		})
+	})
+
+	When("the node is stopped with the processing error", func() {
+		It("reports the error as the exit error", func() {
+			actions := <-node.Ready()
+			_, err := processor.Process(&actions)
+			Expect(err).To(HaveOccurred())
+
+			node.StopWithError(err)
+			Eventually(node.Err()).Should(BeClosed())
+
+			status, exitErr := node.Status(context.Background())
+			Expect(exitErr).To(Equal(err))
+			Expect(status).NotTo(BeNil())
+
+			Expect(node.Tick()).To(Equal(err))
+
+			By("retaining the original error if stopped again")
+			node.Stop()
+			_, exitErr = node.Status(context.Background())
+			Expect(exitErr).To(Equal(err))
+		})
+	})
+})
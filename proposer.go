@@ -32,6 +32,25 @@ type proposalBucket struct {
 	bucketID           bucketID
 	checkpointInterval uint64
 
+	// The cut policy for batches, see the corresponding fields of Config.
+	maxBytes     uint32
+	minCount     uint32
+	timeoutTicks uint32
+	adaptive     bool
+
+	// pendingBytes is the total size of the pending requests, and bytesFull is
+	// set when the next ready request would exceed maxBytes.
+	pendingBytes uint64
+	bytesFull    bool
+
+	// ticksPending is the number of ticks since the first pending request was
+	// added to the batch.
+	ticksPending uint32
+
+	// averageDepth is a moving average of the number of requests which were
+	// queued when each batch was cut, used as the fill target when adaptive.
+	averageDepth uint32
+
 	// currentCheckpoint is initially set to the base checkpoint value.  It is incremented by
 	// the caller when querying for available batches, as the caller supplies the current sequence
 	// number (which will increase monotonically).  If the current sequence number is beyond the
@@ -60,6 +79,10 @@ func newProposer(baseCheckpoint uint64, checkpointInterval uint64, myConfig *pb.
 			readyList:          list.New(),
 			nextReadyList:      list.New(),
 			requestCount:       myConfig.BatchSize,
+			maxBytes:           myConfig.MaxBatchBytes,
+			minCount:           myConfig.MinBatchSize,
+			timeoutTicks:       myConfig.BatchTimeoutTicks,
+			adaptive:           myConfig.AdaptiveBatchSize,
 			pending:            make([]*clientRequest, 0, 1), // TODO, might be interesting to play with not preallocating for performance reasons
 		}
 	}
@@ -114,6 +137,13 @@ func (p *proposer) proposalBucket(bucketID bucketID) *proposalBucket {
 	return p.proposalBuckets[bucketID]
 }
 
+// tick advances the batch timeout of each bucket.
+func (p *proposer) tick() {
+	for _, prb := range p.proposalBuckets {
+		prb.tick()
+	}
+}
+
 func (prb *proposalBucket) queueRequest(validAfterSeqNo uint64, cr *clientRequest) {
 	if prb.currentCheckpoint >= validAfterSeqNo {
 		prb.readyList.PushBack(cr)
@@ -130,16 +160,47 @@ func (prb *proposalBucket) advance(toSeqNo uint64) {
 		prb.nextReadyList = list.New()
 	}
 
-	for uint32(len(prb.pending)) < prb.requestCount {
+	for uint32(len(prb.pending)) < prb.requestCount && !prb.bytesFull {
 		if prb.readyList.Len() == 0 {
 			break
 		}
 
-		prb.pending = append(
-			prb.pending,
-			prb.readyList.Remove(prb.readyList.Front()).(*clientRequest),
-		)
+		cr := prb.readyList.Front().Value.(*clientRequest)
+		if prb.maxBytes != 0 && len(prb.pending) > 0 && prb.pendingBytes+uint64(cr.size) > uint64(prb.maxBytes) {
+			prb.bytesFull = true
+			break
+		}
+
+		prb.readyList.Remove(prb.readyList.Front())
+		prb.pending = append(prb.pending, cr)
+		prb.pendingBytes += uint64(cr.size)
+	}
+}
+
+func (prb *proposalBucket) tick() {
+	if len(prb.pending) > 0 {
+		prb.ticksPending++
+	}
+}
+
+// fillTarget returns the number of pending requests at which a batch
+// is cut before it is full, or zero if batches are only cut when full.
+func (prb *proposalBucket) fillTarget() uint32 {
+	if !prb.adaptive {
+		return prb.minCount
+	}
+
+	target := prb.averageDepth
+	if target < prb.minCount {
+		target = prb.minCount
 	}
+	if target < 1 {
+		target = 1
+	}
+	if target > prb.requestCount {
+		target = prb.requestCount
+	}
+	return target
 }
 
 func (prb *proposalBucket) hasOutstanding(forSeqNo uint64) bool {
@@ -149,11 +210,35 @@ func (prb *proposalBucket) hasOutstanding(forSeqNo uint64) bool {
 
 func (prb *proposalBucket) hasPending(forSeqNo uint64) bool {
 	prb.advance(forSeqNo)
-	return len(prb.pending) > 0 && uint32(len(prb.pending)) == prb.requestCount
+
+	pendingCount := uint32(len(prb.pending))
+	switch {
+	case pendingCount == 0:
+		return false
+	case pendingCount == prb.requestCount:
+		return true
+	case prb.bytesFull:
+		return true
+	case prb.fillTarget() != 0 && pendingCount >= prb.fillTarget():
+		return true
+	case prb.timeoutTicks != 0 && prb.ticksPending >= prb.timeoutTicks:
+		return true
+	default:
+		return false
+	}
 }
 
 func (prb *proposalBucket) next() []*clientRequest {
+	if prb.adaptive {
+		// Weight the queue depth at this cut by a quarter against the history
+		depth := uint32(len(prb.pending) + prb.readyList.Len())
+		prb.averageDepth = (3*prb.averageDepth + depth) / 4
+	}
+
 	result := prb.pending
 	prb.pending = make([]*clientRequest, 0, prb.requestCount)
+	prb.pendingBytes = 0
+	prb.bytesFull = false
+	prb.ticksPending = 0
 	return result
 }
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"container/list"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("proposalBucket", func() {
+	var (
+		prb    *proposalBucket
+		nextNo uint64
+	)
+
+	queue := func(sizes ...int) {
+		for _, size := range sizes {
+			prb.queueRequest(0, &clientRequest{
+				ack: &pb.RequestAck{
+					ClientId: 1,
+					ReqNo:    nextNo,
+				},
+				size: size,
+			})
+			nextNo++
+		}
+	}
+
+	BeforeEach(func() {
+		nextNo = 0
+		prb = &proposalBucket{
+			requestCount:       10,
+			checkpointInterval: 100,
+			readyList:          list.New(),
+			nextReadyList:      list.New(),
+		}
+	})
+
+	It("cuts batches only once they are full by default", func() {
+		queue(1, 1, 1)
+		Expect(prb.hasPending(1)).To(BeFalse())
+		Expect(prb.hasOutstanding(1)).To(BeTrue())
+
+		queue(1, 1, 1, 1, 1, 1, 1, 1)
+		Expect(prb.hasPending(1)).To(BeTrue())
+		Expect(prb.next()).To(HaveLen(10))
+
+		Expect(prb.hasPending(1)).To(BeFalse())
+		Expect(prb.next()).To(HaveLen(1))
+	})
+
+	When("a maximum batch size in bytes is set", func() {
+		BeforeEach(func() {
+			prb.maxBytes = 100
+		})
+
+		It("cuts the batch before the next request would exceed it", func() {
+			queue(40, 40, 40, 40)
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(2))
+
+			queue(40)
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(2))
+
+			Expect(prb.hasPending(1)).To(BeFalse())
+			Expect(prb.next()).To(HaveLen(1))
+		})
+
+		It("allows a single request larger than the maximum", func() {
+			queue(150, 10)
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(1))
+
+			Expect(prb.hasPending(1)).To(BeFalse())
+		})
+	})
+
+	When("a minimum batch size is set", func() {
+		BeforeEach(func() {
+			prb.minCount = 3
+		})
+
+		It("cuts the batch once the minimum is reached", func() {
+			queue(1, 1)
+			Expect(prb.hasPending(1)).To(BeFalse())
+
+			queue(1)
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(3))
+		})
+	})
+
+	When("a batch timeout is set", func() {
+		BeforeEach(func() {
+			prb.minCount = 5
+			prb.timeoutTicks = 2
+		})
+
+		It("cuts the batch once a request has been pending for the timeout", func() {
+			prb.tick()
+			queue(1)
+			Expect(prb.hasPending(1)).To(BeFalse())
+
+			prb.tick()
+			Expect(prb.hasPending(1)).To(BeFalse())
+
+			prb.tick()
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(1))
+
+			queue(1)
+			prb.tick()
+			Expect(prb.hasPending(1)).To(BeFalse())
+		})
+	})
+
+	When("the batch size is adaptive", func() {
+		BeforeEach(func() {
+			prb.adaptive = true
+			prb.minCount = 2
+		})
+
+		It("grows the fill target with the queue depth", func() {
+			queue(1, 1)
+			Expect(prb.hasPending(1)).To(BeTrue())
+			Expect(prb.next()).To(HaveLen(2))
+
+			for i := 0; i < 5; i++ {
+				queue(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+				Expect(prb.hasPending(1)).To(BeTrue())
+				Expect(prb.next()).To(HaveLen(10))
+			}
+
+			Expect(prb.fillTarget()).To(BeNumerically(">", uint32(2)))
+
+			for prb.hasOutstanding(1) {
+				prb.next()
+			}
+			queue(1, 1)
+			Expect(prb.hasPending(1)).To(BeFalse())
+		})
+	})
+})
@@ -11,16 +11,46 @@ SPDX-License-Identifier: Apache-2.0
 package reqstore
 
 import (
+	"bytes"
 	"fmt"
+	"strconv"
+
 	pb "github.com/IBM/mirbft/mirbftpb"
 	badger "github.com/dgraph-io/badger/v2"
 	"github.com/pkg/errors"
 )
 
+// committedPrefix prefixes the keys which record the sequence number at which
+// a request committed.  These keys sort by sequence number, so that garbage
+// collection need only iterate over the requests which it deletes.
+var committedPrefix = []byte("committed/")
+
+// seqNoLength is the length of the zero padded sequence number in a committed key.
+const seqNoLength = 20
+
 func key(ack *pb.RequestAck) []byte {
 	return []byte(fmt.Sprintf("%d.%d.%x", ack.ClientId, ack.ReqNo, ack.Digest))
 }
 
+func committedKey(seqNo uint64, ack *pb.RequestAck) []byte {
+	return append([]byte(fmt.Sprintf("%s%020d/", committedPrefix, seqNo)), key(ack)...)
+}
+
+// parseCommittedKey returns the sequence number and request key encoded in a committed key.
+func parseCommittedKey(committedKey []byte) (uint64, []byte, error) {
+	suffix := committedKey[len(committedPrefix):]
+	if len(suffix) < seqNoLength+1 {
+		return 0, nil, errors.Errorf("committed key '%s' is too short", committedKey)
+	}
+
+	seqNo, err := strconv.ParseUint(string(suffix[:seqNoLength]), 10, 64)
+	if err != nil {
+		return 0, nil, errors.WithMessagef(err, "could not parse sequence number of committed key '%s'", committedKey)
+	}
+
+	return seqNo, suffix[seqNoLength+1:], nil
+}
+
 type Store struct {
 	db *badger.DB
 }
@@ -63,18 +93,80 @@ func (s *Store) Get(requestAck *pb.RequestAck) ([]byte, error) {
 	return valCopy, err
 }
 
-func (s *Store) Commit(ack *pb.RequestAck) error {
+// Commit records that the request committed at the given sequence number.  The
+// request is retained, so that it may be forwarded to peers, until the checkpoint
+// which covers it becomes stable and it is garbage collected.
+func (s *Store) Commit(seqNo uint64, ack *pb.RequestAck) error {
 	return s.db.Update(func(txn *badger.Txn) error {
-		return txn.Delete(key(ack))
+		return txn.Set(committedKey(seqNo, ack), nil)
+	})
+}
+
+// GarbageCollect deletes every request which committed at or below the given
+// sequence number.
+func (s *Store) GarbageCollect(seqNo uint64) error {
+	var keys [][]byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{
+			Prefix: committedPrefix,
+		})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			committedKey := it.Item().KeyCopy(nil)
+			committedSeqNo, requestKey, err := parseCommittedKey(committedKey)
+			if err != nil {
+				return err
+			}
+
+			if committedSeqNo > seqNo {
+				break
+			}
+
+			keys = append(keys, committedKey, requestKey)
+		}
+		return nil
 	})
+	if err != nil {
+		return errors.WithMessage(err, "could not find committed requests")
+	}
+
+	wb := s.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		if err := wb.Delete(key); err != nil {
+			return errors.WithMessage(err, "could not delete committed request")
+		}
+	}
+
+	return wb.Flush()
 }
 
-func (s *Store) Uncommitted(forEach func(ack *pb.RequestAck)) error {
+func (s *Store) Uncommitted(forEach func(ack *pb.RequestAck, size int)) error {
 	return s.db.View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.IteratorOptions{})
+		committed := map[string]struct{}{}
+		it := txn.NewIterator(badger.IteratorOptions{
+			Prefix: committedPrefix,
+		})
+		for it.Rewind(); it.Valid(); it.Next() {
+			_, requestKey, err := parseCommittedKey(it.Item().Key())
+			if err != nil {
+				it.Close()
+				return err
+			}
+			committed[string(requestKey)] = struct{}{}
+		}
+		it.Close()
+
+		it = txn.NewIterator(badger.IteratorOptions{})
 		defer it.Close()
 		for it.Rewind(); it.Valid(); it.Next() {
 			keyName := it.Item().Key()
+			if bytes.HasPrefix(keyName, committedPrefix) {
+				continue
+			}
+			if _, ok := committed[string(keyName)]; ok {
+				continue
+			}
 			ack := &pb.RequestAck{
 				Digest: make([]byte, 0, 32),
 			}
@@ -85,7 +177,7 @@ func (s *Store) Uncommitted(forEach func(ack *pb.RequestAck)) error {
 			if n != 3 {
 				return errors.Errorf("could not scan request ack from key, unexpected!")
 			}
-			forEach(ack)
+			forEach(ack, int(it.Item().ValueSize()))
 		}
 		return nil
 	})
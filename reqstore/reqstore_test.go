@@ -71,10 +71,10 @@ var _ = Describe("Reqstore", func() {
 		err = reqStore.Store(ack2dot2, []byte("data2dot2"))
 		Expect(err).NotTo(HaveOccurred())
 
-		err = reqStore.Commit(ack1dot1)
+		err = reqStore.Commit(1, ack1dot1)
 		Expect(err).NotTo(HaveOccurred())
 
-		err = reqStore.Commit(ack1dot2)
+		err = reqStore.Commit(2, ack1dot2)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -83,9 +83,10 @@ var _ = Describe("Reqstore", func() {
 		os.RemoveAll(tmpDir)
 	})
 
-	It("returns all uncommitted txes", func() {
+	It("returns all uncommitted txes, with their sizes", func() {
 		count := 0
-		reqStore.Uncommitted(func(ack *pb.RequestAck) {
+		reqStore.Uncommitted(func(ack *pb.RequestAck, size int) {
+			Expect(size).To(Equal(len("data2dot1")))
 			switch ack.ReqNo {
 			case 1:
 				Expect(ack).To(Equal(ack2dot1))
@@ -100,4 +101,42 @@ var _ = Describe("Reqstore", func() {
 		})
 		Expect(count).To(Equal(3))
 	})
+
+	It("retains committed requests", func() {
+		data, err := reqStore.Get(ack1dot1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte("data1dot1")))
+
+		data, err = reqStore.Get(ack1dot2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte("data1dot2")))
+	})
+
+	It("garbage collects requests committed through the stable checkpoint", func() {
+		err := reqStore.GarbageCollect(1)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = reqStore.Get(ack1dot1)
+		Expect(err).To(HaveOccurred())
+
+		data, err := reqStore.Get(ack1dot2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte("data1dot2")))
+
+		err = reqStore.GarbageCollect(10)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = reqStore.Get(ack1dot2)
+		Expect(err).To(HaveOccurred())
+
+		count := 0
+		reqStore.Uncommitted(func(ack *pb.RequestAck, _ int) {
+			count++
+		})
+		Expect(count).To(Equal(3))
+
+		data, err = reqStore.Get(ack1dot3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte("data1dot3")))
+	})
 })
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"crypto/ed25519"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+
+	"github.com/pkg/errors"
+)
+
+// RequestVerifier is an optional component of the Processor which verifies
+// that a client request was genuinely issued by the client it claims to be
+// from, usually by checking a signature embedded in the request data.  Without
+// a RequestVerifier, any replica may fabricate requests on behalf of any client.
+// Verification is performed alongside request hashing, and requests which fail
+// verification are never stored, acknowledged, or preprepared by this replica.
+// Implementations must be deterministic, and safe for concurrent use.
+type RequestVerifier interface {
+	VerifyRequest(clientID, reqNo uint64, data []byte) error
+}
+
+// Ed25519RequestVerifier is a RequestVerifier which expects the request data to be
+// an application payload followed by an ed25519 signature, as produced by
+// SignRequest.
+type Ed25519RequestVerifier struct {
+	// PublicKeys maps the client IDs from NetworkState.Clients to the public
+	// key which signs requests for that client.
+	PublicKeys map[uint64]ed25519.PublicKey
+}
+
+func (erv *Ed25519RequestVerifier) VerifyRequest(clientID, reqNo uint64, data []byte) error {
+	publicKey, ok := erv.PublicKeys[clientID]
+	if !ok {
+		return errors.Errorf("no public key registered for client %d", clientID)
+	}
+
+	if len(data) < ed25519.SignatureSize {
+		return errors.Errorf("request data of length %d is too short to contain a signature", len(data))
+	}
+
+	payload := RequestPayload(data)
+	signature := data[len(payload):]
+
+	if !ed25519.Verify(publicKey, signedRequestBytes(clientID, reqNo, payload), signature) {
+		return errors.Errorf("signature does not verify for client %d", clientID)
+	}
+
+	return nil
+}
+
+// SignRequest returns a copy of the given request with the request data replaced
+// by the original data followed by an ed25519 signature over the client ID,
+// request number, and original data.
+func SignRequest(privateKey ed25519.PrivateKey, request *pb.Request) *pb.Request {
+	signature := ed25519.Sign(privateKey, signedRequestBytes(request.ClientId, request.ReqNo, request.Data))
+
+	data := make([]byte, 0, len(request.Data)+len(signature))
+	data = append(data, request.Data...)
+	data = append(data, signature...)
+
+	return &pb.Request{
+		ClientId: request.ClientId,
+		ReqNo:    request.ReqNo,
+		Data:     data,
+	}
+}
+
+// RequestPayload returns the application payload of request data produced
+// by SignRequest, stripping the signature.
+func RequestPayload(data []byte) []byte {
+	if len(data) < ed25519.SignatureSize {
+		return nil
+	}
+
+	return data[:len(data)-ed25519.SignatureSize]
+}
+
+func signedRequestBytes(clientID, reqNo uint64, payload []byte) []byte {
+	result := make([]byte, 0, 16+len(payload))
+	result = append(result, uint64ToBytes(clientID)...)
+	result = append(result, uint64ToBytes(reqNo)...)
+	return append(result, payload...)
+}
+
+// VerifyHashRequest returns an error if the hash request is for a client request
+// which the verifier rejects.  Hash requests of other types are always accepted,
+// as is every request when the verifier is nil.  Processor implementations
+// which support request verification should use this, so that they agree on
+// which hash requests are verified.
+func VerifyHashRequest(verifier RequestVerifier, hashRequest *HashRequest) error {
+	if verifier == nil {
+		return nil
+	}
+
+	switch origin := hashRequest.Origin.Type.(type) {
+	case *pb.HashResult_Request_:
+		request := origin.Request.Request
+		return verifier.VerifyRequest(request.ClientId, request.ReqNo, request.Data)
+	case *pb.HashResult_VerifyRequest_:
+		ack := origin.VerifyRequest.RequestAck
+		return verifier.VerifyRequest(ack.ClientId, ack.ReqNo, origin.VerifyRequest.RequestData)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+
+	"github.com/pkg/errors"
+)
+
+// ActionsProcessor performs the Actions delivered by the node, returning the
+// results to be added back to the node.  The Processor, the ProcessorWorkPool,
+// and the PipelinedProcessor implement this interface.  If the returned results are nil,
+// it is assumed that the processor has returned any results to the node itself.
+type ActionsProcessor interface {
+	Process(*Actions) (*ActionResults, error)
+}
+
+// StateTransferFunc is invoked by Run when the node requests a state transfer.
+// It should fetch the application state corresponding to the target from the
+// other nodes in the network, apply it, and return the network state associated
+// with the target checkpoint.  If the target cannot be obtained, for instance
+// because it has been garbage collected, an error should be returned, and the
+// node will be informed that the state transfer failed.  It is invoked in its
+// own go routine, and the node continues to process actions in the interim.
+// At most one state transfer is in flight; should the node request another, the
+// context of the previous one is cancelled, and Run waits for it to return
+// before starting the next.  The context is likewise cancelled when Run exits.
+// A state transfer which fails because it was cancelled is not reported to the
+// node, but one which completes regardless is, as the application state changed.
+type StateTransferFunc func(ctx context.Context, target *StateTarget) (*pb.NetworkState, error)
+
+// RunOpts contains optional parameters for Run.
+type RunOpts struct {
+	// StateTransfer is invoked whenever the node requests a state transfer.
+	// If it is nil, a request for state transfer stops the node with an error.
+	StateTransfer StateTransferFunc
+}
+
+// Run drives the node, reading actions from Ready() and performing them with
+// the processor, adding their results, and ticking the node at the given interval.
+// Run returns immediately.  The returned channel receives exactly one value once
+// the node has exited, and is then closed.  If the context is cancelled, the node is
+// stopped and nil is sent.  Similarly, if the node is stopped via Stop, nil is sent.
+// Otherwise, if processing fails, the node is stopped with the processing error, and
+// this error (or any other exit error of the node) is sent.
+func Run(ctx context.Context, node *Node, processor ActionsProcessor, tickInterval time.Duration, opts RunOpts) <-chan error {
+	errC := make(chan error, 1)
+
+	go func() {
+		errC <- run(ctx, node, processor, tickInterval, opts)
+		close(errC)
+	}()
+
+	return errC
+}
+
+func run(ctx context.Context, node *Node, processor ActionsProcessor, tickInterval time.Duration, opts RunOpts) error {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	var inFlight *transferInFlight
+	defer func() {
+		inFlight.cancel()
+	}()
+
+	for {
+		select {
+		case actions := <-node.Ready():
+			results, err := processor.Process(&actions)
+			if err != nil {
+				node.StopWithError(err)
+				return err
+			}
+
+			if results != nil {
+				if err := node.AddResults(*results); err != nil {
+					return exitError(err)
+				}
+			}
+
+			if actions.StateTransfer == nil {
+				continue
+			}
+
+			if opts.StateTransfer == nil {
+				err := errors.Errorf("state transfer to checkpoint at seq_no=%d requested, but no state transfer function configured", actions.StateTransfer.SeqNo)
+				node.StopWithError(err)
+				return err
+			}
+
+			inFlight.cancel()
+			inFlight = startTransfer(ctx, node, opts.StateTransfer, actions.StateTransfer)
+		case <-ticker.C:
+			if err := node.Tick(); err != nil {
+				return exitError(err)
+			}
+		case <-node.Err():
+			_, err := node.Status(context.Background())
+			return exitError(err)
+		case <-ctx.Done():
+			node.Stop()
+			return nil
+		}
+	}
+}
+
+// transferInFlight tracks the state transfer currently being performed
+// on behalf of the node.
+type transferInFlight struct {
+	cancelFunc context.CancelFunc
+	doneC      chan struct{}
+}
+
+// startTransfer performs the state transfer in its own go routine.
+func startTransfer(ctx context.Context, node *Node, stateTransfer StateTransferFunc, target *StateTarget) *transferInFlight {
+	transferCtx, cancel := context.WithCancel(ctx)
+	t := &transferInFlight{
+		cancelFunc: cancel,
+		doneC:      make(chan struct{}),
+	}
+
+	go func() {
+		defer close(t.doneC)
+		transfer(transferCtx, node, stateTransfer, target)
+	}()
+
+	return t
+}
+
+// cancel cancels the state transfer, if any, and waits for it to return.
+// Waiting cannot deadlock, as the node accepts state transfer results
+// while it waits for its actions to be read.
+func (t *transferInFlight) cancel() {
+	if t == nil {
+		return
+	}
+
+	t.cancelFunc()
+	<-t.doneC
+}
+
+// transfer invokes the state transfer function, and informs the node
+// of the outcome, unless the transfer failed because it was cancelled.
+// Errors returned by the node indicate that it has exited, which the run
+// loop observes independently.
+func transfer(ctx context.Context, node *Node, stateTransfer StateTransferFunc, target *StateTarget) {
+	networkState, err := stateTransfer(ctx, target)
+	if err != nil && ctx.Err() != nil && errors.Cause(err) == ctx.Err() {
+		return
+	}
+
+	if err != nil {
+		node.Config.Logger.Log(LevelWarn, "state transfer failed", "seq_no", target.SeqNo, "error", err)
+		node.StateTransferFailed(target)
+		return
+	}
+
+	node.StateTransferComplete(target, networkState)
+}
+
+// exitError translates the exit error of the node into the error
+// reported by Run, treating a graceful stop as no error.
+func exitError(err error) error {
+	if err == ErrStopped {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("Run", func() {
+	var (
+		node      *mirbft.Node
+		processor *mirbft.Processor
+		log       *FakeLog
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		var err error
+		node, err = mirbft.StartNewNode(
+			&mirbft.Config{
+				ID:                   0,
+				BatchSize:            1,
+				SuspectTicks:         4,
+				HeartbeatTicks:       2,
+				NewEpochTimeoutTicks: 8,
+				BufferSize:           5 * 1024 * 1024,
+				Logger:               mirbft.ConsoleWarnLogger,
+			},
+			mirbft.StandardInitialNetworkState(1, 0),
+			[]byte("fake-application-state"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		log = &FakeLog{
+			CommitC: make(chan *pb.QEntry, 100),
+		}
+
+		processor = &mirbft.Processor{
+			Node:   node,
+			Hasher: sha256.New,
+			Log:    log,
+			WAL:    &FailingWAL{},
+			RequestStore: &MemRequestStore{
+				Requests: map[string][]byte{},
+			},
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+		node.Stop()
+	})
+
+	It("commits proposals, and exits cleanly when the context is cancelled", func() {
+		errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{})
+
+		proposer, err := node.ClientProposer(context.Background(), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := uint64(0); i < 10; i++ {
+			err := proposer.Propose(context.Background(), &pb.Request{
+				ClientId: 0,
+				ReqNo:    i,
+				Data:     Uint64ToBytes(i),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for i := uint64(0); i < 10; i++ {
+			var entry *pb.QEntry
+			Eventually(log.CommitC, 5*time.Second).Should(Receive(&entry))
+			Expect(entry.Requests).To(HaveLen(1))
+			Expect(entry.Requests[0].ReqNo).To(Equal(i))
+		}
+
+		Consistently(errC, 100*time.Millisecond).ShouldNot(Receive())
+
+		cancel()
+		Eventually(errC).Should(Receive(BeNil()))
+		Eventually(errC).Should(BeClosed())
+		Expect(node.Err()).To(BeClosed())
+	})
+
+	When("the pipelined processor is used", func() {
+		var pipeline *mirbft.PipelinedProcessor
+
+		BeforeEach(func() {
+			pipeline = mirbft.NewPipelinedProcessor(processor, mirbft.PipelinedProcessorOpts{})
+		})
+
+		AfterEach(func() {
+			pipeline.Stop()
+		})
+
+		It("commits proposals", func() {
+			errC := mirbft.Run(ctx, node, pipeline, 10*time.Millisecond, mirbft.RunOpts{})
+
+			proposer, err := node.ClientProposer(context.Background(), 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := uint64(0); i < 10; i++ {
+				err := proposer.Propose(context.Background(), &pb.Request{
+					ClientId: 0,
+					ReqNo:    i,
+					Data:     Uint64ToBytes(i),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			for i := uint64(0); i < 10; i++ {
+				var entry *pb.QEntry
+				Eventually(log.CommitC, 5*time.Second).Should(Receive(&entry))
+				Expect(entry.Requests[0].ReqNo).To(Equal(i))
+			}
+
+			cancel()
+			Eventually(errC).Should(Receive(BeNil()))
+		})
+	})
+
+	It("reports nil when the node is stopped", func() {
+		errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{})
+
+		node.Stop()
+		Eventually(errC).Should(Receive(BeNil()))
+	})
+
+	When("the node must state transfer", func() {
+		var (
+			networkState *pb.NetworkState
+			targetC      chan *mirbft.StateTarget
+			failures     int
+		)
+
+		BeforeEach(func() {
+			node.Stop()
+
+			networkState = mirbft.StandardInitialNetworkState(1, 0)
+
+			var err error
+			node, err = mirbft.JoinNetwork(
+				node.Config,
+				&pb.CEntry{
+					SeqNo:           0,
+					CheckpointValue: []byte("fake-application-state"),
+					NetworkState:    networkState,
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			processor.Node = node
+
+			targetC = make(chan *mirbft.StateTarget, 10)
+			failures = 0
+		})
+
+		stateTransfer := func(ctx context.Context, target *mirbft.StateTarget) (*pb.NetworkState, error) {
+			targetC <- target
+			if failures > 0 {
+				failures--
+				return nil, fmt.Errorf("checkpoint unavailable")
+			}
+			return networkState, nil
+		}
+
+		proposeAndCommit := func() {
+			proposer, err := node.ClientProposer(context.Background(), 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = proposer.Propose(context.Background(), &pb.Request{
+				ClientId: 0,
+				ReqNo:    0,
+				Data:     Uint64ToBytes(0),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var entry *pb.QEntry
+			Eventually(log.CommitC, 5*time.Second).Should(Receive(&entry))
+			Expect(entry.Requests[0].ReqNo).To(Equal(uint64(0)))
+		}
+
+		It("performs the transfer and reports its completion to the node", func() {
+			errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{
+				StateTransfer: stateTransfer,
+			})
+
+			var target *mirbft.StateTarget
+			Eventually(targetC).Should(Receive(&target))
+			Expect(target.SeqNo).To(Equal(uint64(0)))
+			Expect(target.Value).To(Equal([]byte("fake-application-state")))
+
+			proposeAndCommit()
+			Expect(targetC).To(BeEmpty())
+
+			cancel()
+			Eventually(errC).Should(Receive(BeNil()))
+		})
+
+		When("the state transfer fails", func() {
+			BeforeEach(func() {
+				failures = 2
+			})
+
+			It("reports the failure to the node, which requests the target again", func() {
+				errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{
+					StateTransfer: stateTransfer,
+				})
+
+				for i := 0; i < 3; i++ {
+					var target *mirbft.StateTarget
+					Eventually(targetC).Should(Receive(&target))
+					Expect(target.SeqNo).To(Equal(uint64(0)))
+				}
+
+				proposeAndCommit()
+				Expect(targetC).To(BeEmpty())
+
+				cancel()
+				Eventually(errC).Should(Receive(BeNil()))
+			})
+		})
+
+		When("no state transfer function is configured", func() {
+			It("stops the node with an error", func() {
+				errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{})
+
+				var err error
+				Eventually(errC).Should(Receive(&err))
+				Expect(err).To(MatchError("state transfer to checkpoint at seq_no=0 requested, but no state transfer function configured"))
+			})
+		})
+	})
+
+	When("processing fails", func() {
+		BeforeEach(func() {
+			processor.WAL = &FailingWAL{
+				Err: fmt.Errorf("no space left on device"),
+			}
+		})
+
+		It("stops the node and reports the error", func() {
+			errC := mirbft.Run(ctx, node, processor, 10*time.Millisecond, mirbft.RunOpts{})
+
+			var err error
+			Eventually(errC).Should(Receive(&err))
+			Expect(err).To(MatchError("could not persist entry: no space left on device"))
+			Eventually(errC).Should(BeClosed())
+
+			Expect(node.Err()).To(BeClosed())
+			_, exitErr := node.Status(context.Background())
+			Expect(exitErr).To(Equal(err))
+		})
+	})
+})
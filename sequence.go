@@ -264,8 +264,12 @@ func (s *sequence) applyPrepareMsg(source nodeID, digest []byte) *Actions {
 	// the only prepare we get from the owner is our own artificial,
 	// and the choice has already been recorded for the preprepare.
 	if source != s.owner && choice.state > nodeSeqUninitialized {
-		// TODO log oddity
-		return &Actions{}
+		if bytes.Equal(choice.digest, digest) {
+			// Simply a duplicate
+			return &Actions{}
+		}
+
+		return (&Actions{}).misbehave(source, ConflictingMsgs, s.choiceMsg(source, choice), s.prepareMsg(digest))
 	}
 
 	choice.state = nodeSeqPreprepared
@@ -323,12 +327,17 @@ func (s *sequence) checkPrepareQuorum() *Actions {
 
 func (s *sequence) applyCommitMsg(source nodeID, digest []byte) *Actions {
 	choice := s.nodeChoice(source)
+	if choice.state > nodeSeqUninitialized && !bytes.Equal(choice.digest, digest) {
+		return (&Actions{}).misbehave(source, ConflictingMsgs, s.choiceMsg(source, choice), s.commitMsg(digest))
+	}
+
 	if choice.state > nodeSeqPreprepared {
-		// TODO log oddity
+		// Simply a duplicate
 		return &Actions{}
 	}
 
 	choice.state = nodeSeqPrepared
+	choice.digest = digest
 
 	if choice.state == nodeSeqUninitialized {
 		// We also count a commit as an implicit prepare if we have not gotten one
@@ -340,6 +349,51 @@ func (s *sequence) applyCommitMsg(source nodeID, digest []byte) *Actions {
 	return s.advanceState()
 }
 
+// choiceMsg reconstructs the message through which the source made its
+// current choice for this sequence, as evidence should it later conflict.
+func (s *sequence) choiceMsg(source nodeID, choice *nodeSeqChoice) *pb.Msg {
+	switch {
+	case choice.state == nodeSeqPrepared:
+		return s.commitMsg(choice.digest)
+	case source == s.owner:
+		return &pb.Msg{
+			Type: &pb.Msg_Preprepare{
+				Preprepare: &pb.Preprepare{
+					SeqNo: s.seqNo,
+					Epoch: s.epoch,
+					Batch: s.batch,
+				},
+			},
+		}
+	default:
+		return s.prepareMsg(choice.digest)
+	}
+}
+
+func (s *sequence) prepareMsg(digest []byte) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_Prepare{
+			Prepare: &pb.Prepare{
+				SeqNo:  s.seqNo,
+				Epoch:  s.epoch,
+				Digest: digest,
+			},
+		},
+	}
+}
+
+func (s *sequence) commitMsg(digest []byte) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_Commit{
+			Commit: &pb.Commit{
+				SeqNo:  s.seqNo,
+				Epoch:  s.epoch,
+				Digest: digest,
+			},
+		},
+	}
+}
+
 func (s *sequence) checkCommitQuorum() {
 	agreements := s.commits[string(s.digest)]
 	// Do not commit unless we have sent a commit
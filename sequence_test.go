@@ -280,3 +280,70 @@ var _ = XDescribe("sequence", func() {
 		})
 	})
 })
+
+var _ = Describe("sequence misbehavior", func() {
+	var (
+		s *sequence
+	)
+
+	BeforeEach(func() {
+		s = &sequence{
+			myConfig: &pb.StateEvent_InitialParameters{
+				Id: 1,
+			},
+			networkConfig: &pb.NetworkState_Config{
+				Nodes: []uint64{0, 1, 2, 3},
+				F:     1,
+			},
+			epoch:       4,
+			seqNo:       5,
+			owner:       0,
+			nodeChoices: map[nodeID]*nodeSeqChoice{},
+			prepares:    map[string]int{},
+			commits:     map[string]int{},
+		}
+	})
+
+	It("reports conflicting prepares and does not count the second", func() {
+		Expect(s.applyPrepareMsg(2, []byte("digest")).Misbehaviors).To(BeEmpty())
+		Expect(s.applyPrepareMsg(2, []byte("digest")).Misbehaviors).To(BeEmpty())
+
+		actions := s.applyPrepareMsg(2, []byte("other-digest"))
+		Expect(actions.Misbehaviors).To(Equal([]*Misbehavior{
+			{
+				Node: 2,
+				Kind: ConflictingMsgs,
+				Msgs: []*pb.Msg{
+					s.prepareMsg([]byte("digest")),
+					s.prepareMsg([]byte("other-digest")),
+				},
+			},
+		}))
+		Expect(s.prepares).To(Equal(map[string]int{"digest": 1}))
+	})
+
+	It("reports commits which conflict with a prepare", func() {
+		s.applyPrepareMsg(2, []byte("digest"))
+
+		actions := s.applyCommitMsg(2, []byte("other-digest"))
+		Expect(actions.Misbehaviors).To(HaveLen(1))
+		Expect(actions.Misbehaviors[0].Msgs).To(Equal([]*pb.Msg{
+			s.prepareMsg([]byte("digest")),
+			s.commitMsg([]byte("other-digest")),
+		}))
+		Expect(s.commits).To(BeEmpty())
+	})
+
+	It("reports conflicting commits", func() {
+		Expect(s.applyCommitMsg(3, []byte("digest")).Misbehaviors).To(BeEmpty())
+
+		actions := s.applyCommitMsg(3, []byte("other-digest"))
+		Expect(actions.Misbehaviors).To(HaveLen(1))
+		Expect(actions.Misbehaviors[0].Node).To(Equal(uint64(3)))
+		Expect(actions.Misbehaviors[0].Msgs).To(Equal([]*pb.Msg{
+			s.commitMsg([]byte("digest")),
+			s.commitMsg([]byte("other-digest")),
+		}))
+		Expect(s.commits).To(Equal(map[string]int{"digest": 1}))
+	})
+})
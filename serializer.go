@@ -39,6 +39,7 @@ type serializer struct {
 	reqStorage RequestStorage
 
 	exitMutex  sync.Mutex
+	stopErr    error
 	exitErr    error
 	exitStatus *status.StateMachine
 }
@@ -64,11 +65,15 @@ func newSerializer(myConfig *Config, walStorage WALStorage, reqStorage RequestSt
 	return s, nil
 }
 
-func (s *serializer) stop() {
+// stop causes the serializer to exit with the supplied error as its
+// exit error.  If the serializer has already been stopped, the original
+// error is retained.
+func (s *serializer) stop(err error) {
 	s.exitMutex.Lock()
 	select {
 	case <-s.doneC:
 	default:
+		s.stopErr = err
 		close(s.doneC)
 	}
 	s.exitMutex.Unlock()
@@ -85,7 +90,8 @@ func (s *serializer) getExitErr() error {
 // of other go routines.
 func (s *serializer) run() (exitErr error) {
 	sm := &StateMachine{
-		Logger: s.myConfig.Logger,
+		Logger:       s.myConfig.Logger,
+		LeaderPolicy: s.myConfig.LeaderPolicy,
 	}
 
 	defer func() {
@@ -133,12 +139,23 @@ func (s *serializer) run() (exitErr error) {
 	err := applyEvent(&pb.StateEvent{
 		Type: &pb.StateEvent_Initialize{
 			Initialize: &pb.StateEvent_InitialParameters{
-				Id:                   s.myConfig.ID,
-				BatchSize:            s.myConfig.BatchSize,
-				HeartbeatTicks:       s.myConfig.HeartbeatTicks,
-				SuspectTicks:         s.myConfig.SuspectTicks,
-				NewEpochTimeoutTicks: s.myConfig.NewEpochTimeoutTicks,
-				BufferSize:           s.myConfig.BufferSize,
+				Id:                             s.myConfig.ID,
+				BatchSize:                      s.myConfig.BatchSize,
+				HeartbeatTicks:                 s.myConfig.HeartbeatTicks,
+				SuspectTicks:                   s.myConfig.SuspectTicks,
+				NewEpochTimeoutTicks:           s.myConfig.NewEpochTimeoutTicks,
+				BufferSize:                     s.myConfig.BufferSize,
+				MaxBatchBytes:                  s.myConfig.MaxBatchBytes,
+				MinBatchSize:                   s.myConfig.MinBatchSize,
+				BatchTimeoutTicks:              s.myConfig.BatchTimeoutTicks,
+				AdaptiveBatchSize:              s.myConfig.AdaptiveBatchSize,
+				CorrectFetchTicks:              s.myConfig.CorrectFetchTicks,
+				FetchTimeoutTicks:              s.myConfig.FetchTimeoutTicks,
+				AckResendTicks:                 s.myConfig.AckResendTicks,
+				AckResendBackoff:               pb.StateEvent_InitialParameters_Backoff(s.myConfig.AckResendBackoff),
+				AckResendMaxTicks:              s.myConfig.AckResendMaxTicks,
+				TransferOnCheckpointDivergence: s.myConfig.TransferOnCheckpointDivergence,
+				TransferRetryTicks:             s.myConfig.TransferRetryTicks,
 			},
 		},
 	})
@@ -167,7 +184,7 @@ func (s *serializer) run() (exitErr error) {
 		return errors.WithMessage(err, "failed to load persisted from WALStorage")
 	}
 
-	err = s.reqStorage.Uncommitted(func(ack *pb.RequestAck) {
+	err = s.reqStorage.Uncommitted(func(ack *pb.RequestAck, size int) {
 		// Because we do not require that requests be iterate over
 		// in the order in which they were originally persisted, we could
 		// accidentally reply with an ack for a different request than we
@@ -180,6 +197,7 @@ func (s *serializer) run() (exitErr error) {
 			Type: &pb.StateEvent_LoadRequest{
 				LoadRequest: &pb.StateEvent_OutstandingRequest{
 					RequestAck: ack,
+					Size:       uint64(size),
 				},
 			},
 		})
@@ -214,6 +232,12 @@ func (s *serializer) run() (exitErr error) {
 				Type: step,
 			})
 		case actionsC <- *actions:
+			if actionsInterceptor, ok := s.myConfig.EventInterceptor.(ActionsInterceptor); ok {
+				ready := *actions
+				if err := actionsInterceptor.InterceptActions(&ready); err != nil {
+					return errors.WithMessage(err, "event interceptor error")
+				}
+			}
 			actions.clear()
 			actionsC = nil
 			err = applyEvent(&pb.StateEvent{
@@ -243,7 +267,10 @@ func (s *serializer) run() (exitErr error) {
 				},
 			})
 		case <-s.doneC:
-			return ErrStopped
+			s.exitMutex.Lock()
+			stopErr := s.stopErr
+			s.exitMutex.Unlock()
+			return stopErr
 		}
 
 		if !actions.isEmpty() {
@@ -253,5 +280,10 @@ func (s *serializer) run() (exitErr error) {
 		if err != nil {
 			return err
 		}
+
+		if sm.state == smRemoved && actions.isEmpty() {
+			// Our final actions have been consumed
+			return ErrRemoved
+		}
 	}
 }
@@ -5,11 +5,27 @@ SPDX-License-Identifier: Apache-2.0
 */
 
 // Package simplewal is a basic WAL implementation meant to be the first 'real' WAL
-// option for mirbft.  More sophisticated WALs with checksums, byte alignments, etc.
-// may be produced in the future, but this is just a simple place to start.
+// option for mirbft.  Each record is stored with a CRC32 checksum, and a record
+// which was torn by a crash while being written is discarded when the WAL is
+// reopened.  More sophisticated WALs with byte alignments, etc. may be produced in
+// the future, but this is just a simple place to start.
+//
+// Records are written without syncing, as the state machine requests a Sync
+// before any action which depends on the durability of the records.  Therefore,
+// any record which was not completely written at the time of a crash was never
+// synced, and may safely be discarded.  Corruption of any other record cannot
+// be repaired without losing synced state, so it is reported rather than
+// repaired, see Verify and Repair.
 package simplewal
 
 import (
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"sync"
 
 	pb "github.com/IBM/mirbft/mirbftpb"
@@ -19,23 +35,209 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrCorrupt is the cause of any error returned because a record failed
+// verification.
+var ErrCorrupt = errors.New("WAL corrupt")
+
+// recordVersion prefixes every checksummed record.  A marshaled pb.Persistent
+// never begins with a zero byte, so records written before checksums were
+// introduced are distinguished by their first byte, and are read without
+// verification.
+const recordVersion = 0
+
+// recordHeaderSize is the size of the version byte and checksum.
+const recordHeaderSize = 5
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
 type WAL struct {
 	mutex sync.Mutex
 	log   *wal.Log
 }
 
 func Open(path string) (*WAL, error) {
-	log, err := wal.Open(path, &wal.Options{
+	options := &wal.Options{
 		NoSync: true,
 		NoCopy: true,
-	})
+	}
+
+	log, err := wal.Open(path, options)
+	if err == wal.ErrCorrupt {
+		// The final segment could not be parsed, most likely because
+		// its last record was only partially written.
+		if err := truncateTornSegment(path); err != nil {
+			return nil, err
+		}
+
+		log, err = wal.Open(path, options)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "could not open WAL")
 	}
 
-	return &WAL{
+	w := &WAL{
 		log: log,
-	}, nil
+	}
+
+	if err := w.truncateTornRecords(); err != nil {
+		log.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// truncateTornSegment truncates the final segment file in the WAL directory
+// after the last record which may be parsed in its entirety.
+func truncateTornSegment(path string) error {
+	fileInfos, err := ioutil.ReadDir(path)
+	if err != nil {
+		return errors.WithMessage(err, "could not read WAL directory")
+	}
+
+	// Segments are named by their zero padded starting index
+	var segments []string
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || len(name) != 20 {
+			continue
+		}
+		if _, err := strconv.ParseUint(name, 10, 64); err != nil {
+			continue
+		}
+		segments = append(segments, name)
+	}
+
+	if len(segments) == 0 {
+		return errors.Errorf("WAL is corrupt, but no segment files were found")
+	}
+
+	sort.Strings(segments)
+	segmentPath := filepath.Join(path, segments[len(segments)-1])
+
+	data, err := ioutil.ReadFile(segmentPath)
+	if err != nil {
+		return errors.WithMessage(err, "could not read final WAL segment")
+	}
+
+	// Each entry in the segment is a uvarint size, followed by the record
+	validLength := 0
+	for validLength < len(data) {
+		size, n := binary.Uvarint(data[validLength:])
+		if n <= 0 || uint64(len(data)-validLength-n) < size {
+			break
+		}
+		validLength += n + int(size)
+	}
+
+	if validLength == len(data) {
+		// The final segment is intact, so the corruption lies elsewhere
+		return errors.WithMessage(ErrCorrupt, "could not open WAL")
+	}
+
+	if err := os.Truncate(segmentPath, int64(validLength)); err != nil {
+		return errors.WithMessage(err, "could not truncate torn record from final WAL segment")
+	}
+
+	return nil
+}
+
+// truncateTornRecords removes any trailing records which fail verification.
+// This occurs when space for a record was allocated, but its contents
+// were not completely written before a crash.
+func (w *WAL) truncateTornRecords() error {
+	firstIndex, err := w.log.FirstIndex()
+	if err != nil {
+		return errors.WithMessage(err, "could not read first index")
+	}
+
+	if firstIndex == 0 {
+		// WAL is empty
+		return nil
+	}
+
+	lastIndex, err := w.log.LastIndex()
+	if err != nil {
+		return errors.WithMessage(err, "could not read last index")
+	}
+
+	validIndex := lastIndex
+	for ; validIndex >= firstIndex; validIndex-- {
+		if _, err := w.read(validIndex); err == nil {
+			break
+		}
+	}
+
+	if validIndex == lastIndex {
+		return nil
+	}
+
+	if validIndex < firstIndex {
+		return errors.WithMessage(ErrCorrupt, "no record in the WAL is valid")
+	}
+
+	if err := w.log.TruncateBack(validIndex); err != nil {
+		return errors.WithMessagef(err, "could not truncate torn records after index %d", validIndex)
+	}
+
+	return nil
+}
+
+// encodeRecord marshals the entry, prefixed by the record version
+// and a checksum of the marshaled entry.
+func encodeRecord(p *pb.Persistent) ([]byte, error) {
+	data, err := proto.Marshal(p)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not marshal")
+	}
+
+	record := make([]byte, recordHeaderSize, recordHeaderSize+len(data))
+	record[0] = recordVersion
+	binary.BigEndian.PutUint32(record[1:recordHeaderSize], crc32.Checksum(data, crcTable))
+	return append(record, data...), nil
+}
+
+// decodeRecord verifies the checksum of the record and unmarshals it.
+func decodeRecord(record []byte) (*pb.Persistent, error) {
+	data := record
+	switch {
+	case len(record) == 0:
+		return nil, errors.WithMessage(ErrCorrupt, "record is empty")
+	case record[0] != recordVersion:
+		// A record written before checksums were introduced
+	case len(record) < recordHeaderSize:
+		return nil, errors.WithMessagef(ErrCorrupt, "record of length %d is too short to contain a checksum", len(record))
+	default:
+		data = record[recordHeaderSize:]
+		if binary.BigEndian.Uint32(record[1:recordHeaderSize]) != crc32.Checksum(data, crcTable) {
+			return nil, errors.WithMessage(ErrCorrupt, "record checksum mismatch")
+		}
+	}
+
+	result := &pb.Persistent{}
+	if err := proto.Unmarshal(data, result); err != nil {
+		return nil, errors.WithMessage(ErrCorrupt, "could not decode record")
+	}
+
+	return result, nil
+}
+
+// read reads and decodes the record at the given index.
+func (w *WAL) read(index uint64) (*pb.Persistent, error) {
+	data, err := w.log.Read(index)
+	if err == wal.ErrCorrupt {
+		return nil, errors.WithMessagef(ErrCorrupt, "could not read index %d", index)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not read index %d", index)
+	}
+
+	result, err := decodeRecord(data)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid record at index %d", index)
+	}
+
+	return result, nil
 }
 
 func (w *WAL) IsEmpty() (bool, error) {
@@ -62,31 +264,97 @@ func (w *WAL) LoadAll(forEach func(index uint64, p *pb.Persistent)) error {
 
 	lastIndex, err := w.log.LastIndex()
 	if err != nil {
-		return errors.WithMessage(err, "could not read first index")
+		return errors.WithMessage(err, "could not read last index")
 	}
 
 	for i := firstIndex; i <= lastIndex; i++ {
-		data, err := w.log.Read(i)
+		result, err := w.read(i)
 		if err != nil {
-			return errors.WithMessagef(err, "could not read index %d", i)
+			return err
 		}
 
-		result := &pb.Persistent{}
-		err = proto.Unmarshal(data, result)
-		if err != nil {
-			return errors.WithMessage(err, "error decoding to proto, is the WAL corrupt?")
+		forEach(i, result)
+	}
+
+	return nil
+}
+
+// Verify reads every record in the WAL, and returns an error, whose cause is
+// ErrCorrupt, identifying the first record which fails verification.
+func (w *WAL) Verify() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err := w.firstCorrupt()
+	return err
+}
+
+// firstCorrupt returns the index of the first record which fails verification,
+// and the reason, or 0 and nil if every record is valid.
+func (w *WAL) firstCorrupt() (uint64, error) {
+	firstIndex, err := w.log.FirstIndex()
+	if err != nil {
+		return 0, errors.WithMessage(err, "could not read first index")
+	}
+
+	if firstIndex == 0 {
+		// WAL is empty
+		return 0, nil
+	}
+
+	lastIndex, err := w.log.LastIndex()
+	if err != nil {
+		return 0, errors.WithMessage(err, "could not read last index")
+	}
+
+	for i := firstIndex; i <= lastIndex; i++ {
+		if _, err := w.read(i); err != nil {
+			return i, err
 		}
+	}
 
-		forEach(i, result)
+	return 0, nil
+}
+
+// Repair truncates the WAL back to the last record preceding the first record
+// which fails verification.  Note, this discards every record after the corrupt
+// record, including valid ones, and unlike a torn record, these records may have
+// been synced and acted upon.  Restarting a node from a repaired WAL may therefore
+// cause it to behave in a byzantine way, and so it should only be done by an
+// operator who understands the consequences.  If the first record of the WAL is
+// corrupt, the WAL cannot be repaired and an error is returned.
+func (w *WAL) Repair() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	corruptIndex, err := w.firstCorrupt()
+	if err == nil {
+		return nil
+	}
+
+	if errors.Cause(err) != ErrCorrupt {
+		return err
+	}
+
+	firstIndex, err := w.log.FirstIndex()
+	if err != nil {
+		return errors.WithMessage(err, "could not read first index")
+	}
+
+	if corruptIndex == firstIndex {
+		return errors.WithMessagef(ErrCorrupt, "first record at index %d is corrupt, cannot repair", corruptIndex)
+	}
+
+	if err := w.log.TruncateBack(corruptIndex - 1); err != nil {
+		return errors.WithMessagef(err, "could not truncate WAL after index %d", corruptIndex-1)
 	}
 
 	return nil
 }
 
 func (w *WAL) Write(index uint64, p *pb.Persistent) error {
-	data, err := proto.Marshal(p)
+	data, err := encodeRecord(p)
 	if err != nil {
-		return errors.WithMessage(err, "could not marshal")
+		return err
 	}
 
 	w.mutex.Lock()
@@ -0,0 +1,13 @@
+package simplewal_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSimplewal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Simplewal Suite")
+}
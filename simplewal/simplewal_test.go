@@ -0,0 +1,243 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simplewal_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/simplewal"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/wal"
+	"google.golang.org/protobuf/proto"
+)
+
+func entry(seqNo uint64) *pb.Persistent {
+	return &pb.Persistent{
+		Type: &pb.Persistent_CEntry{
+			CEntry: &pb.CEntry{
+				SeqNo:           seqNo,
+				CheckpointValue: []byte("value"),
+			},
+		},
+	}
+}
+
+func loadSeqNos(w *simplewal.WAL) ([]uint64, error) {
+	var seqNos []uint64
+	err := w.LoadAll(func(index uint64, p *pb.Persistent) {
+		Expect(p.Type).To(BeAssignableToTypeOf(&pb.Persistent_CEntry{}))
+		seqNos = append(seqNos, p.Type.(*pb.Persistent_CEntry).CEntry.SeqNo)
+	})
+	return seqNos, err
+}
+
+// recordOffsets parses the segment file, returning the offset of the
+// data of each record.
+func recordOffsets(segment []byte) []int {
+	var offsets []int
+	for pos := 0; pos < len(segment); {
+		size, n := binary.Uvarint(segment[pos:])
+		Expect(n).To(BeNumerically(">", 0))
+		offsets = append(offsets, pos+n)
+		pos += n + int(size)
+	}
+	return offsets
+}
+
+var _ = Describe("WAL", func() {
+	var (
+		tmpDir      string
+		segmentPath string
+		w           *simplewal.WAL
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "simplewal-test")
+		Expect(err).NotTo(HaveOccurred())
+		segmentPath = filepath.Join(tmpDir, "00000000000000000001")
+
+		w, err = simplewal.Open(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := uint64(1); i <= 5; i++ {
+			Expect(w.Write(i, entry(i))).To(Succeed())
+		}
+		Expect(w.Sync()).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if w != nil {
+			w.Close()
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	corruptByte := func(record int) {
+		segment, err := ioutil.ReadFile(segmentPath)
+		Expect(err).NotTo(HaveOccurred())
+		// Skip the version and checksum
+		segment[recordOffsets(segment)[record]+6] ^= 0xff
+		Expect(ioutil.WriteFile(segmentPath, segment, 0644)).To(Succeed())
+	}
+
+	appendBytes := func(data []byte) {
+		f, err := os.OpenFile(segmentPath, os.O_APPEND|os.O_WRONLY, 0644)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.Write(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+	}
+
+	It("reloads the written entries", func() {
+		var err error
+		w, err = simplewal.Open(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Verify()).To(Succeed())
+
+		seqNos, err := loadSeqNos(w)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4, 5}))
+	})
+
+	When("the final record is partially written", func() {
+		BeforeEach(func() {
+			// A size prefix of 100 bytes, with only 3 bytes following
+			appendBytes([]byte{100, 0, 1, 2})
+		})
+
+		It("discards the torn record, and accepts new writes", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			seqNos, err := loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4, 5}))
+
+			Expect(w.Write(6, entry(6))).To(Succeed())
+			Expect(w.Sync()).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			seqNos, err = loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4, 5, 6}))
+		})
+	})
+
+	When("the segment is extended with zeros", func() {
+		BeforeEach(func() {
+			appendBytes(make([]byte, 16))
+		})
+
+		It("discards the empty records", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			seqNos, err := loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4, 5}))
+			Expect(w.Verify()).To(Succeed())
+		})
+	})
+
+	When("the final record fails its checksum", func() {
+		BeforeEach(func() {
+			corruptByte(4)
+		})
+
+		It("discards the torn record", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			seqNos, err := loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4}))
+		})
+	})
+
+	When("a record before the final record fails its checksum", func() {
+		BeforeEach(func() {
+			corruptByte(2)
+		})
+
+		It("reports the corruption, and may be repaired", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = loadSeqNos(w)
+			Expect(err).To(MatchError("invalid record at index 3: record checksum mismatch: WAL corrupt"))
+			Expect(errors.Cause(err)).To(Equal(simplewal.ErrCorrupt))
+
+			err = w.Verify()
+			Expect(errors.Cause(err)).To(Equal(simplewal.ErrCorrupt))
+
+			Expect(w.Repair()).To(Succeed())
+			Expect(w.Verify()).To(Succeed())
+
+			seqNos, err := loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2}))
+		})
+	})
+
+	When("the first record fails its checksum", func() {
+		BeforeEach(func() {
+			corruptByte(0)
+		})
+
+		It("cannot be repaired", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = w.Repair()
+			Expect(err).To(MatchError("first record at index 1 is corrupt, cannot repair: WAL corrupt"))
+		})
+	})
+
+	When("the WAL contains records written without checksums", func() {
+		BeforeEach(func() {
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+
+			log, err := wal.Open(tmpDir, nil)
+			Expect(err).NotTo(HaveOccurred())
+			for i := uint64(1); i <= 3; i++ {
+				data, err := proto.Marshal(entry(i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(log.Write(i, data)).To(Succeed())
+			}
+			Expect(log.Close()).To(Succeed())
+		})
+
+		It("reads them, and appends checksummed records", func() {
+			var err error
+			w, err = simplewal.Open(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Write(4, entry(4))).To(Succeed())
+
+			seqNos, err := loadSeqNos(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seqNos).To(Equal([]uint64{1, 2, 3, 4}))
+			Expect(w.Verify()).To(Succeed())
+		})
+	})
+})
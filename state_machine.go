@@ -13,6 +13,8 @@ import (
 
 	pb "github.com/IBM/mirbft/mirbftpb"
 	"github.com/IBM/mirbft/status"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // bucketID is the identifier for a bucket.  It is a simple alias to a uint64, but
@@ -90,6 +92,7 @@ const (
 	smUninitialized stateMachineState = iota
 	smLoadingPersisted
 	smInitialized
+	smRemoved // The node was removed from the network configuration
 )
 
 // StateMachine contains a deterministic processor for mirbftpb state events.
@@ -98,6 +101,10 @@ const (
 type StateMachine struct {
 	Logger Logger
 
+	// LeaderPolicy selects the leaders of each new epoch, if nil,
+	// the SuspectLeaderPolicy is used.
+	LeaderPolicy LeaderPolicy
+
 	state stateMachineState
 
 	myConfig       *pb.StateEvent_InitialParameters
@@ -110,6 +117,11 @@ type StateMachine struct {
 	checkpointTracker *checkpointTracker
 	epochTracker      *epochTracker
 	persisted         *persisted
+
+	// member is whether this node is in the active network configuration.
+	// A node joining the network observes checkpoints until it is added,
+	// but does not otherwise participate.
+	member bool
 }
 
 func (sm *StateMachine) initialize(parameters *pb.StateEvent_InitialParameters) {
@@ -131,10 +143,14 @@ func (sm *StateMachine) initialize(parameters *pb.StateEvent_InitialParameters)
 		},
 	}
 
+	if sm.LeaderPolicy == nil {
+		sm.LeaderPolicy = SuspectLeaderPolicy{}
+	}
+
 	sm.nodeBuffers = newNodeBuffers(sm.myConfig, sm.Logger)
 	sm.checkpointTracker = newCheckpointTracker(0, dummyInitialState, sm.persisted, sm.nodeBuffers, sm.myConfig, sm.Logger)
 	sm.clientTracker = newClientWindows(sm.persisted, sm.nodeBuffers, sm.myConfig, sm.Logger)
-	sm.commitState = newCommitState(sm.persisted, sm.clientTracker, sm.Logger)
+	sm.commitState = newCommitState(sm.persisted, sm.clientTracker, sm.myConfig, sm.Logger)
 	sm.batchTracker = newBatchTracker(sm.persisted)
 	sm.epochTracker = newEpochTracker(
 		sm.persisted,
@@ -145,6 +161,7 @@ func (sm *StateMachine) initialize(parameters *pb.StateEvent_InitialParameters)
 		sm.myConfig,
 		sm.batchTracker,
 		sm.clientTracker,
+		sm.LeaderPolicy,
 	)
 
 }
@@ -155,7 +172,7 @@ func (sm *StateMachine) applyPersisted(entry *WALEntry) {
 }
 
 func (sm *StateMachine) applyOutstandingRequest(outstandingReq *pb.StateEvent_OutstandingRequest) {
-	sm.superHackyReqs.PushBack(outstandingReq.RequestAck)
+	sm.superHackyReqs.PushBack(outstandingReq)
 }
 
 func (sm *StateMachine) completeInitialization() *Actions {
@@ -171,6 +188,11 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 		assertEqualf(sm.state, smInitialized, "cannot apply events to an uninitialized state machine")
 	}
 
+	if sm.state == smRemoved {
+		// We are no longer part of the network, there is nothing left to do
+		return &Actions{}
+	}
+
 	actions := &Actions{}
 
 	switch event := stateEvent.Type.(type) {
@@ -191,7 +213,18 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 	case *pb.StateEvent_Tick:
 		assertInitialized()
 		actions.concat(sm.clientTracker.tick())
-		actions.concat(sm.epochTracker.tick())
+		if sm.member {
+			actions.concat(sm.epochTracker.tick())
+		}
+		if failed, ok := sm.commitState.transferRetryDue(); ok {
+			// Prefer any newer checkpoint the network has since
+			// attested to over the target which could not be obtained.
+			seqNo, value, ok := sm.checkpointTracker.highestCorrectCheckpoint()
+			if !ok || seqNo <= failed.SeqNo {
+				seqNo, value = failed.SeqNo, failed.Value
+			}
+			actions.concat(sm.commitState.retryTransfer(seqNo, value))
+		}
 	case *pb.StateEvent_Step:
 		assertInitialized()
 		actions.concat(sm.step(
@@ -211,9 +244,22 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 	case *pb.StateEvent_Transfer:
 		assertEqualf(sm.commitState.transferring, true, "state transfer event received but the state machine did not request transfer")
 
+		if event.Transfer.NetworkState == nil {
+			// The target could not be obtained, so we retry after a
+			// number of ticks.
+			sm.Logger.Log(LevelWarn, "state transfer failed", "seq_no", event.Transfer.SeqNo)
+			sm.commitState.transferFailed(event.Transfer.SeqNo, event.Transfer.CheckpointValue)
+			break
+		}
+
 		sm.Logger.Log(LevelDebug, "state transfer completed", "seq_no", event.Transfer.SeqNo)
 
 		actions.concat(sm.persisted.addCEntry(event.Transfer))
+		if event.Transfer.NetworkState != nil && !proto.Equal(event.Transfer.NetworkState.Config, sm.commitState.activeState.Config) {
+			// We transferred across a reconfiguration, so we end our
+			// network configuration, just as if we had reconfigured.
+			actions.concat(sm.epochTracker.reconfigure(event.Transfer.SeqNo))
+		}
 		actions.concat(sm.reinitialize())
 	case *pb.StateEvent_ActionsReceived:
 		// This is a bit odd, in that it's a no-op, but it's harmless
@@ -243,6 +289,44 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 			sm.batchTracker.truncate(newLow - uint64(sm.checkpointTracker.networkConfig.CheckpointInterval))
 		}
 		actions.concat(sm.epochTracker.moveLowWatermark(newLow))
+		actions.concat(&Actions{
+			StableCheckpoint: &newLow,
+		})
+	}
+
+	if !sm.member && !sm.commitState.transferring {
+		// Until we are added to the network configuration, we cannot commit,
+		// so we transfer to any newer checkpoint the network has attested to.
+		seqNo, value, ok := sm.checkpointTracker.highestCorrectCheckpoint()
+		if ok && seqNo > sm.commitState.lowWatermark {
+			sm.Logger.Log(LevelInfo, "observed newer checkpoint while not in the network configuration, transferring", "seq_no", seqNo)
+			actions.concat(sm.commitState.transferTo(seqNo, value))
+		}
+	}
+
+	if sm.member && !sm.commitState.transferring {
+		// If our checkpoint diverged from the network's (and we were configured
+		// to tolerate this rather than panic), we transfer to the network's.
+		seqNo, localValue, networkValue, ok := sm.checkpointTracker.divergentCheckpoint()
+		if ok {
+			sm.Logger.Log(LevelWarn, "checkpoint diverged from the network, transferring", "seq_no", seqNo, "local_value", localValue, "network_value", networkValue)
+			actions.misbehave(
+				nodeID(sm.myConfig.Id),
+				DivergentCheckpoint,
+				checkpointMsg(seqNo, localValue),
+				checkpointMsg(seqNo, networkValue),
+			)
+
+			// We may have committed beyond the divergent checkpoint, so we
+			// reinitialize just as if we had crashed during the transfer,
+			// which suspends our participation in the active epoch until
+			// the transfer completes.
+			actions.concat(sm.persisted.addTEntry(&pb.TEntry{
+				SeqNo: seqNo,
+				Value: networkValue,
+			}))
+			actions.concat(sm.reinitialize())
+		}
 	}
 
 	for {
@@ -255,7 +339,18 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 			Commits: sm.commitState.drain(),
 		})
 
+		if !sm.member {
+			break
+		}
+
 		loopActions := sm.epochTracker.advanceState()
+		if sm.epochTracker.reconfiguring {
+			// The reconfiguration is stable and the end of the old
+			// network configuration persisted, so we restart under
+			// the new network configuration.
+			loopActions.concat(sm.reinitialize())
+		}
+
 		if loopActions.isEmpty() {
 			break
 		}
@@ -263,6 +358,10 @@ func (sm *StateMachine) ApplyEvent(stateEvent *pb.StateEvent) *Actions {
 		actions.concat(loopActions)
 	}
 
+	for _, misbehavior := range actions.Misbehaviors {
+		sm.Logger.Log(LevelWarn, "observed misbehavior", "node", misbehavior.Node, "kind", misbehavior.Kind)
+	}
+
 	return actions
 }
 
@@ -277,13 +376,22 @@ func (sm *StateMachine) reinitialize() *Actions {
 	sm.clientTracker.reinitialize()
 
 	for el := sm.superHackyReqs.Front(); el != nil; el = sm.superHackyReqs.Front() {
-		sm.clientTracker.applyRequestDigest(
-			sm.superHackyReqs.Remove(el).(*pb.RequestAck),
-			nil, // XXX silly, but necessary for the moment
+		outstandingReq := sm.superHackyReqs.Remove(el).(*pb.StateEvent_OutstandingRequest)
+		sm.clientTracker.loadRequest(
+			outstandingReq.RequestAck,
+			int(outstandingReq.Size),
 		)
 	}
 
 	actions.concat(sm.commitState.reinitialize())
+
+	wasMember := sm.member
+	sm.member = isMember(sm.commitState.activeState.Config.Nodes, sm.myConfig.Id)
+	if wasMember && !sm.member {
+		sm.Logger.Log(LevelInfo, "removed from the network configuration, no longer participating")
+		sm.state = smRemoved
+	}
+
 	sm.checkpointTracker.reinitialize()
 	sm.batchTracker.reinitialize()
 	return actions.concat(sm.epochTracker.reinitialize())
@@ -334,6 +442,21 @@ func (sm *StateMachine) propose(requestData *pb.Request) *Actions {
 }
 
 func (sm *StateMachine) step(source nodeID, msg *pb.Msg) *Actions {
+	if !isMember(sm.commitState.activeState.Config.Nodes, uint64(source)) {
+		// Nodes outside our network configuration have no say
+		return &Actions{}
+	}
+
+	if !sm.member {
+		// Until we are added to the network configuration,
+		// we do not participate in epochs.
+		switch msg.Type.(type) {
+		case *pb.Msg_Checkpoint, *pb.Msg_RequestAck, *pb.Msg_FetchRequest, *pb.Msg_ForwardRequest:
+		default:
+			return &Actions{}
+		}
+	}
+
 	actions := &Actions{}
 	switch msg.Type.(type) {
 	case *pb.Msg_RequestAck:
@@ -408,8 +531,20 @@ func (sm *StateMachine) processResults(results *pb.StateEvent_ActionResults) *Ac
 		case *pb.HashResult_VerifyRequest_:
 			request := hashType.VerifyRequest
 			if !bytes.Equal(request.RequestAck.Digest, hashResult.Digest) {
-				panic("byzantine")
-				// XXX this should not panic, but put to make dev easier
+				// The forwarded request data does not match its ack
+				actions.misbehave(
+					nodeID(request.Source),
+					MalformedMsg,
+					&pb.Msg{
+						Type: &pb.Msg_ForwardRequest{
+							ForwardRequest: &pb.ForwardRequest{
+								RequestAck:  request.RequestAck,
+								RequestData: request.RequestData,
+							},
+						},
+					},
+				)
+				continue
 			}
 			actions.concat(sm.clientTracker.applyRequestDigest(
 				request.RequestAck,
@@ -420,7 +555,7 @@ func (sm *StateMachine) processResults(results *pb.StateEvent_ActionResults) *Ac
 			actions.concat(sm.epochTracker.applyEpochChangeDigest(epochChange, hashResult.Digest))
 		case *pb.HashResult_VerifyBatch_:
 			verifyBatch := hashType.VerifyBatch
-			sm.batchTracker.applyVerifyBatchHashResult(hashResult.Digest, verifyBatch)
+			actions.concat(sm.batchTracker.applyVerifyBatchHashResult(hashResult.Digest, verifyBatch))
 			if !sm.batchTracker.hasFetchInFlight() && sm.epochTracker.currentEpoch.state == etFetching {
 				actions.concat(sm.epochTracker.currentEpoch.fetchNewEpochState())
 			}
@@ -442,7 +577,7 @@ func (sm *StateMachine) clientWaiter(clientID uint64) *clientWaiter {
 }
 
 func (sm *StateMachine) Status() *status.StateMachine {
-	if sm.state != smInitialized {
+	if sm.state != smInitialized && sm.state != smRemoved {
 		return &status.StateMachine{}
 	}
 
@@ -452,12 +587,7 @@ func (sm *StateMachine) Status() *status.StateMachine {
 		clientTrackerStatus[i] = sm.clientTracker.clients[clientState.Id].status()
 	}
 
-	nodes := make([]*status.NodeBuffer, len(sm.checkpointTracker.networkConfig.Nodes))
-	for i, id := range sm.checkpointTracker.networkConfig.Nodes {
-		nodes[i] = &status.NodeBuffer{
-			ID: id,
-		} // TODO, actually populate this again
-	}
+	nodes := sm.nodeBuffers.status(sm.checkpointTracker.networkConfig.Nodes)
 
 	lowWatermark, highWatermark, bucketStatus := sm.epochTracker.currentEpoch.bucketStatus()
 
@@ -100,6 +100,27 @@ func someCorrectQuorum(nc *pb.NetworkState_Config) int {
 	return int(nc.F) + 1
 }
 
+// isMember returns whether the node id is among the given nodes.
+func isMember(nodes []uint64, id uint64) bool {
+	for _, nodeID := range nodes {
+		if nodeID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// unionOfNodes returns nodes, followed by any of otherNodes not among them.
+func unionOfNodes(nodes, otherNodes []uint64) []uint64 {
+	result := append([]uint64{}, nodes...)
+	for _, otherID := range otherNodes {
+		if !isMember(result, otherID) {
+			result = append(result, otherID)
+		}
+	}
+	return result
+}
+
 func clientReqToBucket(clientID, reqNo uint64, nc *pb.NetworkState_Config) bucketID {
 	return bucketID((clientID + reqNo) % uint64(nc.NumberOfBuckets))
 }
@@ -0,0 +1,544 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package statetransfer is an optional protocol by which a node which has fallen
+// behind the network may fetch the application state of a checkpoint from its peers.
+// When the node requests a state transfer, the application snapshot for the target
+// checkpoint is fetched in chunks from one peer at a time, and verified against the
+// checkpoint value which the network agreed upon, before being restored.  Because
+// the snapshot is verified, it is safe to fetch it from any peer, correct or not.
+package statetransfer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultChunkSize        = 1024 * 1024
+	DefaultRequestTimeout   = 5 * time.Second
+	DefaultRetryInterval    = 100 * time.Millisecond
+	DefaultMaxRetryInterval = 10 * time.Second
+	DefaultMaxSnapshotSize  = 1024 * 1024 * 1024
+)
+
+// ErrUnavailable is returned by an Application when a snapshot is not available,
+// and by Transfer when the target snapshot has been garbage collected by the peers.
+var ErrUnavailable = errors.New("snapshot unavailable")
+
+// Application is implemented by the consumer to take, verify, and restore
+// snapshots of its state.
+type Application interface {
+	// Snapshot returns the network state and application snapshot for the checkpoint
+	// with the given sequence number and value, as previously returned by Log.Snap.
+	// If the snapshot is not available, for instance because it has been garbage
+	// collected, ErrUnavailable should be returned.
+	Snapshot(seqNo uint64, value []byte) (*pb.NetworkState, []byte, error)
+
+	// CheckpointValue computes the checkpoint value for a snapshot received from
+	// a peer.  It must return the value which Log.Snap would have returned for the
+	// same state, as this is compared to the value agreed upon by the network.  As
+	// the network state is supplied by the peer, the value must commit to all of it,
+	// including any pending reconfigurations.
+	CheckpointValue(seqNo uint64, networkState *pb.NetworkState, data []byte) ([]byte, error)
+
+	// Restore replaces the application state with the given, verified, snapshot.
+	Restore(seqNo uint64, networkState *pb.NetworkState, data []byte) error
+}
+
+// Stepper is the subset of the *mirbft.Node API used by the Router.
+type Stepper interface {
+	Step(ctx context.Context, source uint64, msg *pb.Msg) error
+}
+
+type Config struct {
+	// ID is the NodeID of this node.
+	ID uint64
+
+	// Peers is the set of NodeIDs in the network configuration, from which
+	// snapshots are fetched in order of preference.  This node's ID is not
+	// fetched from if present.  The number of peers determines how many must
+	// report a snapshot unavailable before a transfer fails.
+	Peers []uint64
+
+	// Link is used to send snapshot requests and responses to peers.
+	Link mirbft.Link
+
+	// Application takes, verifies, and restores snapshots.
+	Application Application
+
+	// ChunkSize is the maximum number of snapshot bytes sent in a single
+	// message.  Defaults to DefaultChunkSize.
+	ChunkSize int
+
+	// RequestTimeout is how long to wait for a peer to respond to a request
+	// for a chunk before falling back to another peer.  Defaults to
+	// DefaultRequestTimeout.
+	RequestTimeout time.Duration
+
+	// RetryInterval is how long to wait before retrying the peers once
+	// none of them has supplied the snapshot.  The interval doubles with
+	// each round, up to MaxRetryInterval.  Defaults to DefaultRetryInterval.
+	RetryInterval time.Duration
+
+	// MaxRetryInterval bounds the interval between rounds of retries.
+	// Defaults to DefaultMaxRetryInterval.
+	MaxRetryInterval time.Duration
+
+	// MaxSnapshotSize is the largest snapshot, in bytes, this node will fetch.
+	// The size of a snapshot is supplied by the peer serving it, so this bounds
+	// the memory a faulty peer may cause to be allocated.  Defaults to
+	// DefaultMaxSnapshotSize.
+	MaxSnapshotSize uint64
+
+	// Logger is used to report peers which fail to supply the snapshot.
+	Logger mirbft.Logger
+}
+
+// StateTransfer both serves snapshots to peers, and fetches snapshots from peers
+// when this node requests a state transfer.  Its Transfer method is suitable for
+// use as the StateTransfer function of mirbft.RunOpts.  Inbound state transfer
+// messages must be delivered to Step, see Router.
+type StateTransfer struct {
+	config Config
+
+	mutex    sync.Mutex
+	peers    []uint64
+	transfer *transferCall
+	pending  *pendingFetch
+	cached   *cachedSnapshot
+}
+
+// transferCall is the single transfer this node is currently performing,
+// which concurrent calls to Transfer for the same target join.
+type transferCall struct {
+	target       *mirbft.StateTarget
+	doneC        chan struct{}
+	networkState *pb.NetworkState
+	err          error
+}
+
+// pendingFetch identifies the single chunk this node is currently awaiting.
+type pendingFetch struct {
+	source uint64
+	seqNo  uint64
+	value  []byte
+	offset uint64
+	chunkC chan *pb.SnapshotChunk
+}
+
+// cachedSnapshot retains the most recently served snapshot, so that the
+// application is not asked for it once per chunk.
+type cachedSnapshot struct {
+	seqNo        uint64
+	value        []byte
+	networkState *pb.NetworkState
+	data         []byte
+}
+
+func New(config Config) *StateTransfer {
+	if config.ChunkSize == 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+
+	if config.RetryInterval == 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+
+	if config.MaxRetryInterval == 0 {
+		config.MaxRetryInterval = DefaultMaxRetryInterval
+	}
+
+	if config.MaxSnapshotSize == 0 {
+		config.MaxSnapshotSize = DefaultMaxSnapshotSize
+	}
+
+	if config.Logger == nil {
+		config.Logger = mirbft.ConsoleWarnLogger
+	}
+
+	return &StateTransfer{
+		config: config,
+		peers:  append([]uint64{}, config.Peers...),
+	}
+}
+
+// SetPeers replaces the set of peers from which snapshots are fetched, for
+// instance after a reconfiguration.
+func (st *StateTransfer) SetPeers(peers []uint64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.peers = append([]uint64{}, peers...)
+}
+
+// IsStateTransferMsg returns whether the message should be delivered to Step
+// rather than to the node.
+func IsStateTransferMsg(msg *pb.Msg) bool {
+	switch msg.Type.(type) {
+	case *pb.Msg_SnapshotRequest, *pb.Msg_SnapshotChunk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Step handles an inbound state transfer message.  Requests are answered with
+// a chunk of the requested snapshot, while chunks are delivered to any transfer
+// in progress.  An error is returned only if the message is malformed.
+func (st *StateTransfer) Step(ctx context.Context, source uint64, msg *pb.Msg) error {
+	switch innerMsg := msg.Type.(type) {
+	case *pb.Msg_SnapshotRequest:
+		if innerMsg.SnapshotRequest == nil {
+			return errors.Errorf("message of type SnapshotRequest, but snapshot_request field is nil")
+		}
+		return st.serve(source, innerMsg.SnapshotRequest)
+	case *pb.Msg_SnapshotChunk:
+		if innerMsg.SnapshotChunk == nil {
+			return errors.Errorf("message of type SnapshotChunk, but snapshot_chunk field is nil")
+		}
+		st.deliver(source, innerMsg.SnapshotChunk)
+		return nil
+	default:
+		return errors.Errorf("unexpected message of type '%T' for state transfer", msg.Type)
+	}
+}
+
+func (st *StateTransfer) snapshot(seqNo uint64, value []byte) (*pb.NetworkState, []byte, error) {
+	st.mutex.Lock()
+	cached := st.cached
+	st.mutex.Unlock()
+
+	if cached != nil && cached.seqNo == seqNo && bytes.Equal(cached.value, value) {
+		return cached.networkState, cached.data, nil
+	}
+
+	networkState, data, err := st.config.Application.Snapshot(seqNo, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st.mutex.Lock()
+	st.cached = &cachedSnapshot{
+		seqNo:        seqNo,
+		value:        value,
+		networkState: networkState,
+		data:         data,
+	}
+	st.mutex.Unlock()
+
+	return networkState, data, nil
+}
+
+func (st *StateTransfer) serve(source uint64, req *pb.SnapshotRequest) error {
+	chunk := &pb.SnapshotChunk{
+		SeqNo:  req.SeqNo,
+		Value:  req.Value,
+		Offset: req.Offset,
+	}
+
+	networkState, data, err := st.snapshot(req.SeqNo, req.Value)
+	switch {
+	case err != nil:
+		if errors.Cause(err) != ErrUnavailable {
+			st.config.Logger.Log(mirbft.LevelWarn, "could not read snapshot for peer", "source", source, "seq_no", req.SeqNo, "error", err)
+		}
+		chunk.Unavailable = true
+	case req.Offset > uint64(len(data)):
+		return errors.Errorf("snapshot request offset %d exceeds snapshot size %d", req.Offset, len(data))
+	default:
+		end := req.Offset + uint64(st.config.ChunkSize)
+		if end > uint64(len(data)) {
+			end = uint64(len(data))
+		}
+
+		chunk.TotalSize = uint64(len(data))
+		chunk.Data = data[req.Offset:end]
+		if req.Offset == 0 {
+			chunk.NetworkState = networkState
+		}
+	}
+
+	return st.config.Link.Send(source, &pb.Msg{
+		Type: &pb.Msg_SnapshotChunk{
+			SnapshotChunk: chunk,
+		},
+	})
+}
+
+func (st *StateTransfer) deliver(source uint64, chunk *pb.SnapshotChunk) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	p := st.pending
+	if p == nil ||
+		p.source != source ||
+		p.seqNo != chunk.SeqNo ||
+		p.offset != chunk.Offset ||
+		!bytes.Equal(p.value, chunk.Value) {
+		// A late or unsolicited chunk, ignore it
+		return
+	}
+
+	select {
+	case p.chunkC <- chunk:
+	default:
+		// A duplicate chunk is already waiting
+	}
+}
+
+// Transfer fetches the snapshot for the target from the peers, verifies it
+// against the target value, restores it via the application, and returns the
+// network state of the target checkpoint.  Peers are tried in turn until one
+// supplies the snapshot, with a growing interval between rounds.  A correct peer
+// garbage collects a checkpoint only once a later checkpoint is stable, so once
+// f+1 peers report that the snapshot is unavailable, at least one of them is
+// correct, and ErrUnavailable is returned.  The node should then be informed via
+// StateTransferFailed, and a later target selected.  Otherwise, the peers are
+// retried until the context is cancelled.  Only one transfer is performed at a
+// time.  A call for the target of the transfer in progress joins it, while a call
+// for another target waits for the transfer in progress to finish.
+func (st *StateTransfer) Transfer(ctx context.Context, target *mirbft.StateTarget) (*pb.NetworkState, error) {
+	for {
+		st.mutex.Lock()
+		call := st.transfer
+		if call == nil {
+			call = &transferCall{
+				target: target,
+				doneC:  make(chan struct{}),
+			}
+			st.transfer = call
+			st.mutex.Unlock()
+
+			call.networkState, call.err = st.transferFromPeers(ctx, target)
+
+			st.mutex.Lock()
+			st.transfer = nil
+			st.mutex.Unlock()
+			close(call.doneC)
+
+			return call.networkState, call.err
+		}
+		st.mutex.Unlock()
+
+		select {
+		case <-call.doneC:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if call.target.SeqNo != target.SeqNo || !bytes.Equal(call.target.Value, target.Value) {
+			continue
+		}
+
+		if call.err == context.Canceled || call.err == context.DeadlineExceeded {
+			// The caller we joined gave up, but we have not
+			continue
+		}
+
+		return call.networkState, call.err
+	}
+}
+
+func (st *StateTransfer) transferFromPeers(ctx context.Context, target *mirbft.StateTarget) (*pb.NetworkState, error) {
+	unavailable := map[uint64]struct{}{}
+	retryInterval := st.config.RetryInterval
+
+	for {
+		st.mutex.Lock()
+		peers := st.peers
+		st.mutex.Unlock()
+
+		someCorrectQuorum := (len(peers)-1)/3 + 1
+
+		attempted := 0
+		for _, peer := range peers {
+			if peer == st.config.ID {
+				continue
+			}
+			attempted++
+
+			networkState, err := st.fetchFrom(ctx, peer, target)
+			if err == nil {
+				return networkState, nil
+			}
+
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			st.config.Logger.Log(mirbft.LevelWarn, "could not fetch snapshot from peer", "peer", peer, "seq_no", target.SeqNo, "error", err)
+
+			if errors.Cause(err) != ErrUnavailable {
+				continue
+			}
+
+			unavailable[peer] = struct{}{}
+			if len(unavailable) >= someCorrectQuorum {
+				return nil, errors.WithMessagef(ErrUnavailable, "snapshot for seq_no=%d unavailable from %d of %d peers", target.SeqNo, len(unavailable), len(peers))
+			}
+		}
+
+		if attempted == 0 {
+			return nil, errors.Errorf("no peers from which to fetch snapshot")
+		}
+
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		retryInterval *= 2
+		if retryInterval > st.config.MaxRetryInterval {
+			retryInterval = st.config.MaxRetryInterval
+		}
+	}
+}
+
+// fetchFrom fetches, verifies, and restores the snapshot from a single peer.
+func (st *StateTransfer) fetchFrom(ctx context.Context, peer uint64, target *mirbft.StateTarget) (*pb.NetworkState, error) {
+	networkState, data, err := st.fetch(ctx, peer, target)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := st.config.Application.CheckpointValue(target.SeqNo, networkState, data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not compute checkpoint value of snapshot")
+	}
+
+	if !bytes.Equal(value, target.Value) {
+		return nil, errors.Errorf("snapshot checkpoint value %x does not match target value %x", value, target.Value)
+	}
+
+	if err := st.config.Application.Restore(target.SeqNo, networkState, data); err != nil {
+		return nil, errors.WithMessage(err, "could not restore snapshot")
+	}
+
+	return networkState, nil
+}
+
+// fetch requests the chunks of the snapshot from the peer one at a time
+// until the snapshot is complete.
+func (st *StateTransfer) fetch(ctx context.Context, peer uint64, target *mirbft.StateTarget) (*pb.NetworkState, []byte, error) {
+	p := &pendingFetch{
+		source: peer,
+		seqNo:  target.SeqNo,
+		value:  target.Value,
+		chunkC: make(chan *pb.SnapshotChunk, 1),
+	}
+
+	st.mutex.Lock()
+	st.pending = p
+	st.mutex.Unlock()
+
+	defer func() {
+		st.mutex.Lock()
+		st.pending = nil
+		st.mutex.Unlock()
+	}()
+
+	var networkState *pb.NetworkState
+	var data []byte
+	var totalSize uint64
+
+	for {
+		offset := uint64(len(data))
+
+		st.mutex.Lock()
+		p.offset = offset
+		st.mutex.Unlock()
+
+		err := st.config.Link.Send(peer, &pb.Msg{
+			Type: &pb.Msg_SnapshotRequest{
+				SnapshotRequest: &pb.SnapshotRequest{
+					SeqNo:  target.SeqNo,
+					Value:  target.Value,
+					Offset: offset,
+				},
+			},
+		})
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "could not send snapshot request")
+		}
+
+		timer := time.NewTimer(st.config.RequestTimeout)
+		var chunk *pb.SnapshotChunk
+		select {
+		case chunk = <-p.chunkC:
+			timer.Stop()
+		case <-timer.C:
+			return nil, nil, errors.Errorf("timed out waiting for snapshot chunk at offset %d", offset)
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		}
+
+		if chunk.Unavailable {
+			return nil, nil, ErrUnavailable
+		}
+
+		if offset == 0 {
+			if chunk.NetworkState == nil {
+				return nil, nil, errors.Errorf("first snapshot chunk did not include network state")
+			}
+			if chunk.TotalSize > st.config.MaxSnapshotSize {
+				return nil, nil, errors.Errorf("snapshot size %d exceeds the maximum snapshot size %d", chunk.TotalSize, st.config.MaxSnapshotSize)
+			}
+			networkState = chunk.NetworkState
+			totalSize = chunk.TotalSize
+		} else if chunk.TotalSize != totalSize {
+			return nil, nil, errors.Errorf("snapshot size changed from %d to %d", totalSize, chunk.TotalSize)
+		}
+
+		if offset+uint64(len(chunk.Data)) > st.config.MaxSnapshotSize {
+			return nil, nil, errors.Errorf("snapshot chunk at offset %d of length %d exceeds the maximum snapshot size %d", offset, len(chunk.Data), st.config.MaxSnapshotSize)
+		}
+
+		if offset+uint64(len(chunk.Data)) > totalSize {
+			return nil, nil, errors.Errorf("snapshot chunk at offset %d of length %d exceeds snapshot size %d", offset, len(chunk.Data), totalSize)
+		}
+
+		if len(chunk.Data) == 0 && offset < totalSize {
+			return nil, nil, errors.Errorf("empty snapshot chunk at offset %d of %d", offset, totalSize)
+		}
+
+		data = append(data, chunk.Data...)
+
+		if uint64(len(data)) == totalSize {
+			return networkState, data, nil
+		}
+	}
+}
+
+// Router is a Stepper which delivers state transfer messages to the
+// StateTransfer, and all other messages to the node.  It may be supplied to
+// the transport in place of the node.
+type Router struct {
+	Node          Stepper
+	StateTransfer *StateTransfer
+}
+
+func (r *Router) Step(ctx context.Context, source uint64, msg *pb.Msg) error {
+	if IsStateTransferMsg(msg) {
+		return r.StateTransfer.Step(ctx, source, msg)
+	}
+
+	return r.Node.Step(ctx, source, msg)
+}
@@ -0,0 +1,13 @@
+package statetransfer_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestStateTransfer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StateTransfer Suite")
+}
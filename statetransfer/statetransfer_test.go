@@ -0,0 +1,471 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statetransfer_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/statetransfer"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type snapshot struct {
+	networkState *pb.NetworkState
+	data         []byte
+}
+
+type fakeApplication struct {
+	mutex     sync.Mutex
+	snapshots map[uint64]*snapshot
+	restored  *snapshot
+	restores  int
+	restoreC  chan struct{}
+}
+
+func checkpointValue(seqNo uint64, networkState *pb.NetworkState, data []byte) []byte {
+	nsBytes, err := proto.Marshal(networkState)
+	Expect(err).NotTo(HaveOccurred())
+
+	h := sha256.New()
+	h.Write([]byte(fmt.Sprintf("%d", seqNo)))
+	h.Write(nsBytes)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (fa *fakeApplication) Snapshot(seqNo uint64, value []byte) (*pb.NetworkState, []byte, error) {
+	fa.mutex.Lock()
+	defer fa.mutex.Unlock()
+
+	s, ok := fa.snapshots[seqNo]
+	if !ok || !bytes.Equal(checkpointValue(seqNo, s.networkState, s.data), value) {
+		return nil, nil, statetransfer.ErrUnavailable
+	}
+
+	return s.networkState, s.data, nil
+}
+
+func (fa *fakeApplication) CheckpointValue(seqNo uint64, networkState *pb.NetworkState, data []byte) ([]byte, error) {
+	return checkpointValue(seqNo, networkState, data), nil
+}
+
+func (fa *fakeApplication) Restore(seqNo uint64, networkState *pb.NetworkState, data []byte) error {
+	if fa.restoreC != nil {
+		<-fa.restoreC
+	}
+
+	fa.mutex.Lock()
+	defer fa.mutex.Unlock()
+
+	fa.restores++
+	fa.restored = &snapshot{
+		networkState: networkState,
+		data:         data,
+	}
+	return nil
+}
+
+func (fa *fakeApplication) Restored() *snapshot {
+	fa.mutex.Lock()
+	defer fa.mutex.Unlock()
+	return fa.restored
+}
+
+func (fa *fakeApplication) Restores() int {
+	fa.mutex.Lock()
+	defer fa.mutex.Unlock()
+	return fa.restores
+}
+
+// fakeNetwork delivers messages between the state transfer instances
+// synchronously, dropping any message to a silenced node.
+type fakeNetwork struct {
+	mutex    sync.Mutex
+	nodes    map[uint64]*statetransfer.StateTransfer
+	silenced map[uint64]struct{}
+	requests map[uint64]int
+}
+
+func (fn *fakeNetwork) Requests(dest uint64) int {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	return fn.requests[dest]
+}
+
+func (fn *fakeNetwork) link(source uint64) mirbft.Link {
+	return &fakeLink{network: fn, source: source}
+}
+
+type fakeLink struct {
+	network *fakeNetwork
+	source  uint64
+}
+
+func (fl *fakeLink) Send(dest uint64, msg *pb.Msg) error {
+	fn := fl.network
+	fn.mutex.Lock()
+	node := fn.nodes[dest]
+	_, silenced := fn.silenced[dest]
+	if _, ok := msg.Type.(*pb.Msg_SnapshotRequest); ok {
+		fn.requests[dest]++
+	}
+	fn.mutex.Unlock()
+
+	if silenced {
+		return nil
+	}
+
+	return node.Step(context.Background(), fl.source, msg)
+}
+
+var _ = Describe("StateTransfer", func() {
+	var (
+		network      *fakeNetwork
+		apps         map[uint64]*fakeApplication
+		networkState *pb.NetworkState
+		target       *mirbft.StateTarget
+		data         []byte
+	)
+
+	BeforeEach(func() {
+		networkState = mirbft.StandardInitialNetworkState(4, 0)
+		data = bytes.Repeat([]byte("application-state"), 100)
+		target = &mirbft.StateTarget{
+			SeqNo: 20,
+			Value: checkpointValue(20, networkState, data),
+		}
+
+		network = &fakeNetwork{
+			nodes:    map[uint64]*statetransfer.StateTransfer{},
+			silenced: map[uint64]struct{}{},
+			requests: map[uint64]int{},
+		}
+
+		apps = map[uint64]*fakeApplication{}
+
+		for i := uint64(0); i < 4; i++ {
+			apps[i] = &fakeApplication{
+				snapshots: map[uint64]*snapshot{},
+			}
+
+			if i != 0 {
+				apps[i].snapshots[20] = &snapshot{
+					networkState: networkState,
+					data:         data,
+				}
+			}
+
+			network.nodes[i] = statetransfer.New(statetransfer.Config{
+				ID:             i,
+				Peers:          []uint64{0, 1, 2, 3},
+				Link:           network.link(i),
+				Application:    apps[i],
+				ChunkSize:      100,
+				RequestTimeout: 50 * time.Millisecond,
+				RetryInterval:  100 * time.Millisecond,
+				Logger:         mirbft.ConsoleErrorLogger,
+			})
+		}
+	})
+
+	It("fetches, verifies, and restores the snapshot in chunks", func() {
+		ns, err := network.nodes[0].Transfer(context.Background(), target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(ns, networkState)).To(BeTrue())
+
+		restored := apps[0].Restored()
+		Expect(restored).NotTo(BeNil())
+		Expect(restored.data).To(Equal(data))
+		Expect(network.requests[1]).To(Equal(17)) // 1700 bytes in chunks of 100
+		Expect(network.requests[2]).To(Equal(0))
+	})
+
+	When("a peer has garbage collected the snapshot", func() {
+		BeforeEach(func() {
+			delete(apps[1].snapshots, 20)
+		})
+
+		It("falls back to another peer", func() {
+			_, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.requests[1]).To(Equal(1))
+			Expect(network.requests[2]).NotTo(Equal(0))
+			Expect(apps[0].Restored().data).To(Equal(data))
+		})
+	})
+
+	When("a peer does not respond", func() {
+		BeforeEach(func() {
+			network.silenced[1] = struct{}{}
+		})
+
+		It("falls back to another peer", func() {
+			_, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps[0].Restored().data).To(Equal(data))
+		})
+	})
+
+	When("a peer serves a snapshot which does not match the target", func() {
+		BeforeEach(func() {
+			corrupt := append([]byte{}, data...)
+			corrupt[150] = 'X'
+			apps[1].snapshots[20] = &snapshot{
+				networkState: networkState,
+				data:         corrupt,
+			}
+			// The corrupt peer claims the snapshot is for the target
+			network.nodes[1] = statetransfer.New(statetransfer.Config{
+				ID:          1,
+				Link:        network.link(1),
+				Application: &lyingApplication{fakeApplication: apps[1], value: target.Value},
+				ChunkSize:   100,
+			})
+		})
+
+		It("rejects it, and falls back to another peer", func() {
+			_, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.requests[1]).NotTo(Equal(0))
+			Expect(network.requests[2]).NotTo(Equal(0))
+			Expect(apps[0].Restored().data).To(Equal(data))
+		})
+	})
+
+	When("a peer serves a network state with pending reconfigurations which do not match the target", func() {
+		BeforeEach(func() {
+			forged := proto.Clone(networkState).(*pb.NetworkState)
+			forged.PendingReconfigurations = []*pb.Reconfiguration{
+				{
+					Type: &pb.Reconfiguration_RemoveClient{
+						RemoveClient: 0,
+					},
+				},
+			}
+			apps[1].snapshots[20] = &snapshot{
+				networkState: forged,
+				data:         data,
+			}
+			network.nodes[1] = statetransfer.New(statetransfer.Config{
+				ID:          1,
+				Link:        network.link(1),
+				Application: &lyingApplication{fakeApplication: apps[1], value: target.Value},
+				ChunkSize:   100,
+			})
+		})
+
+		It("rejects it, and falls back to another peer", func() {
+			ns, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(ns, networkState)).To(BeTrue())
+			Expect(network.requests[1]).NotTo(Equal(0))
+			Expect(apps[0].Restored().networkState.PendingReconfigurations).To(BeEmpty())
+		})
+	})
+
+	When("a peer serves a snapshot larger than the maximum snapshot size", func() {
+		BeforeEach(func() {
+			apps[1].snapshots[20] = &snapshot{
+				networkState: networkState,
+				data:         bytes.Repeat(data, 100),
+			}
+			network.nodes[1] = statetransfer.New(statetransfer.Config{
+				ID:          1,
+				Link:        network.link(1),
+				Application: &lyingApplication{fakeApplication: apps[1], value: target.Value},
+				ChunkSize:   100,
+			})
+			network.nodes[0] = statetransfer.New(statetransfer.Config{
+				ID:              0,
+				Peers:           []uint64{0, 1, 2, 3},
+				Link:            network.link(0),
+				Application:     apps[0],
+				ChunkSize:       100,
+				RequestTimeout:  50 * time.Millisecond,
+				MaxSnapshotSize: uint64(len(data)),
+				Logger:          mirbft.ConsoleErrorLogger,
+			})
+		})
+
+		It("rejects it at the first chunk, and falls back to another peer", func() {
+			_, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.requests[1]).To(Equal(1))
+			Expect(network.requests[2]).NotTo(Equal(0))
+			Expect(apps[0].Restored().data).To(Equal(data))
+		})
+	})
+
+	When("fewer than f+1 peers report the snapshot unavailable", func() {
+		BeforeEach(func() {
+			delete(apps[1].snapshots, 20)
+			network.silenced[2] = struct{}{}
+			network.silenced[3] = struct{}{}
+		})
+
+		It("does not give up, but retries until the context is cancelled", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			_, err := network.nodes[0].Transfer(ctx, target)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+		})
+	})
+
+	When("every peer fails quickly", func() {
+		BeforeEach(func() {
+			delete(apps[1].snapshots, 20)
+			for i := uint64(2); i < 4; i++ {
+				corrupt := append([]byte{}, data...)
+				corrupt[0] = 'X'
+				apps[i].snapshots[20] = &snapshot{
+					networkState: networkState,
+					data:         corrupt,
+				}
+				network.nodes[i] = statetransfer.New(statetransfer.Config{
+					ID:          i,
+					Link:        network.link(i),
+					Application: &lyingApplication{fakeApplication: apps[i], value: target.Value},
+					ChunkSize:   100,
+				})
+			}
+		})
+
+		It("backs off between rounds", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+			defer cancel()
+
+			_, err := network.nodes[0].Transfer(ctx, target)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+
+			// Rounds begin after 0, 100, and 300ms
+			Expect(network.Requests(1)).To(BeNumerically(">=", 2))
+			Expect(network.Requests(1)).To(BeNumerically("<=", 4))
+		})
+	})
+
+	When("a transfer to the same target is already in progress", func() {
+		BeforeEach(func() {
+			apps[0].restoreC = make(chan struct{})
+		})
+
+		It("joins it, rather than fetching the snapshot again", func() {
+			type result struct {
+				networkState *pb.NetworkState
+				err          error
+			}
+			resultC := make(chan result, 2)
+			transfer := func() {
+				ns, err := network.nodes[0].Transfer(context.Background(), target)
+				resultC <- result{networkState: ns, err: err}
+			}
+
+			go transfer()
+			Eventually(func() int { return network.Requests(1) }).Should(Equal(17))
+
+			go transfer()
+			Consistently(resultC, 100*time.Millisecond).ShouldNot(Receive())
+
+			close(apps[0].restoreC)
+			for i := 0; i < 2; i++ {
+				var r result
+				Eventually(resultC).Should(Receive(&r))
+				Expect(r.err).NotTo(HaveOccurred())
+				Expect(proto.Equal(r.networkState, networkState)).To(BeTrue())
+			}
+
+			Expect(apps[0].Restores()).To(Equal(1))
+			Expect(network.Requests(1)).To(Equal(17))
+		})
+	})
+
+	When("all peers have garbage collected the snapshot", func() {
+		BeforeEach(func() {
+			for i := uint64(1); i < 4; i++ {
+				delete(apps[i].snapshots, 20)
+			}
+		})
+
+		It("returns ErrUnavailable", func() {
+			_, err := network.nodes[0].Transfer(context.Background(), target)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(statetransfer.ErrUnavailable.Error()))
+			Expect(apps[0].Restored()).To(BeNil())
+		})
+	})
+
+	When("no peer responds", func() {
+		BeforeEach(func() {
+			for i := uint64(1); i < 4; i++ {
+				network.silenced[i] = struct{}{}
+			}
+		})
+
+		It("retries until the context is cancelled", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			_, err := network.nodes[0].Transfer(ctx, target)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+			Expect(network.requests[1]).To(BeNumerically(">", 1))
+		})
+	})
+
+	It("routes state transfer messages away from the node", func() {
+		router := &statetransfer.Router{
+			Node:          &failingStepper{},
+			StateTransfer: network.nodes[1],
+		}
+
+		err := router.Step(context.Background(), 0, &pb.Msg{
+			Type: &pb.Msg_SnapshotRequest{
+				SnapshotRequest: &pb.SnapshotRequest{
+					SeqNo: target.SeqNo,
+					Value: target.Value,
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = router.Step(context.Background(), 0, &pb.Msg{
+			Type: &pb.Msg_Checkpoint{
+				Checkpoint: &pb.Checkpoint{},
+			},
+		})
+		Expect(err).To(MatchError("stepped into node"))
+	})
+})
+
+type lyingApplication struct {
+	*fakeApplication
+	value []byte
+}
+
+func (la *lyingApplication) Snapshot(seqNo uint64, value []byte) (*pb.NetworkState, []byte, error) {
+	if !bytes.Equal(value, la.value) {
+		return nil, nil, statetransfer.ErrUnavailable
+	}
+	s := la.snapshots[seqNo]
+	return s.networkState, s.data, nil
+}
+
+type failingStepper struct{}
+
+func (failingStepper) Step(ctx context.Context, source uint64, msg *pb.Msg) error {
+	return fmt.Errorf("stepped into node")
+}
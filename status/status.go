@@ -16,7 +16,7 @@ type EpochTargetState int
 
 const (
 	// EpochPrepending indicates we have sent an epoch-change, but waiting for a quorum
-	EpochPrepending = iota
+	EpochPrepending EpochTargetState = iota
 
 	// EpochPending indicates that we have a quorum of epoch-change messages, waits on new-epoch
 	EpochPending
@@ -33,16 +33,43 @@ const (
 	// EpochReadying indicates we have received a quorum of echos, waiting a on qourum of readies
 	EpochReadying
 
+	// EpochResuming indicates we crashed during this epoch, and are waiting to resume it
+	EpochResuming
+
 	// EpochReady indicates the new epoch is ready to begin
 	EpochReady
 
 	// EpochInProgress indicates the epoch is currently active
 	EpochInProgress
 
+	// EpochEnding indicates the epoch has committed everything it can, and we have a stable checkpoint
+	EpochEnding
+
 	// EpochDone indicates this epoch has ended, either gracefully or because we sent an epoch change
 	EpochDone
 )
 
+var epochTargetStateNames = []string{
+	EpochPrepending: "prepending",
+	EpochPending:    "pending",
+	EpochVerifying:  "verifying",
+	EpochFetching:   "fetching",
+	EpochEchoing:    "echoing",
+	EpochReadying:   "readying",
+	EpochResuming:   "resuming",
+	EpochReady:      "ready",
+	EpochInProgress: "in_progress",
+	EpochEnding:     "ending",
+	EpochDone:       "done",
+}
+
+func (ets EpochTargetState) String() string {
+	if ets < 0 || int(ets) >= len(epochTargetStateNames) {
+		return fmt.Sprintf("unknown(%d)", int(ets))
+	}
+	return epochTargetStateNames[ets]
+}
+
 type SequenceState int
 
 const (
@@ -92,12 +119,15 @@ type Checkpoint struct {
 	MaxAgreements int    `json:"max_agreements"`
 	NetQuorum     bool   `json:"net_quorum"`
 	LocalDecision bool   `json:"local_decision"`
+	Stable        bool   `json:"stable"`
 }
 
 type EpochTracker struct {
-	State           EpochTargetState `json:"state"` // TODO, move into epoch target
-	LastActiveEpoch uint64           `json:"last_active_epoch"`
-	EpochTargets    []*EpochTarget   `json:"epoch_targets"`
+	State            EpochTargetState `json:"state"` // TODO, move into epoch target
+	LastActiveEpoch  uint64           `json:"last_active_epoch"`
+	EpochTargets     []*EpochTarget   `json:"epoch_targets"`
+	EpochChangesSent uint64           `json:"epoch_changes_sent"`
+	Leaders          []uint64         `json:"leaders"`
 }
 
 type EpochTarget struct {
@@ -122,6 +152,8 @@ type NodeBuffer struct {
 	ID             uint64       `json:"id"`
 	Buckets        []NodeBucket `json:"buckets"`
 	LastCheckpoint uint64       `json:"last_checkpoint"`
+	Msgs           int          `json:"msgs"`
+	Size           int          `json:"size"`
 }
 
 type NodeBucket struct {
@@ -145,7 +177,7 @@ func (s *StateMachine) Pretty() string {
 	buffer.WriteString("===========================================\n\n")
 
 	buffer.WriteString("=== Epoch Changer ===\n")
-	buffer.WriteString(fmt.Sprintf("Change is in state: %d, last active epoch %d\n", s.EpochTracker.State, s.EpochTracker.LastActiveEpoch))
+	buffer.WriteString(fmt.Sprintf("Change is in state: %d, last active epoch %d, leaders %v\n", s.EpochTracker.State, s.EpochTracker.LastActiveEpoch, s.EpochTracker.Leaders))
 	for _, et := range s.EpochTracker.EpochTargets {
 		buffer.WriteString(fmt.Sprintf("Target Epoch %d:\n", et.Number))
 		buffer.WriteString("  EpochChanges:\n")
@@ -28,6 +28,7 @@ import (
 	"github.com/IBM/mirbft/reqstore"
 	"github.com/IBM/mirbft/simplewal"
 	"github.com/IBM/mirbft/status"
+	"github.com/IBM/mirbft/transport"
 )
 
 var (
@@ -61,8 +62,9 @@ type FakeLink struct {
 	Source        uint64
 }
 
-func (fl *FakeLink) Send(dest uint64, msg *pb.Msg) {
+func (fl *FakeLink) Send(dest uint64, msg *pb.Msg) error {
 	fl.FakeTransport.Send(fl.Source, dest, msg)
+	return nil
 }
 
 type FakeTransport struct {
@@ -150,22 +152,49 @@ func (ft *FakeTransport) Stop() {
 	ft.WaitGroup.Wait()
 }
 
+// NodeStepper allows the TCP transport to be started before the node
+// it delivers to exists, holding inbound messages until the node is set.
+type NodeStepper struct {
+	Node   *mirbft.Node
+	ReadyC chan struct{}
+	DoneC  <-chan struct{}
+}
+
+func (ns *NodeStepper) Step(ctx context.Context, source uint64, msg *pb.Msg) error {
+	select {
+	case <-ns.ReadyC:
+	case <-ns.DoneC:
+		return mirbft.ErrStopped
+	}
+	return ns.Node.Step(ctx, source, msg)
+}
+
+func (ns *NodeStepper) Err() <-chan struct{} {
+	select {
+	case <-ns.ReadyC:
+		return ns.Node.Err()
+	default:
+		return ns.DoneC
+	}
+}
+
 type FakeLog struct {
 	Entries []*pb.QEntry
 	CommitC chan *pb.QEntry
 }
 
-func (fl *FakeLog) Apply(entry *pb.QEntry) {
+func (fl *FakeLog) Apply(entry *pb.QEntry) error {
 	if len(entry.Requests) == 0 {
 		// this is a no-op batch from a tick, or catchup, ignore it
-		return
+		return nil
 	}
 	fl.Entries = append(fl.Entries, entry)
 	fl.CommitC <- entry
+	return nil
 }
 
-func (fl *FakeLog) Snap(*pb.NetworkState_Config, []*pb.NetworkState_Client) []byte {
-	return Uint64ToBytes(uint64(len(fl.Entries)))
+func (fl *FakeLog) Snap(*pb.NetworkState) ([]byte, error) {
+	return Uint64ToBytes(uint64(len(fl.Entries))), nil
 }
 
 type TestConfig struct {
@@ -174,8 +203,14 @@ type TestConfig struct {
 	MsgCount           int
 	CheckpointInterval int
 	BatchSize          uint32
+	MaxBatchBytes      uint32
+	MinBatchSize       uint32
+	BatchTimeoutTicks  uint32
+	AdaptiveBatchSize  bool
 	ClientWidth        uint32
 	ParallelProcess    bool
+	PipelinedProcess   bool
+	TCPTransport       bool
 }
 
 func Uint64ToBytes(value uint64) []byte {
@@ -306,6 +341,36 @@ var _ = Describe("StressyTest", func() {
 			MsgCount:           10000,
 			ParallelProcess:    true,
 		}),
+
+		Entry("FourNodeBFT single bucket big batch adaptive greenpath", &TestConfig{
+			NodeCount:          4,
+			BucketCount:        1,
+			CheckpointInterval: 10,
+			BatchSize:          10,
+			MaxBatchBytes:      64,
+			MinBatchSize:       2,
+			BatchTimeoutTicks:  1,
+			AdaptiveBatchSize:  true,
+			ClientWidth:        1000,
+			MsgCount:           10000,
+		}),
+
+		Entry("FourNodeBFT single bucket big batch pipelined greenpath", &TestConfig{
+			NodeCount:          4,
+			BucketCount:        1,
+			CheckpointInterval: 10,
+			BatchSize:          10,
+			ClientWidth:        1000,
+			MsgCount:           10000,
+			PipelinedProcess:   true,
+		}),
+
+		Entry("FourNodeBFT TCP transport greenpath", &TestConfig{
+			NodeCount:          4,
+			CheckpointInterval: 20,
+			MsgCount:           1000,
+			TCPTransport:       true,
+		}),
 	)
 })
 
@@ -315,8 +380,11 @@ type TestReplica struct {
 	TmpDir              string
 	Log                 *FakeLog
 	FakeTransport       *FakeTransport
+	TCPTransport        *transport.Transport
+	TCPStepper          *NodeStepper
 	FakeClient          *FakeClient
 	ParallelProcess     bool
+	PipelinedProcess    bool
 	DoneC               <-chan struct{}
 }
 
@@ -324,6 +392,25 @@ func (tr *TestReplica) EventLogPath() string {
 	return filepath.Join(tr.TmpDir, "eventlog.gz")
 }
 
+func (tr *TestReplica) runFakeLink(node *mirbft.Node, linkDoneC chan<- struct{}) {
+	defer GinkgoRecover()
+	defer close(linkDoneC)
+	recvC := tr.FakeTransport.RecvC(node.Config.ID)
+	for {
+		select {
+		case sourceMsg := <-recvC:
+			// fmt.Printf("Stepping message from %d to %d\n", sourceMsg.Source, node.Config.ID)
+			err := node.Step(context.Background(), sourceMsg.Source, sourceMsg.Msg)
+			if err == mirbft.ErrStopped {
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+		case <-tr.DoneC:
+			return
+		}
+	}
+}
+
 func (tr *TestReplica) Run() (*status.StateMachine, error) {
 	ticker := time.NewTicker(tickInterval)
 	defer ticker.Stop()
@@ -340,7 +427,7 @@ func (tr *TestReplica) Run() (*status.StateMachine, error) {
 	Expect(err).NotTo(HaveOccurred())
 	defer file.Close()
 
-	interceptor := eventlog.NewRecorder(tr.Config.ID, file)
+	interceptor := eventlog.NewRecorder(tr.Config.ID, file, eventlog.RecordActionsOpt())
 	defer func() {
 		err := interceptor.Stop()
 		Expect(err).NotTo(HaveOccurred())
@@ -359,45 +446,45 @@ func (tr *TestReplica) Run() (*status.StateMachine, error) {
 	Expect(err).NotTo(HaveOccurred())
 	defer node.Stop()
 
+	var link mirbft.Link
 	linkDoneC := make(chan struct{})
-	go func() {
-		defer GinkgoRecover()
-		defer close(linkDoneC)
-		recvC := tr.FakeTransport.RecvC(node.Config.ID)
-		for {
-			select {
-			case sourceMsg := <-recvC:
-				// fmt.Printf("Stepping message from %d to %d\n", sourceMsg.Source, node.Config.ID)
-				err := node.Step(context.Background(), sourceMsg.Source, sourceMsg.Msg)
-				if err == mirbft.ErrStopped {
-					return
-				}
-				Expect(err).NotTo(HaveOccurred())
-			case <-tr.DoneC:
-				return
-			}
-		}
-	}()
+	if tr.TCPTransport != nil {
+		link = tr.TCPTransport
+		tr.TCPStepper.Node = node
+		close(tr.TCPStepper.ReadyC)
+		close(linkDoneC)
+	} else {
+		link = tr.FakeTransport.Link(node.Config.ID)
+		go tr.runFakeLink(node, linkDoneC)
+	}
 	defer func() {
 		<-linkDoneC
 	}()
 
 	processor := &mirbft.Processor{
 		Node:         node,
-		Link:         tr.FakeTransport.Link(node.Config.ID),
+		Link:         link,
 		Hasher:       sha256.New,
 		Log:          tr.Log,
 		RequestStore: reqStore,
 		WAL:          wal,
 	}
 
-	var process func(*mirbft.Actions) *mirbft.ActionResults
+	var process func(*mirbft.Actions) (*mirbft.ActionResults, error)
 
-	if tr.ParallelProcess {
+	switch {
+	case tr.PipelinedProcess:
+		pp := mirbft.NewPipelinedProcessor(processor, mirbft.PipelinedProcessorOpts{})
+		defer pp.Stop()
+		process = func(actions *mirbft.Actions) (*mirbft.ActionResults, error) {
+			// Results are returned to the node by the pipeline itself
+			return nil, pp.Submit(actions)
+		}
+	case tr.ParallelProcess:
 		pwp := mirbft.NewProcessorWorkPool(processor, mirbft.ProcessorWorkPoolOpts{})
 		defer pwp.Stop()
 		process = pwp.Process
-	} else {
+	default:
 		process = processor.Process
 	}
 
@@ -432,8 +519,14 @@ func (tr *TestReplica) Run() (*status.StateMachine, error) {
 	for {
 		select {
 		case actions := <-node.Ready():
-			results := process(&actions)
-			node.AddResults(*results)
+			results, err := process(&actions)
+			if err != nil {
+				node.StopWithError(err)
+				continue
+			}
+			if results != nil {
+				node.AddResults(*results)
+			}
 			if actions.StateTransfer != nil {
 				panic("we need to implement state transfer for these tests")
 			}
@@ -449,8 +542,9 @@ func (tr *TestReplica) Run() (*status.StateMachine, error) {
 }
 
 type Network struct {
-	Transport    *FakeTransport
-	TestReplicas []*TestReplica
+	Transport     *FakeTransport
+	TCPTransports []*transport.Transport
+	TestReplicas  []*TestReplica
 }
 
 type NodeStatus struct {
@@ -459,7 +553,7 @@ type NodeStatus struct {
 }
 
 func CreateNetwork(testConfig *TestConfig, doneC <-chan struct{}) *Network {
-	transport := NewFakeTransport(testConfig.NodeCount)
+	fakeTransport := NewFakeTransport(testConfig.NodeCount)
 
 	networkState := mirbft.StandardInitialNetworkState(testConfig.NodeCount, 0)
 
@@ -497,6 +591,11 @@ func CreateNetwork(testConfig *TestConfig, doneC <-chan struct{}) *Network {
 			config.BatchSize = testConfig.BatchSize
 		}
 
+		config.MaxBatchBytes = testConfig.MaxBatchBytes
+		config.MinBatchSize = testConfig.MinBatchSize
+		config.BatchTimeoutTicks = testConfig.BatchTimeoutTicks
+		config.AdaptiveBatchSize = testConfig.AdaptiveBatchSize
+
 		fakeLog := &FakeLog{
 			// We make the CommitC excessive, to prevent deadlock
 			// in case of bugs this test would otherwise catch.
@@ -508,18 +607,48 @@ func CreateNetwork(testConfig *TestConfig, doneC <-chan struct{}) *Network {
 			InitialNetworkState: networkState,
 			TmpDir:              filepath.Join(tmpDir, fmt.Sprintf("node%d", i)),
 			Log:                 fakeLog,
-			FakeTransport:       transport,
+			FakeTransport:       fakeTransport,
 			FakeClient: &FakeClient{
 				MsgCount: uint64(testConfig.MsgCount),
 			},
-			ParallelProcess: testConfig.ParallelProcess,
-			DoneC:           doneC,
+			ParallelProcess:  testConfig.ParallelProcess,
+			PipelinedProcess: testConfig.PipelinedProcess,
+			DoneC:            doneC,
+		}
+	}
+
+	var tcpTransports []*transport.Transport
+	if testConfig.TCPTransport {
+		tcpTransports = make([]*transport.Transport, len(replicas))
+		for i, replica := range replicas {
+			tcpTransports[i] = transport.New(transport.Config{
+				ID:            uint64(i),
+				ListenAddress: "127.0.0.1:0",
+				Logger:        mirbft.ConsoleWarnLogger,
+			})
+			replica.TCPTransport = tcpTransports[i]
+			replica.TCPStepper = &NodeStepper{
+				ReadyC: make(chan struct{}),
+				DoneC:  doneC,
+			}
+			err := tcpTransports[i].Start(replica.TCPStepper)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for i, tcpTransport := range tcpTransports {
+			for j, peer := range tcpTransports {
+				if i == j {
+					continue
+				}
+				tcpTransport.AddPeer(uint64(j), peer.Addr().String())
+			}
 		}
 	}
 
 	return &Network{
-		Transport:    transport,
-		TestReplicas: replicas,
+		Transport:     fakeTransport,
+		TCPTransports: tcpTransports,
+		TestReplicas:  replicas,
 	}
 }
 
@@ -555,6 +684,10 @@ func (n *Network) Run() []*NodeStatus {
 
 	wg.Wait()
 
+	for _, tcpTransport := range n.TCPTransports {
+		tcpTransport.Stop()
+	}
+
 	fmt.Printf("All go routines shut down\n")
 	return result
 }
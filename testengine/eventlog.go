@@ -57,6 +57,12 @@ func ReadEventLog(source io.Reader) (el *EventLog, err error) {
 			return nil, err
 		}
 
+		if event.Actions != nil {
+			// Recorded actions are not inputs, and the player
+			// regenerates them anyway.
+			continue
+		}
+
 		eventLog.List.PushBack(event)
 	}
 
@@ -72,6 +72,22 @@ func After(matcher MangleMatcher) *Mangling {
 	}
 }
 
+// Once is useful to apply a mangling only the first time a condition is satisfied,
+// for instance, to crash a node once it reaches some state.
+func Once(matcher MangleMatcher) *Mangling {
+	matched := false
+	return &Mangling{
+		Filter: InlineMatcher(func(random int, event *rpb.RecordedEvent) bool {
+			if matched || !matcher.Matches(random, event) {
+				return false
+			}
+
+			matched = true
+			return true
+		}),
+	}
+}
+
 // For is a simple way to apply a mangler whenever a condition is satisfied.
 func For(matcher MangleMatcher) *Mangling {
 	return &Mangling{
@@ -639,6 +655,35 @@ func (baseMangling) OfTypeRequestAck() mangleFilter {
 	return ofType(reflect.TypeOf(&pb.Msg_RequestAck{}))
 }
 
+// ChainMangler applies each of its manglers in turn, to the results of the
+// previous mangler, allowing several faults to be combined.  Results which
+// are to be remangled are not passed to the subsequent manglers, as they will
+// be mangled again by the whole chain.
+type ChainMangler []Mangler
+
+func (cm ChainMangler) Mangle(random int, event *rpb.RecordedEvent) []MangleResult {
+	results := []MangleResult{
+		{
+			Event: event,
+		},
+	}
+
+	for _, mangler := range cm {
+		var nextResults []MangleResult
+		for _, result := range results {
+			if result.Remangle {
+				nextResults = append(nextResults, result)
+				continue
+			}
+
+			nextResults = append(nextResults, mangler.Mangle(random, result.Event)...)
+		}
+		results = nextResults
+	}
+
+	return results
+}
+
 type DropMangler struct{}
 
 func (DropMangler) Mangle(random int, event *rpb.RecordedEvent) []MangleResult {
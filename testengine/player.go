@@ -11,6 +11,7 @@ import (
 	"io"
 
 	"github.com/IBM/mirbft"
+	"github.com/IBM/mirbft/eventlog"
 	rpb "github.com/IBM/mirbft/eventlog/recorderpb"
 	pb "github.com/IBM/mirbft/mirbftpb"
 	"github.com/IBM/mirbft/status"
@@ -27,6 +28,12 @@ type PlaybackNode struct {
 	Processing   *mirbft.Actions
 	Actions      *mirbft.Actions
 	Status       *status.StateMachine
+
+	// Results is set once the node has been restarted synthetically, as
+	// at the start of a rotated event log segment, and filters the recorded
+	// results to those which the replayed state machine has requested.
+	Results      *eventlog.ResultFilter
+	segmentStart bool
 }
 
 type Player struct {
@@ -97,7 +104,29 @@ func (p *Player) Step() error {
 
 	node := p.Node(event.NodeId)
 
-	switch event.StateEvent.Type.(type) {
+	switch {
+	case event.Segment.GetWalUnknown():
+		return errors.Errorf("cannot play segment %d: the recorder did not know the WAL of node %d when it began", event.Segment.Number, event.NodeId)
+	case event.Segment != nil:
+		// The next Initialize is the synthetic restart of a rotated segment
+		node.segmentStart = true
+		return nil
+	case event.Gap != nil:
+		return errors.Errorf("cannot play incomplete log: the recorder dropped events for node %d", event.NodeId)
+	case event.StateEvent == nil:
+		// Recorded actions are regenerated by playback
+		return nil
+	}
+
+	stateEvent := event.StateEvent
+	if node.Results != nil {
+		stateEvent = node.Results.Filter(stateEvent)
+		if stateEvent == nil {
+			return nil
+		}
+	}
+
+	switch stateEvent.Type.(type) {
 	case *pb.StateEvent_Initialize:
 		sm := &mirbft.StateMachine{
 			Logger: NamedLogger{
@@ -110,6 +139,11 @@ func (p *Player) Step() error {
 		node.Actions = &mirbft.Actions{}
 		node.Status = sm.Status()
 		node.Processing = nil
+		node.Results = nil
+		if node.segmentStart {
+			node.segmentStart = false
+			node.Results = eventlog.NewResultFilter()
+		}
 	case *pb.StateEvent_Transfer:
 	case *pb.StateEvent_AddResults:
 		if node.Processing == nil {
@@ -126,13 +160,17 @@ func (p *Player) Step() error {
 		node.Actions = &mirbft.Actions{}
 	}
 
-	newActions := node.StateMachine.ApplyEvent(event.StateEvent)
+	newActions := node.StateMachine.ApplyEvent(stateEvent)
+	if node.Results != nil {
+		node.Results.ApplyActions(newActions)
+	}
 	node.Actions.Send = append(node.Actions.Send, newActions.Send...)
 	node.Actions.Hash = append(node.Actions.Hash, newActions.Hash...)
 	node.Actions.Commits = append(node.Actions.Commits, newActions.Commits...)
 	node.Actions.WriteAhead = append(node.Actions.WriteAhead, newActions.WriteAhead...)
 	node.Actions.ForwardRequests = append(node.Actions.ForwardRequests, newActions.ForwardRequests...)
 	node.Actions.StoreRequests = append(node.Actions.StoreRequests, newActions.StoreRequests...)
+	node.Actions.Misbehaviors = append(node.Actions.Misbehaviors, newActions.Misbehaviors...)
 	if newActions.StateTransfer != nil {
 		if node.Actions.StateTransfer != nil {
 			return errors.Errorf("node %d has requested state transfer twice without resolution", event.NodeId)
@@ -140,6 +178,9 @@ func (p *Player) Step() error {
 
 		node.Actions.StateTransfer = newActions.StateTransfer
 	}
+	if newActions.StableCheckpoint != nil {
+		node.Actions.StableCheckpoint = newActions.StableCheckpoint
+	}
 
 	node.Status = node.StateMachine.Status()
 
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"container/list"
+	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
@@ -36,6 +37,11 @@ func uint64ToBytes(value uint64) []byte {
 type RecorderNodeConfig struct {
 	InitParms    *pb.StateEvent_InitialParameters
 	RuntimeParms *RuntimeParameters
+
+	// DivergentCheckpoints are sequence numbers at which this node's
+	// application computes a wrong checkpoint value, simulating
+	// non-determinism in the application.
+	DivergentCheckpoints []uint64
 }
 
 type RuntimeParameters struct {
@@ -52,24 +58,28 @@ type RuntimeParameters struct {
 type ReqStore struct {
 	ReqAcks   *list.List
 	ReqAckMap map[*pb.RequestAck]*list.Element
+	ReqSizes  map[*pb.RequestAck]int
 }
 
 func NewReqStore() *ReqStore {
 	return &ReqStore{
 		ReqAcks:   list.New(),
 		ReqAckMap: map[*pb.RequestAck]*list.Element{},
+		ReqSizes:  map[*pb.RequestAck]int{},
 	}
 }
 
-func (rs *ReqStore) Store(ack *pb.RequestAck, _ []byte) {
+func (rs *ReqStore) Store(ack *pb.RequestAck, data []byte) {
 	el := rs.ReqAcks.PushBack(ack)
 	rs.ReqAckMap[ack] = el
+	rs.ReqSizes[ack] = len(data)
 	// TODO, deal with free-ing
 }
 
-func (rs *ReqStore) Uncommitted(forEach func(*pb.RequestAck)) error {
+func (rs *ReqStore) Uncommitted(forEach func(*pb.RequestAck, int)) error {
 	for el := rs.ReqAcks.Front(); el != nil; el = el.Next() {
-		forEach(el.Value.(*pb.RequestAck))
+		ack := el.Value.(*pb.RequestAck)
+		forEach(ack, rs.ReqSizes[ack])
 	}
 	return nil
 }
@@ -109,6 +119,24 @@ func NewWAL(initialState *pb.NetworkState, initialCP []byte) *WAL {
 	return wal
 }
 
+// NewJoinWAL is like NewWAL, but for a node joining the network from the
+// initial state, which must first state transfer to it.  It mirrors the
+// WAL initialized by mirbft.JoinNetwork.
+func NewJoinWAL(initialState *pb.NetworkState, initialCP []byte) *WAL {
+	wal := NewWAL(initialState, initialCP)
+
+	wal.List.PushBack(&pb.Persistent{
+		Type: &pb.Persistent_TEntry{
+			TEntry: &pb.TEntry{
+				SeqNo: 0,
+				Value: []byte("fake-initial-value"),
+			},
+		},
+	})
+
+	return wal
+}
+
 func (wal *WAL) Append(index uint64, p *pb.Persistent) {
 	if index != wal.LowIndex+uint64(wal.List.Len()) {
 		panic(fmt.Sprintf("WAL out of order: expect next index %d, but got %d", wal.LowIndex+uint64(wal.List.Len()), index))
@@ -148,6 +176,9 @@ type RecorderNode struct {
 	ReqStore             *ReqStore
 	Config               *RecorderNodeConfig
 	AwaitingProcessEvent bool
+
+	// Misbehaviors accumulates the misbehavior the node has reported.
+	Misbehaviors []*mirbft.Misbehavior
 }
 
 type RecorderClient struct {
@@ -166,11 +197,17 @@ func (rc *RecorderClient) RequestByReqNo(reqNo uint64) *pb.Request {
 	buffer.Write([]byte("-"))
 	buffer.Write(uint64ToBytes(reqNo))
 
-	return &pb.Request{
+	request := &pb.Request{
 		ClientId: rc.Config.ID,
 		ReqNo:    reqNo,
 		Data:     buffer.Bytes(),
 	}
+
+	if rc.Config.SigningKey != nil {
+		return mirbft.SignRequest(rc.Config.SigningKey, request)
+	}
+
+	return request
 }
 
 type NodeState struct {
@@ -181,6 +218,7 @@ type NodeState struct {
 	PendingReconfigurations []*pb.Reconfiguration
 	Checkpoints             *list.List
 	CheckpointsBySeqNo      map[uint64]*list.Element
+	DivergentCheckpoints    []uint64
 }
 
 func (ns *NodeState) Set(seqNo uint64, value []byte, networkState *pb.NetworkState) *pb.CheckpointResult {
@@ -193,6 +231,7 @@ func (ns *NodeState) Set(seqNo uint64, value []byte, networkState *pb.NetworkSta
 	}
 
 	ns.LastSeqNo = seqNo
+	ns.PendingReconfigurations = nil
 
 	el := ns.Checkpoints.PushBack(checkpoint)
 	ns.CheckpointsBySeqNo[seqNo] = el
@@ -239,12 +278,20 @@ func (ns *NodeState) Commit(commits []*mirbft.Commit, node uint64) []*pb.Checkpo
 			panic("asked to checkpoint for uncommitted sequence")
 		}
 
+		value := ns.ActiveHash.Sum(nil)
+		for _, seqNo := range ns.DivergentCheckpoints {
+			if seqNo == commit.Checkpoint.SeqNo {
+				value[0] ^= 0xff
+			}
+		}
+
 		checkpoint := ns.Set(
 			commit.Checkpoint.SeqNo,
-			ns.ActiveHash.Sum(nil),
+			value,
 			&pb.NetworkState{
-				Config:  commit.Checkpoint.NetworkConfig,
-				Clients: commit.Checkpoint.ClientsState,
+				Config:                  commit.Checkpoint.NetworkConfig,
+				Clients:                 commit.Checkpoint.ClientsState,
+				PendingReconfigurations: ns.PendingReconfigurations,
 			},
 		)
 
@@ -260,6 +307,9 @@ type ClientConfig struct {
 	TxLatency   uint64
 	MaxInFlight int
 	Total       uint64
+
+	// SigningKey, if set, is used to sign each request with mirbft.SignRequest
+	SigningKey ed25519.PrivateKey
 }
 
 type ReconfigPoint struct {
@@ -276,6 +326,7 @@ type Recorder struct {
 	Mangler             Mangler
 	LogOutput           io.Writer
 	Hasher              Hasher
+	RequestVerifier     mirbft.RequestVerifier
 	RandomSeed          int64
 }
 
@@ -298,7 +349,14 @@ func (r *Recorder) Recording(output *gzip.Writer) (*Recording, error) {
 
 		checkpointValue := []byte("fake-initial-value")
 
-		wal := NewWAL(r.NetworkState, checkpointValue)
+		var wal *WAL
+		if isMember(r.NetworkState.Config.Nodes, nodeID) {
+			wal = NewWAL(r.NetworkState, checkpointValue)
+		} else {
+			// Nodes outside the initial network configuration
+			// join the network, participating once added.
+			wal = NewJoinWAL(r.NetworkState, checkpointValue)
+		}
 
 		eventLog.InsertStateEvent(
 			nodeID,
@@ -311,10 +369,11 @@ func (r *Recorder) Recording(output *gzip.Writer) (*Recording, error) {
 		)
 
 		nodeState := &NodeState{
-			Hasher:             r.Hasher,
-			ReconfigPoints:     r.ReconfigPoints,
-			Checkpoints:        list.New(),
-			CheckpointsBySeqNo: map[uint64]*list.Element{},
+			Hasher:               r.Hasher,
+			ReconfigPoints:       r.ReconfigPoints,
+			Checkpoints:          list.New(),
+			CheckpointsBySeqNo:   map[uint64]*list.Element{},
+			DivergentCheckpoints: recorderNodeConfig.DivergentCheckpoints,
 		}
 
 		nodes[i] = &RecorderNode{
@@ -337,20 +396,22 @@ func (r *Recorder) Recording(output *gzip.Writer) (*Recording, error) {
 	}
 
 	return &Recording{
-		Hasher:   r.Hasher,
-		EventLog: eventLog,
-		Player:   player,
-		Nodes:    nodes,
-		Clients:  clients,
+		Hasher:          r.Hasher,
+		RequestVerifier: r.RequestVerifier,
+		EventLog:        eventLog,
+		Player:          player,
+		Nodes:           nodes,
+		Clients:         clients,
 	}, nil
 }
 
 type Recording struct {
-	Hasher   Hasher
-	EventLog *EventLog
-	Player   *Player
-	Nodes    []*RecorderNode
-	Clients  []*RecorderClient
+	Hasher          Hasher
+	RequestVerifier mirbft.RequestVerifier
+	EventLog        *EventLog
+	Player          *Player
+	Nodes           []*RecorderNode
+	Clients         []*RecorderClient
 }
 
 func (r *Recording) Step() error {
@@ -381,6 +442,12 @@ func (r *Recording) Step() error {
 				}
 
 				highWatermark := rw.LowWatermark + uint64(rw.Width) - uint64(rw.WidthConsumedLastCheckpoint)
+				if client.NextNodeReqNoSend[lastEvent.NodeId] > highWatermark {
+					// The client window shrank, so the requests above it were
+					// dropped and must be resubmitted once the window allows,
+					// as a ClientProposer reports with a RequestsDroppedError.
+					client.NextNodeReqNoSend[lastEvent.NodeId] = highWatermark
+				}
 
 				for i := client.NextNodeReqNoSend[lastEvent.NodeId]; i < highWatermark && i < client.Config.Total; i++ {
 					req := client.RequestByReqNo(i)
@@ -402,6 +469,8 @@ func (r *Recording) Step() error {
 		node.AwaitingProcessEvent = false
 		processing := playbackNode.Processing
 
+		node.Misbehaviors = append(node.Misbehaviors, processing.Misbehaviors...)
+
 		for _, req := range processing.StoreRequests {
 			node.ReqStore.Store(req.RequestAck, req.RequestData)
 		}
@@ -439,19 +508,24 @@ func (r *Recording) Step() error {
 		}
 
 		apply := &pb.StateEvent_ActionResults{
-			Digests: make([]*pb.HashResult, len(processing.Hash)),
+			Digests: make([]*pb.HashResult, 0, len(processing.Hash)),
 		}
 
-		for i, hashRequest := range processing.Hash {
+		for _, hashRequest := range processing.Hash {
+			// As the processor does, drop requests which fail verification
+			if mirbft.VerifyHashRequest(r.RequestVerifier, hashRequest) != nil {
+				continue
+			}
+
 			hasher := r.Hasher()
 			for _, data := range hashRequest.Data {
 				hasher.Write(data)
 			}
 
-			apply.Digests[i] = &pb.HashResult{
+			apply.Digests = append(apply.Digests, &pb.HashResult{
 				Digest: hasher.Sum(nil),
 				Type:   hashRequest.Origin.Type,
-			}
+			})
 		}
 
 		apply.Checkpoints = nodeState.Commit(processing.Commits, lastEvent.NodeId)
@@ -508,6 +582,10 @@ func (r *Recording) Step() error {
 			}
 		}
 
+		// Any pending processing event was just removed from the log,
+		// and the player discards the actions it was processing.
+		node.AwaitingProcessEvent = false
+
 		delay := int64(0)
 
 		var maxCEntry *pb.CEntry
@@ -534,7 +612,7 @@ func (r *Recording) Step() error {
 
 		nodeState.Set(maxCEntry.SeqNo, maxCEntry.CheckpointValue, maxCEntry.NetworkState)
 
-		node.ReqStore.Uncommitted(func(ack *pb.RequestAck) {
+		node.ReqStore.Uncommitted(func(ack *pb.RequestAck, size int) {
 			delay += int64(runtimeParms.ReqReadDelay)
 			r.EventLog.InsertStateEvent(
 				lastEvent.NodeId,
@@ -542,6 +620,7 @@ func (r *Recording) Step() error {
 					Type: &pb.StateEvent_LoadRequest{
 						LoadRequest: &pb.StateEvent_OutstandingRequest{
 							RequestAck: ack,
+							Size:       uint64(size),
 						},
 					},
 				},
@@ -578,6 +657,15 @@ func (r *Recording) Step() error {
 	return nil
 }
 
+func isMember(nodes []uint64, id uint64) bool {
+	for _, nodeID := range nodes {
+		if nodeID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func isEmpty(actions *mirbft.Actions) bool {
 	return len(actions.Send) == 0 &&
 		len(actions.WriteAhead) == 0 &&
@@ -585,7 +673,9 @@ func isEmpty(actions *mirbft.Actions) bool {
 		len(actions.StoreRequests) == 0 &&
 		len(actions.ForwardRequests) == 0 &&
 		len(actions.Commits) == 0 &&
-		actions.StateTransfer == nil
+		actions.StateTransfer == nil &&
+		actions.StableCheckpoint == nil &&
+		len(actions.Misbehaviors) == 0
 }
 
 // DrainClients will execute the recording until all client requests have committed.
@@ -608,7 +698,13 @@ func (r *Recording) DrainClients(timeout int) (count int, err error) {
 		allDone := true
 	outer:
 		for _, node := range r.Nodes {
-			for _, client := range node.State.LastCheckpoint().NetworkState.Clients {
+			lastCheckpoint := node.State.LastCheckpoint()
+			if !isMember(lastCheckpoint.NetworkState.Config.Nodes, node.Config.InitParms.Id) {
+				// Nodes which have yet to join, or have been removed, commit nothing
+				continue
+			}
+
+			for _, client := range lastCheckpoint.NetworkState.Clients {
 				if targetReqs[client.Id] != client.LowWatermark {
 					allDone = false
 					break outer
@@ -623,7 +719,12 @@ func (r *Recording) DrainClients(timeout int) (count int, err error) {
 		if count > timeout {
 			var errText string
 			for _, node := range r.Nodes {
-				for _, client := range node.State.LastCheckpoint().NetworkState.Clients {
+				lastCheckpoint := node.State.LastCheckpoint()
+				if !isMember(lastCheckpoint.NetworkState.Config.Nodes, node.Config.InitParms.Id) {
+					continue
+				}
+
+				for _, client := range lastCheckpoint.NetworkState.Clients {
 					if targetReqs[client.Id] != client.LowWatermark {
 						errText = fmt.Sprintf("(at least) node%d failed with client %d committing only through %d when expected %d", node.Config.InitParms.Id, client.Id, client.LowWatermark, targetReqs[client.Id])
 					}
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mirbft
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/IBM/mirbft/mirbftpb"
+)
+
+var _ = Describe("transfer", func() {
+	var (
+		node         *Node
+		transferC    chan *pb.StateEvent_Transfer
+		ctx          context.Context
+		cancel       context.CancelFunc
+		target       *StateTarget
+		networkState *pb.NetworkState
+	)
+
+	BeforeEach(func() {
+		transferC = make(chan *pb.StateEvent_Transfer, 1)
+		node = &Node{
+			Config: &Config{
+				Logger: ConsoleWarnLogger,
+			},
+			s: &serializer{
+				transferC: transferC,
+				errC:      make(chan struct{}),
+			},
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+		target = &StateTarget{
+			SeqNo: 20,
+			Value: []byte("value"),
+		}
+		networkState = StandardInitialNetworkState(4, 0)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("reports a transfer which completes though it was cancelled", func() {
+		transfer(ctx, node, func(ctx context.Context, target *StateTarget) (*pb.NetworkState, error) {
+			cancel()
+			return networkState, nil
+		}, target)
+
+		var result *pb.StateEvent_Transfer
+		Expect(transferC).To(Receive(&result))
+		Expect(result.Transfer.SeqNo).To(Equal(uint64(20)))
+		Expect(result.Transfer.NetworkState).To(Equal(networkState))
+	})
+
+	It("reports a failed transfer", func() {
+		transfer(ctx, node, func(ctx context.Context, target *StateTarget) (*pb.NetworkState, error) {
+			return nil, fmt.Errorf("checkpoint unavailable")
+		}, target)
+
+		var result *pb.StateEvent_Transfer
+		Expect(transferC).To(Receive(&result))
+		Expect(result.Transfer.NetworkState).To(BeNil())
+	})
+
+	It("reports a transfer which fails for another reason after it was cancelled", func() {
+		transfer(ctx, node, func(ctx context.Context, target *StateTarget) (*pb.NetworkState, error) {
+			cancel()
+			return nil, fmt.Errorf("checkpoint unavailable")
+		}, target)
+
+		Expect(transferC).To(Receive())
+	})
+
+	It("does not report a transfer which fails because it was cancelled", func() {
+		transfer(ctx, node, func(ctx context.Context, target *StateTarget) (*pb.NetworkState, error) {
+			cancel()
+			return nil, errors.WithMessage(ctx.Err(), "fetch interrupted")
+		}, target)
+
+		Expect(transferC).NotTo(Receive())
+	})
+})
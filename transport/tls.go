@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// TLSConfig enables mutually authenticated TLS between peers.  Rather than
+// relying on a certificate authority, each node is identified by exactly one
+// certificate, and the certificates of all nodes in NetworkState.Config.Nodes
+// must be known in advance.  A connection is only accepted if the certificate
+// presented by the remote side is the certificate of the node it claims to be.
+type TLSConfig struct {
+	// Certificate is the certificate and private key this node presents
+	// to its peers.
+	Certificate tls.Certificate
+
+	// PeerCertificates maps the NodeIDs of the other nodes in the network
+	// to the certificates they present.
+	PeerCertificates map[uint64]*x509.Certificate
+}
+
+// peerCertificates is a concurrency safe copy of the configured peer
+// certificates which may be updated as the network is reconfigured.
+type peerCertificates struct {
+	mutex sync.Mutex
+	certs map[uint64]*x509.Certificate
+}
+
+func newPeerCertificates(certs map[uint64]*x509.Certificate) *peerCertificates {
+	pc := &peerCertificates{
+		certs: map[uint64]*x509.Certificate{},
+	}
+
+	for id, cert := range certs {
+		pc.certs[id] = cert
+	}
+
+	return pc
+}
+
+func (pc *peerCertificates) set(id uint64, cert *x509.Certificate) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.certs[id] = cert
+}
+
+func (pc *peerCertificates) remove(id uint64) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	delete(pc.certs, id)
+}
+
+// verify ensures that the raw certificate presented by the remote side is
+// the certificate expected for the given node.
+func (pc *peerCertificates) verify(id uint64, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return errors.Errorf("node %d presented no certificate", id)
+	}
+
+	pc.mutex.Lock()
+	expected, ok := pc.certs[id]
+	pc.mutex.Unlock()
+
+	if !ok {
+		return errors.Errorf("no certificate is known for node %d", id)
+	}
+
+	if !bytes.Equal(expected.Raw, rawCerts[0]) {
+		return errors.Errorf("certificate presented does not match the certificate of node %d", id)
+	}
+
+	return nil
+}
+
+// serverConfig returns the TLS configuration for inbound connections.  The
+// client certificate is required, but cannot be verified until the remote
+// side has claimed an identity, so verification is deferred to
+// verifyInbound.
+func (tc *TLSConfig) serverConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{tc.Certificate},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// clientConfig returns the TLS configuration for an outbound connection to
+// the given node.  The standard chain verification is replaced by
+// verification that the server presents the expected node certificate.
+func (tc *TLSConfig) clientConfig(id uint64, pc *peerCertificates) *tls.Config {
+	return &tls.Config{
+		Certificates:       []tls.Certificate{tc.Certificate},
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return pc.verify(id, rawCerts)
+		},
+	}
+}
+
+// verifyInbound ensures that the remote side of an inbound TLS connection
+// presented the certificate of the node it claims to be.
+func (pc *peerCertificates) verifyInbound(conn net.Conn, source uint64) error {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return errors.Errorf("connection is not a TLS connection")
+	}
+
+	state := tlsConn.ConnectionState()
+	rawCerts := make([][]byte, len(state.PeerCertificates))
+	for i, cert := range state.PeerCertificates {
+		rawCerts[i] = cert.Raw
+	}
+
+	return pc.verify(source, rawCerts)
+}
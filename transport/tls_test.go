@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/mirbft/transport"
+)
+
+type nodeIdentity struct {
+	tlsCert tls.Certificate
+	cert    *x509.Certificate
+}
+
+func generateIdentity(id uint64) nodeIdentity {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(id) + 1),
+		Subject: pkix.Name{
+			CommonName: fmt.Sprintf("node%d", id),
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	return nodeIdentity{
+		tlsCert: tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  key,
+			Leaf:        cert,
+		},
+		cert: cert,
+	}
+}
+
+var _ = Describe("TLS Transport", func() {
+	var (
+		nodeCount  = 4
+		identities []nodeIdentity
+		peerCerts  map[uint64]*x509.Certificate
+		transports []*transport.Transport
+		steppers   []*fakeStepper
+	)
+
+	startNode := func(id uint64, identity nodeIdentity, listenAddress string) (*transport.Transport, *fakeStepper) {
+		t := transport.New(transport.Config{
+			ID:            id,
+			ListenAddress: listenAddress,
+			TLS: &transport.TLSConfig{
+				Certificate:      identity.tlsCert,
+				PeerCertificates: peerCerts,
+			},
+		})
+		stepper := &fakeStepper{}
+		err := t.Start(stepper)
+		Expect(err).NotTo(HaveOccurred())
+		return t, stepper
+	}
+
+	BeforeEach(func() {
+		identities = make([]nodeIdentity, nodeCount)
+		peerCerts = map[uint64]*x509.Certificate{}
+		for i := range identities {
+			identities[i] = generateIdentity(uint64(i))
+			peerCerts[uint64(i)] = identities[i].cert
+		}
+
+		transports = make([]*transport.Transport, nodeCount)
+		steppers = make([]*fakeStepper, nodeCount)
+		for i := range transports {
+			transports[i], steppers[i] = startNode(uint64(i), identities[i], "127.0.0.1:0")
+		}
+
+		for i, t := range transports {
+			for j, peer := range transports {
+				if i == j {
+					continue
+				}
+				t.AddPeer(uint64(j), peer.Addr().String())
+			}
+		}
+	})
+
+	AfterEach(func() {
+		for _, t := range transports {
+			t.Stop()
+		}
+	})
+
+	It("delivers messages between authenticated nodes", func() {
+		for i, t := range transports {
+			for j := range transports {
+				if i == j {
+					continue
+				}
+				t.Send(uint64(j), checkpointMsg(uint64(i)))
+			}
+		}
+
+		for i := range transports {
+			for j := range transports {
+				if i == j {
+					continue
+				}
+				Eventually(func() []uint64 {
+					return steppers[j].SeqNosFrom(uint64(i))
+				}).Should(Equal([]uint64{uint64(i)}))
+			}
+		}
+	})
+
+	When("a node claims the identity of another node", func() {
+		It("rejects the connection", func() {
+			// The impostor holds a valid certificate, node 3's, but
+			// claims to be node 1.
+			impostor, _ := startNode(1, identities[3], "127.0.0.1:0")
+			defer impostor.Stop()
+
+			impostor.AddPeer(0, transports[0].Addr().String())
+			Consistently(func() int {
+				impostor.Send(0, checkpointMsg(99))
+				return len(steppers[0].Received())
+			}, 500*time.Millisecond).Should(BeZero())
+		})
+	})
+
+	When("a node presents an unknown certificate", func() {
+		It("rejects the connection", func() {
+			stranger, _ := startNode(1, generateIdentity(1), "127.0.0.1:0")
+			defer stranger.Stop()
+
+			stranger.AddPeer(0, transports[0].Addr().String())
+			Consistently(func() int {
+				stranger.Send(0, checkpointMsg(99))
+				return len(steppers[0].Received())
+			}, 500*time.Millisecond).Should(BeZero())
+		})
+	})
+
+	When("a node connects without TLS", func() {
+		It("rejects the connection", func() {
+			plaintext := transport.New(transport.Config{
+				ID:            1,
+				ListenAddress: "127.0.0.1:0",
+			})
+			err := plaintext.Start(&fakeStepper{})
+			Expect(err).NotTo(HaveOccurred())
+			defer plaintext.Stop()
+
+			plaintext.AddPeer(0, transports[0].Addr().String())
+			Consistently(func() int {
+				plaintext.Send(0, checkpointMsg(99))
+				return len(steppers[0].Received())
+			}, 500*time.Millisecond).Should(BeZero())
+		})
+	})
+
+	When("the server presents the wrong certificate", func() {
+		It("does not send to it", func() {
+			// This node listens claiming to be node 1, but has
+			// node 2's certificate
+			address := transports[1].Addr().String()
+			transports[1].Stop()
+			impostorStepper := &fakeStepper{}
+			impostor := transport.New(transport.Config{
+				ID:            1,
+				ListenAddress: address,
+				TLS: &transport.TLSConfig{
+					Certificate:      identities[2].tlsCert,
+					PeerCertificates: peerCerts,
+				},
+			})
+			err := impostor.Start(impostorStepper)
+			Expect(err).NotTo(HaveOccurred())
+			transports[1] = impostor
+			impostor.AddPeer(0, transports[0].Addr().String())
+
+			Consistently(func() int {
+				transports[0].Send(1, checkpointMsg(99))
+				return len(impostorStepper.Received())
+			}, 500*time.Millisecond).Should(BeZero())
+		})
+	})
+})
@@ -0,0 +1,717 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package transport is a basic TCP based implementation of the mirbft.Link
+// interface, meant to be the first 'real' networking option for mirbft.
+// Each node dials a single outbound connection to each of its peers, over which
+// it writes length prefixed, marshaled pb.Msg messages.  Each node likewise
+// accepts inbound connections from its peers, and injects the messages read
+// from them into the state machine via Step.  Because the state machine tolerates
+// message loss, sends never block; if the send queue for a peer is full, or
+// the connection to a peer fails, messages are dropped and the connection is
+// re-established with a backoff.  Unless TLS is configured, the node ID
+// claimed by the remote side of a connection is trusted without verification.
+package transport
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultSendQueueSize is the number of messages which may be queued
+	// for a peer before further messages to that peer are dropped.
+	DefaultSendQueueSize = 1000
+
+	// DefaultMaxMsgSize is the largest message in bytes which will be
+	// read from a peer before the connection is considered corrupt.
+	DefaultMaxMsgSize = 100 * 1024 * 1024
+
+	// DefaultMinReconnectBackoff is the initial delay before attempting
+	// to re-establish a failed connection.
+	DefaultMinReconnectBackoff = 50 * time.Millisecond
+
+	// DefaultMaxReconnectBackoff is the maximum delay between attempts
+	// to re-establish a failed connection.
+	DefaultMaxReconnectBackoff = 5 * time.Second
+
+	// handshakeTimeout bounds the time a new connection may take to
+	// complete the TLS and node identity handshakes.
+	handshakeTimeout = 10 * time.Second
+)
+
+// ErrStopped is returned by Send once the transport has been stopped.
+var ErrStopped = errors.New("transport stopped")
+
+// Stepper is the subset of the *mirbft.Node API used to deliver inbound
+// messages to the state machine, and to detect that it has exited.
+type Stepper interface {
+	Step(ctx context.Context, source uint64, msg *pb.Msg) error
+	Err() <-chan struct{}
+}
+
+type Config struct {
+	// ID is the NodeID for this instance.
+	ID uint64
+
+	// ListenAddress is the address on which to accept connections from
+	// peers, for instance "0.0.0.0:7000".  A port of 0 selects a random
+	// port, which may be retrieved via Addr after Start.
+	ListenAddress string
+
+	// Peers is a map from the NodeIDs of the other nodes in the network
+	// to the address they are listening on.  Peers may also be added
+	// or removed after start via AddPeer and RemovePeer.
+	Peers map[uint64]string
+
+	// SendQueueSize overrides DefaultSendQueueSize if non-zero.
+	SendQueueSize int
+
+	// MaxMsgSize overrides DefaultMaxMsgSize if non-zero.
+	MaxMsgSize uint32
+
+	// MinReconnectBackoff overrides DefaultMinReconnectBackoff if non-zero.
+	MinReconnectBackoff time.Duration
+
+	// MaxReconnectBackoff overrides DefaultMaxReconnectBackoff if non-zero.
+	MaxReconnectBackoff time.Duration
+
+	// TLS, if set, enables mutually authenticated TLS for all connections.
+	// If unset, the node ID claimed by the remote side of a connection is
+	// trusted, which is only appropriate for testing.
+	TLS *TLSConfig
+
+	// Logger provides the logging functions.
+	Logger mirbft.Logger
+}
+
+// Transport implements mirbft.Link.  It must be started before use
+// and stopped to release its resources.
+type Transport struct {
+	config   Config
+	stepper  Stepper
+	listener net.Listener
+
+	// peerCerts is nil unless TLS is enabled
+	peerCerts *peerCertificates
+
+	mutex sync.Mutex
+	peers map[uint64]*peer
+	conns map[net.Conn]struct{}
+
+	// inbound tracks the authenticated inbound connections by peer ID,
+	// so that they may be closed when the peer is removed.
+	inbound map[uint64]map[net.Conn]struct{}
+
+	waitGroup sync.WaitGroup
+	doneC     chan struct{}
+}
+
+// New creates a new transport from the given config.  Unset optional values
+// in the config are replaced with their defaults.
+func New(config Config) *Transport {
+	if config.SendQueueSize == 0 {
+		config.SendQueueSize = DefaultSendQueueSize
+	}
+
+	if config.MaxMsgSize == 0 {
+		config.MaxMsgSize = DefaultMaxMsgSize
+	}
+
+	if config.MinReconnectBackoff == 0 {
+		config.MinReconnectBackoff = DefaultMinReconnectBackoff
+	}
+
+	if config.MaxReconnectBackoff == 0 {
+		config.MaxReconnectBackoff = DefaultMaxReconnectBackoff
+	}
+
+	if config.Logger == nil {
+		config.Logger = mirbft.ConsoleWarnLogger
+	}
+
+	var peerCerts *peerCertificates
+	if config.TLS != nil {
+		peerCerts = newPeerCertificates(config.TLS.PeerCertificates)
+	}
+
+	return &Transport{
+		config:    config,
+		peerCerts: peerCerts,
+		peers:     map[uint64]*peer{},
+		conns:     map[net.Conn]struct{}{},
+		inbound:   map[uint64]map[net.Conn]struct{}{},
+		doneC:     make(chan struct{}),
+	}
+}
+
+// Start begins listening for inbound connections, delivering the messages
+// received to the stepper (usually a *mirbft.Node), and begins connecting to
+// the configured peers.
+func (t *Transport) Start(stepper Stepper) error {
+	listener, err := net.Listen("tcp", t.config.ListenAddress)
+	if err != nil {
+		return errors.WithMessagef(err, "could not listen on %s", t.config.ListenAddress)
+	}
+
+	if t.config.TLS != nil {
+		listener = tls.NewListener(listener, t.config.TLS.serverConfig())
+	}
+
+	t.stepper = stepper
+	t.listener = listener
+
+	t.waitGroup.Add(1)
+	go func() {
+		defer t.waitGroup.Done()
+		t.serviceListener()
+	}()
+
+	for id, address := range t.config.Peers {
+		t.AddPeer(id, address)
+	}
+
+	return nil
+}
+
+// Addr returns the address the transport is listening on.  It is only
+// valid after Start returns successfully.
+func (t *Transport) Addr() net.Addr {
+	return t.listener.Addr()
+}
+
+// AddPeer begins connecting to the given node at the given address.  If the
+// peer is already known, the old connection is closed and replaced.
+func (t *Transport) AddPeer(id uint64, address string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	select {
+	case <-t.doneC:
+		return
+	default:
+	}
+
+	if oldPeer, ok := t.peers[id]; ok {
+		oldPeer.stop()
+	}
+
+	p := &peer{
+		id:        id,
+		address:   address,
+		transport: t,
+		sendC:     make(chan *pb.Msg, t.config.SendQueueSize),
+		doneC:     make(chan struct{}),
+	}
+	t.peers[id] = p
+
+	t.waitGroup.Add(1)
+	go func() {
+		defer t.waitGroup.Done()
+		p.run()
+	}()
+}
+
+// SetPeerCertificate sets the certificate which the given node must present
+// when TLS is enabled.  It should be called before AddPeer for any node not
+// included in the initial TLS configuration.
+func (t *Transport) SetPeerCertificate(id uint64, cert *x509.Certificate) {
+	if t.peerCerts == nil {
+		return
+	}
+
+	t.peerCerts.set(id, cert)
+}
+
+// RemovePeer stops sending to the given node and closes the connections to
+// and from it.  If TLS is enabled, the certificate for the node is also forgotten.
+func (t *Transport) RemovePeer(id uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.peerCerts != nil {
+		t.peerCerts.remove(id)
+	}
+
+	for conn := range t.inbound[id] {
+		conn.Close()
+	}
+	delete(t.inbound, id)
+
+	p, ok := t.peers[id]
+	if !ok {
+		return
+	}
+
+	p.stop()
+	delete(t.peers, id)
+}
+
+// Send enqueues the message to be sent to the destination node.  It never
+// blocks.  If the destination is this node, the message is delivered directly
+// to the stepper.  If the destination is unknown, or the send queue for the
+// destination is full, the message is dropped.  An error is returned only
+// if the transport has been stopped.
+func (t *Transport) Send(dest uint64, msg *pb.Msg) error {
+	select {
+	case <-t.doneC:
+		return ErrStopped
+	default:
+	}
+
+	if dest == t.config.ID {
+		if err := t.stepper.Step(context.Background(), dest, msg); err != nil {
+			return errors.WithMessage(err, "could not step message to self")
+		}
+		return nil
+	}
+
+	t.mutex.Lock()
+	p, ok := t.peers[dest]
+	t.mutex.Unlock()
+
+	if !ok {
+		t.config.Logger.Log(mirbft.LevelWarn, "dropping message for unknown peer", "dest", dest, "type", fmt.Sprintf("%T", msg.Type))
+		return nil
+	}
+
+	select {
+	case p.sendC <- msg:
+	default:
+		t.config.Logger.Log(mirbft.LevelWarn, "dropping message, send queue is full", "dest", dest, "type", fmt.Sprintf("%T", msg.Type))
+	}
+
+	return nil
+}
+
+// Stop closes all connections and the listener, and waits for all
+// go routines associated with the transport to exit.
+func (t *Transport) Stop() {
+	t.mutex.Lock()
+	select {
+	case <-t.doneC:
+		t.mutex.Unlock()
+		return
+	default:
+	}
+	close(t.doneC)
+
+	for _, p := range t.peers {
+		p.stop()
+	}
+
+	for conn := range t.conns {
+		conn.Close()
+	}
+	t.mutex.Unlock()
+
+	if t.listener != nil {
+		t.listener.Close()
+	}
+
+	t.waitGroup.Wait()
+}
+
+// trackConn registers an inbound connection so that it is closed at stop.
+// It returns false if the transport is already stopped.
+func (t *Transport) trackConn(conn net.Conn) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	select {
+	case <-t.doneC:
+		return false
+	default:
+	}
+
+	t.conns[conn] = struct{}{}
+	return true
+}
+
+func (t *Transport) untrackConn(conn net.Conn) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.conns, conn)
+}
+
+// trackInbound registers an authenticated inbound connection from the given
+// peer so that it is closed if the peer is removed.  It returns false if
+// the peer was removed while the connection was being authenticated.
+func (t *Transport) trackInbound(source uint64, conn net.Conn) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.peers[source]; !ok {
+		return false
+	}
+
+	conns, ok := t.inbound[source]
+	if !ok {
+		conns = map[net.Conn]struct{}{}
+		t.inbound[source] = conns
+	}
+	conns[conn] = struct{}{}
+	return true
+}
+
+func (t *Transport) untrackInbound(source uint64, conn net.Conn) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	conns, ok := t.inbound[source]
+	if !ok {
+		return
+	}
+
+	delete(conns, conn)
+	if len(conns) == 0 {
+		delete(t.inbound, source)
+	}
+}
+
+func (t *Transport) serviceListener() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			select {
+			case <-t.doneC:
+			default:
+				t.config.Logger.Log(mirbft.LevelError, "listener failed, no longer accepting connections", "error", err)
+			}
+			return
+		}
+
+		if !t.trackConn(conn) {
+			conn.Close()
+			return
+		}
+
+		t.waitGroup.Add(1)
+		go func() {
+			defer t.waitGroup.Done()
+			defer t.untrackConn(conn)
+			defer conn.Close()
+
+			err := t.serviceInbound(conn)
+			select {
+			case <-t.doneC:
+			default:
+				t.config.Logger.Log(mirbft.LevelInfo, "inbound connection closed", "remote_addr", conn.RemoteAddr(), "error", err)
+			}
+		}()
+	}
+}
+
+func (t *Transport) serviceInbound(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+
+	source, err := t.authenticateInbound(conn, reader)
+	if err != nil {
+		t.config.Logger.Log(mirbft.LevelWarn, "rejecting inbound connection", "remote_addr", conn.RemoteAddr(), "error", err)
+		return errors.WithMessage(err, "handshake failed")
+	}
+
+	if !t.trackInbound(source, conn) {
+		return errors.Errorf("node %d was removed during the handshake", source)
+	}
+	defer t.untrackInbound(source, conn)
+
+	t.config.Logger.Log(mirbft.LevelDebug, "accepted inbound connection", "source", source, "remote_addr", conn.RemoteAddr())
+
+	buffer := make([]byte, 0, 1024)
+	for {
+		msg := &pb.Msg{}
+		buffer, err = readFrame(reader, buffer, t.config.MaxMsgSize)
+		if err != nil {
+			return err
+		}
+
+		if err := proto.Unmarshal(buffer, msg); err != nil {
+			return errors.WithMessagef(err, "could not unmarshal message from %d", source)
+		}
+
+		err := t.stepper.Step(context.Background(), source, msg)
+		if err == nil {
+			continue
+		}
+
+		select {
+		case <-t.doneC:
+			return nil
+		default:
+		}
+
+		select {
+		case <-t.stepper.Err():
+			// The node exited, whether it was stopped or stopped with
+			// an error, and the remote peer is not to blame.
+			return errors.WithMessage(err, "node exited")
+		default:
+		}
+
+		// The message was malformed, the remote peer is misbehaving
+		return errors.WithMessagef(err, "could not step message from %d", source)
+	}
+}
+
+// authenticateInbound performs the TLS handshake if TLS is enabled, then
+// reads the node ID claimed by the remote side, and finally, if TLS is enabled,
+// verifies that the certificate presented belongs to the claimed node.
+func (t *Transport) authenticateInbound(conn net.Conn, reader io.Reader) (uint64, error) {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return 0, errors.WithMessage(err, "could not set handshake deadline")
+	}
+
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			return 0, errors.WithMessage(err, "TLS handshake failed")
+		}
+	}
+
+	source, err := t.acceptHandshake(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if t.peerCerts != nil {
+		if err := t.peerCerts.verifyInbound(conn, source); err != nil {
+			return 0, errors.WithMessagef(err, "remote side could not be authenticated as node %d", source)
+		}
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return 0, errors.WithMessage(err, "could not clear handshake deadline")
+	}
+
+	return source, nil
+}
+
+// acceptHandshake reads the node ID claimed by the remote side of the
+// connection and ensures that it is a known peer.
+func (t *Transport) acceptHandshake(reader io.Reader) (uint64, error) {
+	idBuf := make([]byte, 8)
+	if _, err := io.ReadFull(reader, idBuf); err != nil {
+		return 0, errors.WithMessage(err, "could not read node id")
+	}
+
+	source := binary.BigEndian.Uint64(idBuf)
+
+	t.mutex.Lock()
+	_, ok := t.peers[source]
+	t.mutex.Unlock()
+
+	if !ok {
+		return 0, errors.Errorf("connection claims to be from unknown node %d", source)
+	}
+
+	return source, nil
+}
+
+func (t *Transport) sendHandshake(writer io.Writer) error {
+	idBuf := make([]byte, 8)
+	binary.BigEndian.PutUint64(idBuf, t.config.ID)
+	_, err := writer.Write(idBuf)
+	return err
+}
+
+// peer manages the outbound connection to a single remote node.
+type peer struct {
+	id        uint64
+	address   string
+	transport *Transport
+	sendC     chan *pb.Msg
+
+	stopOnce sync.Once
+	doneC    chan struct{}
+}
+
+func (p *peer) stop() {
+	p.stopOnce.Do(func() {
+		close(p.doneC)
+	})
+}
+
+// run repeatedly connects to the peer, and writes messages from the send
+// queue to it until the connection fails or the peer is stopped.
+func (p *peer) run() {
+	config := p.transport.config
+	backoff := config.MinReconnectBackoff
+
+	for {
+		conn, err := p.connect()
+		if err == nil {
+			backoff = config.MinReconnectBackoff
+			err = p.serviceOutbound(conn)
+			conn.Close()
+		}
+
+		select {
+		case <-p.doneC:
+			return
+		default:
+		}
+
+		config.Logger.Log(mirbft.LevelInfo, "connection to peer failed, reconnecting", "dest", p.id, "address", p.address, "backoff", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-p.doneC:
+			return
+		}
+
+		backoff *= 2
+		if backoff > config.MaxReconnectBackoff {
+			backoff = config.MaxReconnectBackoff
+		}
+	}
+}
+
+func (p *peer) connect() (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: p.transport.config.MaxReconnectBackoff,
+	}
+
+	conn, err := dialer.Dial("tcp", p.address)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not dial %s", p.address)
+	}
+
+	if tlsConfig := p.transport.config.TLS; tlsConfig != nil {
+		tlsConn := tls.Client(conn, tlsConfig.clientConfig(p.id, p.transport.peerCerts))
+		if err := tlsConn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+			tlsConn.Close()
+			return nil, errors.WithMessage(err, "could not set handshake deadline")
+		}
+
+		if err := tlsConn.Handshake(); err != nil {
+			tlsConn.Close()
+			return nil, errors.WithMessagef(err, "TLS handshake with %s failed", p.address)
+		}
+
+		if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+			tlsConn.Close()
+			return nil, errors.WithMessage(err, "could not clear handshake deadline")
+		}
+
+		conn = tlsConn
+	}
+
+	if err := p.transport.sendHandshake(conn); err != nil {
+		conn.Close()
+		return nil, errors.WithMessage(err, "could not send handshake")
+	}
+
+	return conn, nil
+}
+
+func (p *peer) serviceOutbound(conn net.Conn) error {
+	// We never expect to read anything from an outbound connection,
+	// but reading allows us to detect that the remote side has closed.
+	closedC := make(chan struct{})
+	go func() {
+		io.Copy(ioutil.Discard, conn)
+		close(closedC)
+	}()
+	defer func() {
+		conn.Close()
+		<-closedC
+	}()
+
+	writer := bufio.NewWriter(conn)
+	for {
+		var msg *pb.Msg
+		select {
+		case msg = <-p.sendC:
+		case <-closedC:
+			return errors.Errorf("connection closed by remote")
+		case <-p.doneC:
+			return nil
+		}
+
+		if err := writeFrame(writer, msg); err != nil {
+			return err
+		}
+
+		// Opportunistically batch any other queued messages into the
+		// same flush.
+	drain:
+		for {
+			select {
+			case msg = <-p.sendC:
+				if err := writeFrame(writer, msg); err != nil {
+					return err
+				}
+			default:
+				break drain
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return errors.WithMessage(err, "could not flush to connection")
+		}
+	}
+}
+
+// writeFrame writes the message to the writer, prefixed by its length
+// as a four byte, big endian, unsigned integer.
+func writeFrame(writer io.Writer, msg proto.Message) error {
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal")
+	}
+
+	lenBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenBuf, uint32(len(msgBytes)))
+	if _, err = writer.Write(lenBuf); err != nil {
+		return errors.WithMessage(err, "could not write length prefix")
+	}
+
+	if _, err = writer.Write(msgBytes); err != nil {
+		return errors.WithMessage(err, "could not write message")
+	}
+
+	return nil
+}
+
+// readFrame reads a single length prefixed frame into the buffer, growing
+// it as required, and returns the buffer resliced to the frame contents.
+func readFrame(reader io.Reader, buffer []byte, maxSize uint32) ([]byte, error) {
+	lenBuf := make([]byte, 4)
+	if _, err := io.ReadFull(reader, lenBuf); err != nil {
+		return buffer, errors.WithMessage(err, "could not read length prefix")
+	}
+
+	l := binary.BigEndian.Uint32(lenBuf)
+	if l > maxSize {
+		return buffer, errors.Errorf("message of size %d exceeds maximum of %d", l, maxSize)
+	}
+
+	if uint32(cap(buffer)) < l {
+		buffer = make([]byte, l)
+	}
+	buffer = buffer[:l]
+
+	if _, err := io.ReadFull(reader, buffer); err != nil {
+		return buffer, errors.WithMessage(err, "could not read message")
+	}
+
+	return buffer, nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	"github.com/IBM/mirbft"
+	pb "github.com/IBM/mirbft/mirbftpb"
+	"github.com/IBM/mirbft/transport"
+)
+
+type receivedMsg struct {
+	source uint64
+	msg    *pb.Msg
+}
+
+type fakeStepper struct {
+	mutex    sync.Mutex
+	received []receivedMsg
+	exitErr  error
+	errC     chan struct{}
+}
+
+func (fs *fakeStepper) Step(ctx context.Context, source uint64, msg *pb.Msg) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if fs.exitErr != nil {
+		return fs.exitErr
+	}
+	fs.received = append(fs.received, receivedMsg{source: source, msg: msg})
+	return nil
+}
+
+func (fs *fakeStepper) Err() <-chan struct{} {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	return fs.errChan()
+}
+
+// Exit mimics a node which was stopped with the given error.
+func (fs *fakeStepper) Exit(err error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	fs.exitErr = err
+	close(fs.errChan())
+}
+
+func (fs *fakeStepper) errChan() chan struct{} {
+	if fs.errC == nil {
+		fs.errC = make(chan struct{})
+	}
+	return fs.errC
+}
+
+func (fs *fakeStepper) Received() []receivedMsg {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	return append([]receivedMsg(nil), fs.received...)
+}
+
+func (fs *fakeStepper) SeqNosFrom(source uint64) []uint64 {
+	var result []uint64
+	for _, rm := range fs.Received() {
+		if rm.source != source {
+			continue
+		}
+		result = append(result, rm.msg.Type.(*pb.Msg_Checkpoint).Checkpoint.SeqNo)
+	}
+	return result
+}
+
+type recordingLogger struct {
+	mutex   sync.Mutex
+	entries []string
+}
+
+func (rl *recordingLogger) Log(level mirbft.LogLevel, text string, args ...interface{}) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	rl.entries = append(rl.entries, fmt.Sprint(append([]interface{}{text}, args...)...))
+}
+
+func (rl *recordingLogger) Matching(substring string) []string {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	var result []string
+	for _, entry := range rl.entries {
+		if strings.Contains(entry, substring) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func checkpointMsg(seqNo uint64) *pb.Msg {
+	return &pb.Msg{
+		Type: &pb.Msg_Checkpoint{
+			Checkpoint: &pb.Checkpoint{
+				SeqNo: seqNo,
+				Value: []byte(fmt.Sprintf("value-%d", seqNo)),
+			},
+		},
+	}
+}
+
+var _ = Describe("Transport", func() {
+	var (
+		nodeCount  = 4
+		transports []*transport.Transport
+		steppers   []*fakeStepper
+		loggers    []*recordingLogger
+	)
+
+	BeforeEach(func() {
+		transports = make([]*transport.Transport, nodeCount)
+		steppers = make([]*fakeStepper, nodeCount)
+		loggers = make([]*recordingLogger, nodeCount)
+
+		for i := 0; i < nodeCount; i++ {
+			loggers[i] = &recordingLogger{}
+			transports[i] = transport.New(transport.Config{
+				ID:            uint64(i),
+				ListenAddress: "127.0.0.1:0",
+				Logger:        loggers[i],
+			})
+			steppers[i] = &fakeStepper{}
+			err := transports[i].Start(steppers[i])
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for i, t := range transports {
+			for j, peer := range transports {
+				if i == j {
+					continue
+				}
+				t.AddPeer(uint64(j), peer.Addr().String())
+			}
+		}
+	})
+
+	AfterEach(func() {
+		for _, t := range transports {
+			t.Stop()
+		}
+	})
+
+	It("delivers messages between all nodes in order", func() {
+		for seqNo := uint64(1); seqNo <= 100; seqNo++ {
+			for i, t := range transports {
+				for j := range transports {
+					if i == j {
+						continue
+					}
+					t.Send(uint64(j), checkpointMsg(seqNo))
+				}
+			}
+		}
+
+		for i := range transports {
+			for j := range transports {
+				if i == j {
+					continue
+				}
+				Eventually(func() int {
+					return len(steppers[j].SeqNosFrom(uint64(i)))
+				}).Should(Equal(100))
+
+				seqNos := steppers[j].SeqNosFrom(uint64(i))
+				for k, seqNo := range seqNos {
+					Expect(seqNo).To(Equal(uint64(k + 1)))
+				}
+			}
+		}
+	})
+
+	It("delivers messages to self directly", func() {
+		transports[0].Send(0, checkpointMsg(7))
+		Expect(steppers[0].Received()).To(HaveLen(1))
+		Expect(steppers[0].Received()[0].source).To(Equal(uint64(0)))
+	})
+
+	It("drops messages for unknown peers", func() {
+		transports[0].Send(9, checkpointMsg(1))
+		transports[0].Send(1, checkpointMsg(2))
+		Eventually(func() []uint64 {
+			return steppers[1].SeqNosFrom(0)
+		}).Should(Equal([]uint64{2}))
+	})
+
+	When("a peer restarts", func() {
+		It("reconnects and resumes delivery", func() {
+			transports[0].Send(1, checkpointMsg(1))
+			Eventually(func() []uint64 {
+				return steppers[1].SeqNosFrom(0)
+			}).Should(Equal([]uint64{1}))
+
+			address := transports[1].Addr().String()
+			transports[1].Stop()
+
+			transports[1] = transport.New(transport.Config{
+				ID:            1,
+				ListenAddress: address,
+			})
+			steppers[1] = &fakeStepper{}
+			err := transports[1].Start(steppers[1])
+			Expect(err).NotTo(HaveOccurred())
+			transports[1].AddPeer(0, transports[0].Addr().String())
+
+			// Messages sent while the connection is down may be lost,
+			// so keep sending until one is received.
+			Eventually(func() int {
+				transports[0].Send(1, checkpointMsg(2))
+				return len(steppers[1].SeqNosFrom(0))
+			}).ShouldNot(BeZero())
+		})
+	})
+
+	When("a peer is removed", func() {
+		It("stops accepting messages from it", func() {
+			transports[1].Send(0, checkpointMsg(1))
+			Eventually(func() []uint64 {
+				return steppers[0].SeqNosFrom(1)
+			}).Should(Equal([]uint64{1}))
+
+			transports[0].RemovePeer(1)
+
+			Consistently(func() []uint64 {
+				transports[1].Send(0, checkpointMsg(2))
+				return steppers[0].SeqNosFrom(1)
+			}).Should(Equal([]uint64{1}))
+		})
+	})
+
+	When("the node exits with an error", func() {
+		It("closes inbound connections without blaming the peers", func() {
+			steppers[1].Exit(errors.New("disk full"))
+			transports[0].Send(1, checkpointMsg(1))
+
+			Eventually(func() []string {
+				return loggers[1].Matching("inbound connection closed")
+			}).ShouldNot(BeEmpty())
+			Expect(loggers[1].Matching("inbound connection closed")[0]).To(ContainSubstring("node exited: disk full"))
+			Expect(loggers[1].Matching("could not step message")).To(BeEmpty())
+		})
+	})
+
+	When("connections come from unknown nodes", func() {
+		It("rejects them", func() {
+			stranger := transport.New(transport.Config{
+				ID:            99,
+				ListenAddress: "127.0.0.1:0",
+			})
+			err := stranger.Start(&fakeStepper{})
+			Expect(err).NotTo(HaveOccurred())
+			defer stranger.Stop()
+
+			stranger.AddPeer(0, transports[0].Addr().String())
+			for i := uint64(0); i < 10; i++ {
+				stranger.Send(0, checkpointMsg(i))
+			}
+
+			Consistently(func() []receivedMsg {
+				return steppers[0].Received()
+			}).Should(BeEmpty())
+		})
+	})
+})